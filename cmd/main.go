@@ -3,9 +3,11 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -15,22 +17,86 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/m04kA/SMC-UserService/internal/config"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/accept_invitation"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/batch_get_selected_cars"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/batch_get_users"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/change_user_role"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/change_user_status"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/create_car"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/create_company"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/create_invitation"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/create_role"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/create_service_credential"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/create_user"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/delete_car"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/delete_current_user"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/export_current_user"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/find_cars_by_plate"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_car"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_current_user"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_my_companies"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_my_permissions"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_selected_car"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_superusers"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_user_by_id"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_users_by_phone"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/list_cars"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/list_companies"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/list_impersonation_audit"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/list_invitations"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/list_permissions"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/list_plate_claims"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/list_plate_lookup_audit"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/list_roles"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/list_service_credentials"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/list_users"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/lookup_company_cars"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/remove_company_member"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/request_phone_verification"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/resolve_plate_claim"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/restore_current_user"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/revoke_invitation"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/revoke_service_credential"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/select_car"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/set_company_member"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/set_role_permissions"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/update_car"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/update_current_user"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/verify_phone"
 	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/infra/notify"
+	"github.com/m04kA/SMC-UserService/internal/infra/sms"
 	carrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/car"
+	companyrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/company"
+	exportrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/export"
+	impersonationrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/impersonation"
+	invitationrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/invitation"
+	phonerepo "github.com/m04kA/SMC-UserService/internal/infra/storage/phone"
+	plateclaimrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/plateclaim"
+	platelookuprepo "github.com/m04kA/SMC-UserService/internal/infra/storage/platelookup"
+	ratelimitrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/ratelimit"
+	rolerepo "github.com/m04kA/SMC-UserService/internal/infra/storage/role"
+	credentialrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/servicecredential"
+	servicenoncerepo "github.com/m04kA/SMC-UserService/internal/infra/storage/servicenonce"
 	userrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/user"
+	"github.com/m04kA/SMC-UserService/internal/service/company"
+	"github.com/m04kA/SMC-UserService/internal/service/export"
+	"github.com/m04kA/SMC-UserService/internal/service/impersonation"
+	"github.com/m04kA/SMC-UserService/internal/service/invitation"
+	"github.com/m04kA/SMC-UserService/internal/service/phone"
+	"github.com/m04kA/SMC-UserService/internal/service/plateclaim"
+	"github.com/m04kA/SMC-UserService/internal/service/platelookup"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac"
+	"github.com/m04kA/SMC-UserService/internal/service/serviceauth"
 	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/pkg/dbtx"
+	"github.com/m04kA/SMC-UserService/pkg/jwtkeys"
 	"github.com/m04kA/SMC-UserService/pkg/logger"
+	"github.com/m04kA/SMC-UserService/pkg/ratelimit"
+	"github.com/m04kA/SMC-UserService/pkg/secretbox"
+	"github.com/m04kA/SMC-UserService/pkg/telegram"
 )
 
 func main() {
@@ -52,6 +118,11 @@ func main() {
 	log.Info("Starting SMC-UserService...")
 	log.Info("Configuration loaded from config.toml")
 
+	// Каталог сообщений должен покрывать все коды ошибок на всех языках
+	if err := api.CheckMessages(); err != nil {
+		log.Fatal("Message catalog is incomplete: %v", err)
+	}
+
 	// Подключаемся к базе данных
 	db, err := sqlx.Connect("postgres", cfg.Database.DSN())
 	if err != nil {
@@ -69,58 +140,270 @@ func main() {
 	// Инициализируем репозитории
 	userRepo := userrepo.NewRepository(db)
 	carRepo := carrepo.NewRepository(db)
+	credentialRepo := credentialrepo.NewRepository(db)
+	roleRepo := rolerepo.NewRepository(db)
+	auditRepo := impersonationrepo.NewRepository(db)
+	exportRepo := exportrepo.NewRepository(db)
+	phoneRepo := phonerepo.NewRepository(db)
+	companyRepo := companyrepo.NewRepository(db)
+	invitationRepo := invitationrepo.NewRepository(db)
+	plateLookupRepo := platelookuprepo.NewRepository(db)
+	plateClaimRepo := plateclaimrepo.NewRepository(db)
 
-	// Инициализируем сервис
-	service := userservice.NewUserService(userRepo, carRepo)
+	// Инициализируем сервисы
+	rbacService := rbac.NewService(roleRepo, time.Duration(cfg.RBAC.CacheTTL)*time.Second)
+	deletionRetention := time.Duration(cfg.Deletion.RetentionDays) * 24 * time.Hour
+	txManager := dbtx.NewManager(db, cfg.Database.TxMaxAttempts)
+	service := userservice.NewUserService(userRepo, carRepo, plateClaimRepo, rbacService, txManager, deletionRetention, userservice.PhoneConfig{
+		DefaultRegion:   cfg.PhoneNumbers.DefaultRegion,
+		DuplicatePolicy: userservice.DuplicatePhonePolicy(cfg.PhoneNumbers.DuplicatePolicy),
+	}, userservice.PlateConfig{
+		DuplicatePolicy: userservice.DuplicatePlatePolicy(cfg.LicensePlates.DuplicatePolicy),
+	})
+	keyCipher, err := newKeyCipher(cfg.InternalAuth)
+	if err != nil {
+		log.Fatal("Failed to configure service key encryption: %v", err)
+	}
+	serviceAuthService := serviceauth.NewService(credentialRepo, keyCipher)
+	if keyCipher != nil {
+		encrypted, err := serviceAuthService.EncryptLegacyKeys(context.Background())
+		if err != nil {
+			log.Fatal("Failed to encrypt service signing keys: %v", err)
+		}
+		if encrypted > 0 {
+			log.Info("Service credentials: encrypted %d legacy signing keys", encrypted)
+		}
+	} else {
+		log.Warn("Service credentials: key_encryption_key is not set, service keys cannot be issued or verified")
+	}
+	auditService := impersonation.NewService(auditRepo)
+	exportService := export.NewService(service, exportRepo)
+	phoneService := phone.NewService(service, phoneRepo, newPhoneSender(cfg.Phone, log), phone.Config{
+		CodeLength:     cfg.Phone.CodeLength,
+		CodeTTL:        time.Duration(cfg.Phone.CodeTTL) * time.Second,
+		MaxAttempts:    cfg.Phone.MaxAttempts,
+		ResendInterval: time.Duration(cfg.Phone.ResendInterval) * time.Second,
+	})
+	log.Info("Phone verification: sender=%s", cfg.Phone.Sender)
+	companyService := company.NewService(companyRepo, service)
+	plateLookupService := platelookup.NewService(service, companyService, rbacService, plateLookupRepo)
+	plateClaimService := plateclaim.NewService(plateClaimRepo, carRepo, companyService, rbacService, txManager, newNotifier(cfg.Notifications, log))
+	log.Info("License plates: duplicate_policy=%s, notifications sender=%s", cfg.LicensePlates.DuplicatePolicy, cfg.Notifications.Sender)
+	invitationService := invitation.NewService(invitationRepo, service, companyService, rbacService, txManager, invitation.Config{
+		BotUsername: cfg.Invitations.BotUsername,
+		AppName:     cfg.Invitations.AppName,
+		DefaultTTL:  time.Duration(cfg.Invitations.DefaultTTL) * time.Second,
+		MaxTTL:      time.Duration(cfg.Invitations.MaxTTL) * time.Second,
+	})
 
 	// Инициализируем handlers
 	createUserHandler := create_user.NewHandler(service, log)
 	getCurrentUserHandler := get_current_user.NewHandler(service, log)
 	updateCurrentUserHandler := update_current_user.NewHandler(service, log)
 	deleteCurrentUserHandler := delete_current_user.NewHandler(service, log)
+	restoreCurrentUserHandler := restore_current_user.NewHandler(service, log)
+	exportCurrentUserHandler := export_current_user.NewHandler(exportService, log)
+	requestPhoneVerificationHandler := request_phone_verification.NewHandler(phoneService, log)
+	verifyPhoneHandler := verify_phone.NewHandler(phoneService, log)
 	createCarHandler := create_car.NewHandler(service, log)
+	listCarsHandler := list_cars.NewHandler(service, log)
+	getCarHandler := get_car.NewHandler(service, log)
 	updateCarHandler := update_car.NewHandler(service, log)
 	deleteCarHandler := delete_car.NewHandler(service, log)
 	getSelectedCarHandler := get_selected_car.NewHandler(service, log)
 	selectCarHandler := select_car.NewHandler(service, log)
-	getUserByIDHandler := get_user_by_id.NewHandler(service, log)
+	getUserByIDHandler := get_user_by_id.NewHandler(service, companyService, log)
+	getUsersByPhoneHandler := get_users_by_phone.NewHandler(service, log)
 	getSuperUsersHandler := get_superusers.NewHandler(service, log)
+	listUsersHandler := list_users.NewHandler(service, log)
+	batchGetUsersHandler := batch_get_users.NewHandler(service, log, cfg.InternalAPI.MaxBatchSize)
+	batchGetSelectedCarsHandler := batch_get_selected_cars.NewHandler(service, log, cfg.InternalAPI.MaxBatchSize)
+	changeUserRoleHandler := change_user_role.NewHandler(service, log)
+	changeUserStatusHandler := change_user_status.NewHandler(service, log)
+	createServiceCredentialHandler := create_service_credential.NewHandler(serviceAuthService, log)
+	listServiceCredentialsHandler := list_service_credentials.NewHandler(serviceAuthService, log)
+	revokeServiceCredentialHandler := revoke_service_credential.NewHandler(serviceAuthService, log)
+	getMyPermissionsHandler := get_my_permissions.NewHandler(rbacService, log)
+	listRolesHandler := list_roles.NewHandler(rbacService, log)
+	createRoleHandler := create_role.NewHandler(rbacService, log)
+	setRolePermissionsHandler := set_role_permissions.NewHandler(rbacService, log)
+	listPermissionsHandler := list_permissions.NewHandler(rbacService, log)
+	listImpersonationAuditHandler := list_impersonation_audit.NewHandler(auditService, log)
+	findCarsByPlateHandler := find_cars_by_plate.NewHandler(service, log)
+	lookupCompanyCarsHandler := lookup_company_cars.NewHandler(plateLookupService, log)
+	listPlateLookupAuditHandler := list_plate_lookup_audit.NewHandler(plateLookupService, log)
+	listPlateClaimsHandler := list_plate_claims.NewHandler(plateClaimService, log)
+	resolvePlateClaimHandler := resolve_plate_claim.NewHandler(plateClaimService, log)
+	createCompanyHandler := create_company.NewHandler(companyService, log)
+	listCompaniesHandler := list_companies.NewHandler(companyService, log)
+	setCompanyMemberHandler := set_company_member.NewHandler(companyService, log)
+	removeCompanyMemberHandler := remove_company_member.NewHandler(companyService, log)
+	getMyCompaniesHandler := get_my_companies.NewHandler(companyService, log)
+	createInvitationHandler := create_invitation.NewHandler(invitationService, log)
+	listInvitationsHandler := list_invitations.NewHandler(invitationService, log)
+	revokeInvitationHandler := revoke_invitation.NewHandler(invitationService, log)
+	acceptInvitationHandler := accept_invitation.NewHandler(invitationService, log)
 
 	// Настраиваем роутер
 	r := mux.NewRouter()
 
+	// Неизвестные пути и методы отвечают в формате application/problem+json
+	r.NotFoundHandler = api.NotFoundHandler()
+	r.MethodNotAllowedHandler = api.MethodNotAllowedHandler()
+
 	// Применяем metrics middleware ко всем роутам
 	r.Use(middleware.Metrics)
 
 	// Metrics endpoint
 	r.Handle("/metrics", promhttp.Handler()).Methods(http.MethodGet)
 
-	// Public routes
-	r.HandleFunc("/users", createUserHandler.Handle).Methods(http.MethodPost)
+	// Аутентификация: JWT, initData Telegram Mini App и (опционально) заголовки доверенного gateway
+	var authenticators []middleware.Authenticator
+	if cfg.Auth.TrustedGateway {
+		log.Warn("Auth: trusted_gateway mode enabled, X-User-ID/X-User-Role headers are trusted")
+		authenticators = append(authenticators, middleware.NewHeaderAuthenticator())
+	}
+	if len(cfg.Auth.Keys) > 0 {
+		keySet, err := loadKeySet(cfg.Auth)
+		if err != nil {
+			log.Fatal("Failed to load JWT keys: %v", err)
+		}
+		verifier := middleware.NewTokenVerifier(keySet, cfg.Auth.Issuer, cfg.Auth.Audience,
+			time.Duration(cfg.Auth.Leeway)*time.Second)
+		authenticators = append(authenticators, middleware.NewJWTAuthenticator(verifier))
+		log.Info("Auth: JWT enabled (%d keys)", len(cfg.Auth.Keys))
+	}
+	if cfg.Auth.Telegram.Enabled {
+		validator := telegram.NewValidator(cfg.Auth.Telegram.BotToken,
+			time.Duration(cfg.Auth.Telegram.MaxAge)*time.Second)
+		authenticators = append(authenticators, middleware.NewTelegramAuthenticator(validator, service))
+		log.Info("Auth: Telegram init data enabled (max_age=%ds)", cfg.Auth.Telegram.MaxAge)
+	}
+	authenticate := middleware.Authenticate(authenticators...)
+	requireActive := middleware.RequireActiveUser(service)
+	userLanguage := middleware.UserLanguage(service)
+
+	// Ограничение частоты запросов
+	limiter, err := newRateLimiter(cfg.RateLimit, db)
+	if err != nil {
+		log.Fatal("Failed to configure rate limiter: %v", err)
+	}
+	if cfg.RateLimit.Enabled {
+		log.Info("Rate limit: enabled (store=%s, routes=%d)", cfg.RateLimit.Store, len(cfg.RateLimit.Routes))
+	}
+
+	// Internal routes (для межсервисного взаимодействия, требуют подписи сервиса)
+	internal := r.PathPrefix("/internal").Subrouter()
+	if cfg.InternalAuth.Enabled {
+		var nonces middleware.NonceStore = middleware.NewMemoryNonceStore()
+		if cfg.InternalAuth.NonceStore == "postgres" {
+			nonces = servicenoncerepo.NewRepository(db)
+		}
+		internal.Use(middleware.ServiceAuth(serviceAuthService, nonces, time.Duration(cfg.InternalAuth.MaxClockSkew)*time.Second))
+		log.Info("Internal auth: enabled (nonce_store=%s)", cfg.InternalAuth.NonceStore)
+	} else {
+		log.Warn("Internal auth disabled: /internal routes are accessible without service credentials")
+	}
+	internal.Use(limiter.Limit("internal"))
+
+	internal.HandleFunc("/users", listUsersHandler.Handle).Methods(http.MethodGet)
+	internal.HandleFunc("/users:batchGet", batchGetUsersHandler.Handle).Methods(http.MethodPost)
+	internal.HandleFunc("/users/cars/selected:batchGet", batchGetSelectedCarsHandler.Handle).Methods(http.MethodPost)
+	internal.HandleFunc("/users/superusers", getSuperUsersHandler.Handle).Methods(http.MethodGet)
+	internal.HandleFunc("/users/by-phone/{phone}", getUsersByPhoneHandler.Handle).Methods(http.MethodGet)
+	internal.HandleFunc("/users/{tg_user_id}", getUserByIDHandler.Handle).Methods(http.MethodGet)
+	internal.HandleFunc("/users/{tg_user_id}/cars/selected", getSelectedCarHandler.Handle).Methods(http.MethodGet)
+	internal.HandleFunc("/cars", findCarsByPlateHandler.Handle).Methods(http.MethodGet)
+
+	// Admin routes (требуют соответствующего права роли)
+	admin := r.PathPrefix("/admin").Subrouter()
+	admin.Use(authenticate, requireActive, userLanguage)
+
+	readUsers := middleware.RequirePermission(rbacService, domain.PermUsersReadAny)
+	admin.Handle("/users", readUsers(http.HandlerFunc(listUsersHandler.Handle))).Methods(http.MethodGet)
+
+	assignRoles := middleware.RequirePermission(rbacService, domain.PermUsersRoleAssign)
+	admin.Handle("/users/{tg_user_id}/role", assignRoles(http.HandlerFunc(changeUserRoleHandler.Handle))).Methods(http.MethodPut)
+
+	updateStatus := middleware.RequirePermission(rbacService, domain.PermUsersStatusUpdate)
+	admin.Handle("/users/{tg_user_id}/status", updateStatus(http.HandlerFunc(changeUserStatusHandler.Handle))).Methods(http.MethodPut)
+
+	manageRoles := middleware.RequirePermission(rbacService, domain.PermRolesManage)
+	admin.Handle("/roles", manageRoles(http.HandlerFunc(listRolesHandler.Handle))).Methods(http.MethodGet)
+	admin.Handle("/roles", manageRoles(http.HandlerFunc(createRoleHandler.Handle))).Methods(http.MethodPost)
+	admin.Handle("/roles/{name}/permissions", manageRoles(http.HandlerFunc(setRolePermissionsHandler.Handle))).Methods(http.MethodPut)
+	admin.Handle("/permissions", manageRoles(http.HandlerFunc(listPermissionsHandler.Handle))).Methods(http.MethodGet)
+
+	readAudit := middleware.RequirePermission(rbacService, domain.PermAuditRead)
+	admin.Handle("/impersonation-audit", readAudit(http.HandlerFunc(listImpersonationAuditHandler.Handle))).Methods(http.MethodGet)
+	admin.Handle("/plate-lookup-audit", readAudit(http.HandlerFunc(listPlateLookupAuditHandler.Handle))).Methods(http.MethodGet)
+
+	resolveClaims := middleware.RequirePermission(rbacService, domain.PermCarsClaimsResolve)
+	admin.Handle("/plate-claims", resolveClaims(http.HandlerFunc(listPlateClaimsHandler.Handle))).Methods(http.MethodGet)
+	admin.Handle("/plate-claims/{claim_id}/resolve", resolveClaims(http.HandlerFunc(resolvePlateClaimHandler.Handle))).Methods(http.MethodPost)
 
-	// Internal routes (для межсервисного взаимодействия)
-	r.HandleFunc("/internal/users/superusers", getSuperUsersHandler.Handle).Methods(http.MethodGet)
-	r.HandleFunc("/internal/users/{tg_user_id}", getUserByIDHandler.Handle).Methods(http.MethodGet)
-	r.HandleFunc("/internal/users/{tg_user_id}/cars/selected", getSelectedCarHandler.Handle).Methods(http.MethodGet)
+	manageServiceCreds := middleware.RequirePermission(rbacService, domain.PermServiceCredsManage)
+	admin.Handle("/service-credentials", manageServiceCreds(http.HandlerFunc(createServiceCredentialHandler.Handle))).Methods(http.MethodPost)
+	admin.Handle("/service-credentials", manageServiceCreds(http.HandlerFunc(listServiceCredentialsHandler.Handle))).Methods(http.MethodGet)
+	admin.Handle("/service-credentials/{id}", manageServiceCreds(http.HandlerFunc(revokeServiceCredentialHandler.Handle))).Methods(http.MethodDelete)
 
-	// Protected routes (требуют заголовок X-User-ID)
+	manageCompanies := middleware.RequirePermission(rbacService, domain.PermCompaniesManage)
+	admin.Handle("/companies", manageCompanies(http.HandlerFunc(createCompanyHandler.Handle))).Methods(http.MethodPost)
+	admin.Handle("/companies", manageCompanies(http.HandlerFunc(listCompaniesHandler.Handle))).Methods(http.MethodGet)
+	admin.Handle("/companies/{company_id}/members/{tg_user_id}", manageCompanies(http.HandlerFunc(setCompanyMemberHandler.Handle))).Methods(http.MethodPut)
+	admin.Handle("/companies/{company_id}/members/{tg_user_id}", manageCompanies(http.HandlerFunc(removeCompanyMemberHandler.Handle))).Methods(http.MethodDelete)
+
+	// Protected routes (требуют аутентификации пользователя с активным аккаунтом)
 	protected := r.PathPrefix("").Subrouter()
-	protected.Use(middleware.UserIDAuth)
+	protected.Use(authenticate, requireActive, userLanguage)
+	if cfg.Impersonation.Enabled {
+		protected.Use(middleware.Impersonate(rbacService, service, auditService, cfg.Impersonation.ReadOnly))
+		log.Info("Impersonation: enabled via %s header (read_only=%t)", middleware.HeaderActAs, cfg.Impersonation.ReadOnly)
+	}
+
+	// Регистрация: пользователь может зарегистрировать только собственный Telegram аккаунт
+	protected.Handle("/users", limiter.Limit("register")(http.HandlerFunc(createUserHandler.Handle))).Methods(http.MethodPost)
 
 	protected.HandleFunc("/users/me", getCurrentUserHandler.Handle).Methods(http.MethodGet)
 	protected.HandleFunc("/users/me", updateCurrentUserHandler.Handle).Methods(http.MethodPut)
 	protected.HandleFunc("/users/me", deleteCurrentUserHandler.Handle).Methods(http.MethodDelete)
+	protected.HandleFunc("/users/me/restore", restoreCurrentUserHandler.Handle).Methods(http.MethodPost)
+	protected.Handle("/users/me/export", limiter.Limit("export")(http.HandlerFunc(exportCurrentUserHandler.Handle))).Methods(http.MethodGet)
+	protected.Handle("/users/me/phone/verification", limiter.Limit("phone_verification")(http.HandlerFunc(requestPhoneVerificationHandler.Handle))).Methods(http.MethodPost)
+	protected.HandleFunc("/users/me/phone/verify", verifyPhoneHandler.Handle).Methods(http.MethodPost)
+	protected.HandleFunc("/users/me/permissions", getMyPermissionsHandler.Handle).Methods(http.MethodGet)
+	protected.HandleFunc("/users/me/companies", getMyCompaniesHandler.Handle).Methods(http.MethodGet)
+
+	// Приглашения создают пользователи с правом users:role:assign и владельцы компаний (проверяется сервисом)
+	protected.HandleFunc("/invitations", createInvitationHandler.Handle).Methods(http.MethodPost)
+	protected.HandleFunc("/invitations", listInvitationsHandler.Handle).Methods(http.MethodGet)
+	protected.HandleFunc("/invitations/{id}", revokeInvitationHandler.Handle).Methods(http.MethodDelete)
+	protected.HandleFunc("/invitations/{token}/accept", acceptInvitationHandler.Handle).Methods(http.MethodPost)
+
+	// Поиск по госномеру от имени компании: менеджер только своей компании (проверяется сервисом), каждый поиск в журнале
+	lookupCars := middleware.RequirePermission(rbacService, domain.PermCarsLookupCompany)
+	protected.Handle("/companies/{company_id}/cars", lookupCars(http.HandlerFunc(lookupCompanyCarsHandler.Handle))).Methods(http.MethodGet)
 
-	protected.HandleFunc("/users/me/cars", createCarHandler.Handle).Methods(http.MethodPost)
+	protected.Handle("/users/me/cars", limiter.Limit("create_car")(http.HandlerFunc(createCarHandler.Handle))).Methods(http.MethodPost)
+	protected.HandleFunc("/users/me/cars", listCarsHandler.Handle).Methods(http.MethodGet)
+	protected.HandleFunc("/users/me/cars/{car_id}", getCarHandler.Handle).Methods(http.MethodGet)
 	protected.HandleFunc("/users/me/cars/{car_id}", updateCarHandler.Handle).Methods(http.MethodPatch)
 	protected.HandleFunc("/users/me/cars/{car_id}", deleteCarHandler.Handle).Methods(http.MethodDelete)
 	protected.HandleFunc("/users/me/cars/{car_id}/select", selectCarHandler.Handle).Methods(http.MethodPut)
 
+	// Фоновая очистка удаленных аккаунтов с истекшим сроком восстановления
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	defer stopPurge()
+	if cfg.Deletion.PurgeEnabled {
+		go runDeletionPurge(purgeCtx, service, time.Duration(cfg.Deletion.PurgeInterval)*time.Second, log)
+		log.Info("Deletion purge: enabled (retention=%dd, interval=%ds)", cfg.Deletion.RetentionDays, cfg.Deletion.PurgeInterval)
+	}
+
 	// Создаем HTTP сервер
 	addr := fmt.Sprintf(":%d", cfg.Server.HTTPPort)
 	srv := &http.Server{
 		Addr:         addr,
-		Handler:      r,
+		Handler:      middleware.RequestID(middleware.Language(cfg.Localization.DefaultLanguage)(r)),
 		ReadTimeout:  time.Duration(cfg.Server.ReadTimeout) * time.Second,
 		WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
 		IdleTimeout:  time.Duration(cfg.Server.IdleTimeout) * time.Second,
@@ -140,6 +423,7 @@ func main() {
 	<-quit
 
 	log.Info("Shutting down server...")
+	stopPurge()
 
 	shutdownCtx, cancel := context.WithTimeout(
 		context.Background(),
@@ -153,3 +437,119 @@ func main() {
 
 	log.Info("Server stopped gracefully")
 }
+
+// loadKeySet загружает ключи проверки JWT из конфигурации
+func loadKeySet(cfg config.AuthConfig) (*jwtkeys.KeySet, error) {
+	keys := make([]jwtkeys.Key, 0, len(cfg.Keys))
+	for _, kc := range cfg.Keys {
+		var publicPEM []byte
+		if kc.PublicKeyFile != "" {
+			data, err := os.ReadFile(kc.PublicKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: failed to read public key file: %w", kc.KID, err)
+			}
+			publicPEM = data
+		}
+
+		key, err := jwtkeys.ParseVerificationKey(kc.KID, kc.Alg, []byte(kc.Secret), publicPEM)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return jwtkeys.NewKeySet(keys...)
+}
+
+// newRateLimiter создает ограничитель частоты запросов из конфигурации.
+// При выключенном ограничении правила не задаются и middleware пропускает все запросы.
+func newRateLimiter(cfg config.RateLimitConfig, db *sqlx.DB) (*middleware.RateLimiter, error) {
+	rules := make(map[string]middleware.RateLimitRule)
+	if !cfg.Enabled {
+		return middleware.NewRateLimiter(nil, rules, nil), nil
+	}
+
+	for name, route := range cfg.Routes {
+		rules[name] = middleware.RateLimitRule{
+			Key:   middleware.RateLimitKey(route.Key),
+			Limit: ratelimit.Every(route.Requests, time.Duration(route.Period)*time.Second, route.Burst),
+		}
+	}
+
+	proxies := make([]*net.IPNet, 0, len(cfg.TrustedProxies))
+	for _, proxy := range cfg.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			if strings.Contains(proxy, ":") {
+				proxy += "/128"
+			} else {
+				proxy += "/32"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		proxies = append(proxies, network)
+	}
+
+	var store ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.Store == "postgres" {
+		store = ratelimitrepo.NewRepository(db)
+	}
+
+	return middleware.NewRateLimiter(store, rules, proxies), nil
+}
+
+// newKeyCipher создает шифрование ключей подписи сервисов; nil, если KEK не задан
+func newKeyCipher(cfg config.InternalAuthConfig) (serviceauth.KeyCipher, error) {
+	if cfg.KeyEncryptionKey == "" {
+		return nil, nil
+	}
+	key, err := secretbox.ParseKey(cfg.KeyEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return secretbox.New(key)
+}
+
+// runDeletionPurge периодически удаляет аккаунты с истекшим сроком восстановления до отмены ctx
+func runDeletionPurge(ctx context.Context, service *userservice.Service, interval time.Duration, log *logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := service.PurgeDeletedUsers(ctx)
+		if err != nil {
+			log.Error("Deletion purge failed: %v", err)
+		} else if purged > 0 {
+			log.Info("Deletion purge: removed %d users", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// newPhoneSender создает способ доставки кодов подтверждения телефона
+func newPhoneSender(cfg config.PhoneConfig, log *logger.Logger) phone.Sender {
+	switch cfg.Sender {
+	case "file":
+		return sms.NewFileSender(cfg.FilePath)
+	case "webhook":
+		return sms.NewWebhookSender(cfg.Webhook.URL, cfg.Webhook.Token, time.Duration(cfg.Webhook.Timeout)*time.Second)
+	default:
+		log.Warn("Phone verification: log sender enabled, verification codes are written to the application log")
+		return sms.NewLogSender(log)
+	}
+}
+
+// newNotifier создает способ доставки уведомлений о решениях по спорам о госномерах
+func newNotifier(cfg config.NotificationsConfig, log *logger.Logger) plateclaim.Notifier {
+	if cfg.Sender == "webhook" {
+		return notify.NewWebhookNotifier(cfg.Webhook.URL, cfg.Webhook.Token, time.Duration(cfg.Webhook.Timeout)*time.Second)
+	}
+	return notify.NewLogNotifier(log)
+}
@@ -4,15 +4,39 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/BurntSushi/toml"
+
+	"github.com/m04kA/SMC-UserService/internal/i18n"
+	"github.com/m04kA/SMC-UserService/pkg/phonenumber"
+	"github.com/m04kA/SMC-UserService/pkg/secretbox"
 )
 
+// minJWTSecretLength - минимальная длина секрета HS256 (байты), не меньше размера выхода SHA-256
+const minJWTSecretLength = 32
+
+// placeholderJWTSecret - секрет из старых примеров конфигурации, известный всем, кто видел репозиторий
+const placeholderJWTSecret = "your-secret-key-change-in-production"
+
 // Config представляет полную конфигурацию приложения
 type Config struct {
-	Logs     LogsConfig     `toml:"logs"`
-	Server   ServerConfig   `toml:"server"`
-	Database DatabaseConfig `toml:"database"`
+	Logs          LogsConfig          `toml:"logs"`
+	Server        ServerConfig        `toml:"server"`
+	Database      DatabaseConfig      `toml:"database"`
+	Auth          AuthConfig          `toml:"auth"`
+	InternalAuth  InternalAuthConfig  `toml:"internal_auth"`
+	InternalAPI   InternalAPIConfig   `toml:"internal_api"`
+	RBAC          RBACConfig          `toml:"rbac"`
+	RateLimit     RateLimitConfig     `toml:"rate_limit"`
+	Impersonation ImpersonationConfig `toml:"impersonation"`
+	Deletion      DeletionConfig      `toml:"deletion"`
+	Phone         PhoneConfig         `toml:"phone_verification"`
+	PhoneNumbers  PhoneNumbersConfig  `toml:"phone_numbers"`
+	Localization  LocalizationConfig  `toml:"localization"`
+	Invitations   InvitationsConfig   `toml:"invitations"`
+	LicensePlates LicensePlatesConfig `toml:"license_plates"`
+	Notifications NotificationsConfig `toml:"notifications"`
 }
 
 // LogsConfig содержит настройки логирования
@@ -41,6 +65,137 @@ type DatabaseConfig struct {
 	MaxOpenConns    int    `toml:"max_open_conns"`
 	MaxIdleConns    int    `toml:"max_idle_conns"`
 	ConnMaxLifetime int    `toml:"conn_max_lifetime"`
+	TxMaxAttempts   int    `toml:"tx_max_attempts"` // Попыток транзакции при конфликте (serialization failure, deadlock)
+}
+
+// AuthConfig содержит настройки аутентификации
+type AuthConfig struct {
+	// TrustedGateway включает доверие заголовкам X-User-ID / X-User-Role.
+	// Допустимо только если сервис доступен исключительно через API Gateway, который сам проверяет пользователя.
+	TrustedGateway bool           `toml:"trusted_gateway"`
+	Issuer         string         `toml:"issuer"`
+	Audience       string         `toml:"audience"`
+	Leeway         int            `toml:"leeway"`
+	Keys           []JWTKeyConfig `toml:"keys"`
+	Telegram       TelegramConfig `toml:"telegram"`
+}
+
+// TelegramConfig содержит настройки проверки initData Telegram Mini App
+type TelegramConfig struct {
+	Enabled  bool   `toml:"enabled"`
+	BotToken string `toml:"bot_token"`
+	MaxAge   int    `toml:"max_age"` // Окно свежести auth_date (секунды)
+}
+
+// JWTKeyConfig описывает ключ проверки подписи JWT
+type JWTKeyConfig struct {
+	KID           string `toml:"kid"`
+	Alg           string `toml:"alg"`             // HS256, RS256, EdDSA
+	Secret        string `toml:"secret"`          // Секрет для HS256
+	SecretEnv     string `toml:"secret_env"`      // Имя переменной окружения с секретом для HS256
+	PublicKeyFile string `toml:"public_key_file"` // PEM файл публичного ключа для RS256/EdDSA
+}
+
+// InternalAuthConfig содержит настройки аутентификации сервисов на /internal маршрутах
+type InternalAuthConfig struct {
+	Enabled          bool   `toml:"enabled"`
+	MaxClockSkew     int    `toml:"max_clock_skew"`     // Окно допустимой метки времени запроса (секунды)
+	NonceStore       string `toml:"nonce_store"`        // postgres (общий для всех инстансов) или memory
+	KeyEncryptionKey string `toml:"key_encryption_key"` // KEK ключей подписи сервисов: 32 байта в base64 (INTERNAL_AUTH_KEK)
+}
+
+// InternalAPIConfig содержит настройки межсервисного API
+type InternalAPIConfig struct {
+	MaxBatchSize int `toml:"max_batch_size"` // Максимум ID в пакетных запросах :batchGet
+}
+
+// RBACConfig содержит настройки кеша ролей и прав
+type RBACConfig struct {
+	CacheTTL int `toml:"cache_ttl"` // Время жизни кеша справочника ролей (секунды)
+}
+
+// RateLimitConfig содержит настройки ограничения частоты запросов
+type RateLimitConfig struct {
+	Enabled        bool                           `toml:"enabled"`
+	Store          string                         `toml:"store"`           // memory или postgres (общий для всех инстансов)
+	TrustedProxies []string                       `toml:"trusted_proxies"` // IP/CIDR прокси, которым доверяется X-Forwarded-For
+	Routes         map[string]RateLimitRuleConfig `toml:"routes"`
+}
+
+// RateLimitRuleConfig описывает лимит для группы маршрутов
+type RateLimitRuleConfig struct {
+	Key      string `toml:"key"`      // user, ip или service
+	Requests int    `toml:"requests"` // Количество запросов за period
+	Period   int    `toml:"period"`   // Период (секунды)
+	Burst    int    `toml:"burst"`    // Емкость корзины (по умолчанию requests)
+}
+
+// ImpersonationConfig содержит настройки выполнения запросов от имени другого пользователя (X-Act-As)
+type ImpersonationConfig struct {
+	Enabled  bool `toml:"enabled"`
+	ReadOnly bool `toml:"read_only"` // Разрешать только GET/HEAD/OPTIONS при имперсонации
+}
+
+// DeletionConfig содержит настройки мягкого удаления аккаунтов
+type DeletionConfig struct {
+	RetentionDays int  `toml:"retention_days"` // Срок, в течение которого удаленный аккаунт можно восстановить (дни)
+	PurgeEnabled  bool `toml:"purge_enabled"`  // Запускать фоновую очистку в этом инстансе
+	PurgeInterval int  `toml:"purge_interval"` // Период запуска очистки (секунды)
+}
+
+// PhoneNumbersConfig содержит правила нормализации номеров телефонов
+type PhoneNumbersConfig struct {
+	DefaultRegion   string `toml:"default_region"`   // Регион номеров без кода страны: RU, KZ, BY, UA, US
+	DuplicatePolicy string `toml:"duplicate_policy"` // reject, allow или flag
+}
+
+// LocalizationConfig содержит настройки языка сообщений об ошибках
+type LocalizationConfig struct {
+	DefaultLanguage string `toml:"default_language"` // Язык без Accept-Language и сохраненного языка пользователя: ru, en
+}
+
+// InvitationsConfig содержит настройки приглашений, выдающих роль
+type InvitationsConfig struct {
+	BotUsername string `toml:"bot_username"` // Бот для deep link https://t.me/<bot>/<app>?startapp=<token>
+	AppName     string `toml:"app_name"`     // Короткое имя Mini App; пусто - основное Mini App бота
+	DefaultTTL  int    `toml:"default_ttl"`  // Срок действия по умолчанию (секунды)
+	MaxTTL      int    `toml:"max_ttl"`      // Максимальный срок действия (секунды)
+}
+
+// LicensePlatesConfig содержит правила закрепления госномеров за пользователями
+type LicensePlatesConfig struct {
+	DuplicatePolicy string `toml:"duplicate_policy"` // reject, allow или claim
+}
+
+// NotificationsConfig содержит настройки уведомлений пользователей о решениях по спорам о госномерах
+type NotificationsConfig struct {
+	Sender  string                     `toml:"sender"` // log или webhook
+	Webhook NotificationsWebhookConfig `toml:"webhook"`
+}
+
+// NotificationsWebhookConfig содержит настройки отправки уведомлений через HTTP (например, сервису Telegram бота)
+type NotificationsWebhookConfig struct {
+	URL     string `toml:"url"`
+	Token   string `toml:"token"`   // Bearer токен (переопределяется через NOTIFICATIONS_WEBHOOK_TOKEN)
+	Timeout int    `toml:"timeout"` // Таймаут запроса (секунды)
+}
+
+// PhoneConfig содержит настройки подтверждения номера телефона одноразовым кодом
+type PhoneConfig struct {
+	CodeLength     int                `toml:"code_length"`
+	CodeTTL        int                `toml:"code_ttl"`        // Время жизни кода (секунды)
+	MaxAttempts    int                `toml:"max_attempts"`    // Попыток ввода на один код
+	ResendInterval int                `toml:"resend_interval"` // Минимальный интервал повторной отправки (секунды)
+	Sender         string             `toml:"sender"`          // log, file или webhook
+	FilePath       string             `toml:"file_path"`       // Файл для sender = "file"
+	Webhook        PhoneWebhookConfig `toml:"webhook"`
+}
+
+// PhoneWebhookConfig содержит настройки отправки кодов через HTTP шлюз SMS
+type PhoneWebhookConfig struct {
+	URL     string `toml:"url"`
+	Token   string `toml:"token"`   // Bearer токен шлюза (переопределяется через PHONE_WEBHOOK_TOKEN)
+	Timeout int    `toml:"timeout"` // Таймаут запроса (секунды)
 }
 
 // DSN формирует строку подключения к PostgreSQL
@@ -109,6 +264,48 @@ func overrideFromEnv(cfg *Config) {
 	if v := os.Getenv("LOG_FILE"); v != "" {
 		cfg.Logs.File = v
 	}
+
+	// Auth
+	if v := os.Getenv("AUTH_TRUSTED_GATEWAY"); v != "" {
+		if trusted, err := strconv.ParseBool(v); err == nil {
+			cfg.Auth.TrustedGateway = trusted
+		}
+	}
+	if v := os.Getenv("INTERNAL_AUTH_ENABLED"); v != "" {
+		if enabled, err := strconv.ParseBool(v); err == nil {
+			cfg.InternalAuth.Enabled = enabled
+		}
+	}
+	if v := os.Getenv("INTERNAL_AUTH_KEK"); v != "" {
+		cfg.InternalAuth.KeyEncryptionKey = v
+	}
+	if v := os.Getenv("RATE_LIMIT_ENABLED"); v != "" {
+		if enabled, err := strconv.ParseBool(v); err == nil {
+			cfg.RateLimit.Enabled = enabled
+		}
+	}
+	if v := os.Getenv("RATE_LIMIT_STORE"); v != "" {
+		cfg.RateLimit.Store = v
+	}
+	if v := os.Getenv("PHONE_SENDER"); v != "" {
+		cfg.Phone.Sender = v
+	}
+	if v := os.Getenv("PHONE_WEBHOOK_TOKEN"); v != "" {
+		cfg.Phone.Webhook.Token = v
+	}
+	if v := os.Getenv("NOTIFICATIONS_WEBHOOK_TOKEN"); v != "" {
+		cfg.Notifications.Webhook.Token = v
+	}
+	if v := os.Getenv("TELEGRAM_BOT_TOKEN"); v != "" {
+		cfg.Auth.Telegram.BotToken = v
+	}
+	for i := range cfg.Auth.Keys {
+		if env := cfg.Auth.Keys[i].SecretEnv; env != "" {
+			if v := os.Getenv(env); v != "" {
+				cfg.Auth.Keys[i].Secret = v
+			}
+		}
+	}
 }
 
 // validate проверяет корректность конфигурации
@@ -126,12 +323,207 @@ func validate(cfg *Config) error {
 	if cfg.Database.DBName == "" {
 		return fmt.Errorf("database name is required")
 	}
+	if cfg.Database.TxMaxAttempts == 0 {
+		cfg.Database.TxMaxAttempts = 3
+	}
+	if cfg.Database.TxMaxAttempts < 0 {
+		return fmt.Errorf("database tx_max_attempts must be positive")
+	}
 
 	// Server validation
 	if cfg.Server.HTTPPort <= 0 || cfg.Server.HTTPPort > 65535 {
 		return fmt.Errorf("HTTP port must be between 1 and 65535")
 	}
 
+	// Auth validation
+	if !cfg.Auth.TrustedGateway && len(cfg.Auth.Keys) == 0 && !cfg.Auth.Telegram.Enabled {
+		return fmt.Errorf("auth: configure JWT keys, telegram init data or trusted_gateway")
+	}
+	if cfg.Auth.Telegram.Enabled && cfg.Auth.Telegram.BotToken == "" {
+		return fmt.Errorf("auth: telegram bot_token is required when telegram auth is enabled")
+	}
+	if cfg.Auth.Telegram.MaxAge == 0 {
+		cfg.Auth.Telegram.MaxAge = 86400 // 24 hours
+	}
+	for _, key := range cfg.Auth.Keys {
+		if key.KID == "" {
+			return fmt.Errorf("auth: key id (kid) is required")
+		}
+		switch key.Alg {
+		case "HS256":
+			if key.Secret == "" {
+				return fmt.Errorf("auth: key %q: secret is required for HS256", key.KID)
+			}
+			if key.Secret == placeholderJWTSecret || len(key.Secret) < minJWTSecretLength {
+				return fmt.Errorf("auth: key %q: HS256 secret must be a random value of at least %d bytes", key.KID, minJWTSecretLength)
+			}
+		case "RS256", "EdDSA":
+			if key.PublicKeyFile == "" {
+				return fmt.Errorf("auth: key %q: public_key_file is required for %s", key.KID, key.Alg)
+			}
+		default:
+			return fmt.Errorf("auth: key %q: unsupported alg %q", key.KID, key.Alg)
+		}
+	}
+	if cfg.Auth.Leeway == 0 {
+		cfg.Auth.Leeway = 30
+	}
+
+	if cfg.InternalAuth.MaxClockSkew == 0 {
+		cfg.InternalAuth.MaxClockSkew = 300 // 5 minutes
+	}
+	if cfg.InternalAuth.NonceStore == "" {
+		cfg.InternalAuth.NonceStore = "postgres"
+	}
+	if cfg.InternalAuth.NonceStore != "memory" && cfg.InternalAuth.NonceStore != "postgres" {
+		return fmt.Errorf("internal_auth: unsupported nonce_store %q", cfg.InternalAuth.NonceStore)
+	}
+	if cfg.InternalAuth.KeyEncryptionKey != "" {
+		if _, err := secretbox.ParseKey(cfg.InternalAuth.KeyEncryptionKey); err != nil {
+			return fmt.Errorf("internal_auth: key_encryption_key must be %d random bytes in base64", secretbox.KeySize)
+		}
+	} else if cfg.InternalAuth.Enabled {
+		return fmt.Errorf("internal_auth: key_encryption_key (INTERNAL_AUTH_KEK) is required when internal auth is enabled")
+	}
+
+	if cfg.InternalAPI.MaxBatchSize == 0 {
+		cfg.InternalAPI.MaxBatchSize = 100
+	}
+
+	if cfg.RBAC.CacheTTL == 0 {
+		cfg.RBAC.CacheTTL = 60
+	}
+
+	// Rate limit validation
+	if cfg.RateLimit.Store == "" {
+		cfg.RateLimit.Store = "memory"
+	}
+	if cfg.RateLimit.Store != "memory" && cfg.RateLimit.Store != "postgres" {
+		return fmt.Errorf("rate_limit: unsupported store %q", cfg.RateLimit.Store)
+	}
+	for name, route := range cfg.RateLimit.Routes {
+		switch route.Key {
+		case "user", "ip", "service":
+		default:
+			return fmt.Errorf("rate_limit: route %q: unsupported key %q", name, route.Key)
+		}
+		if route.Requests <= 0 || route.Period <= 0 {
+			return fmt.Errorf("rate_limit: route %q: requests and period must be positive", name)
+		}
+	}
+
+	if cfg.Deletion.RetentionDays < 0 {
+		return fmt.Errorf("deletion: retention_days must not be negative")
+	}
+	if cfg.Deletion.RetentionDays == 0 {
+		cfg.Deletion.RetentionDays = 30
+	}
+	if cfg.Deletion.PurgeInterval == 0 {
+		cfg.Deletion.PurgeInterval = 3600 // 1 hour
+	}
+
+	// Phone numbers validation
+	if cfg.PhoneNumbers.DefaultRegion == "" {
+		cfg.PhoneNumbers.DefaultRegion = "RU"
+	}
+	cfg.PhoneNumbers.DefaultRegion = strings.ToUpper(cfg.PhoneNumbers.DefaultRegion)
+	if _, ok := phonenumber.Regions[cfg.PhoneNumbers.DefaultRegion]; !ok {
+		return fmt.Errorf("phone_numbers: unsupported default_region %q", cfg.PhoneNumbers.DefaultRegion)
+	}
+	if cfg.PhoneNumbers.DuplicatePolicy == "" {
+		cfg.PhoneNumbers.DuplicatePolicy = "reject"
+	}
+	switch cfg.PhoneNumbers.DuplicatePolicy {
+	case "reject", "allow", "flag":
+	default:
+		return fmt.Errorf("phone_numbers: unsupported duplicate_policy %q", cfg.PhoneNumbers.DuplicatePolicy)
+	}
+
+	// License plates validation
+	if cfg.LicensePlates.DuplicatePolicy == "" {
+		cfg.LicensePlates.DuplicatePolicy = "claim"
+	}
+	switch cfg.LicensePlates.DuplicatePolicy {
+	case "reject", "allow", "claim":
+	default:
+		return fmt.Errorf("license_plates: unsupported duplicate_policy %q", cfg.LicensePlates.DuplicatePolicy)
+	}
+
+	// Notifications validation
+	if cfg.Notifications.Sender == "" {
+		cfg.Notifications.Sender = "log"
+	}
+	switch cfg.Notifications.Sender {
+	case "log":
+	case "webhook":
+		if cfg.Notifications.Webhook.URL == "" {
+			return fmt.Errorf("notifications: webhook url is required when sender is webhook")
+		}
+		if cfg.Notifications.Webhook.Timeout == 0 {
+			cfg.Notifications.Webhook.Timeout = 5
+		}
+	default:
+		return fmt.Errorf("notifications: unsupported sender %q", cfg.Notifications.Sender)
+	}
+
+	// Localization validation
+	if cfg.Localization.DefaultLanguage == "" {
+		cfg.Localization.DefaultLanguage = i18n.DefaultLanguage
+	}
+	lang, ok := i18n.Normalize(cfg.Localization.DefaultLanguage)
+	if !ok {
+		return fmt.Errorf("localization: unsupported default_language %q", cfg.Localization.DefaultLanguage)
+	}
+	cfg.Localization.DefaultLanguage = lang
+
+	// Invitations validation
+	cfg.Invitations.BotUsername = strings.TrimPrefix(cfg.Invitations.BotUsername, "@")
+	if cfg.Invitations.DefaultTTL == 0 {
+		cfg.Invitations.DefaultTTL = 7 * 86400 // 7 days
+	}
+	if cfg.Invitations.MaxTTL == 0 {
+		cfg.Invitations.MaxTTL = 30 * 86400 // 30 days
+	}
+	if cfg.Invitations.DefaultTTL < 0 || cfg.Invitations.DefaultTTL > cfg.Invitations.MaxTTL {
+		return fmt.Errorf("invitations: default_ttl must be positive and not exceed max_ttl")
+	}
+
+	// Phone verification validation
+	if cfg.Phone.CodeLength == 0 {
+		cfg.Phone.CodeLength = 6
+	}
+	if cfg.Phone.CodeLength < 4 || cfg.Phone.CodeLength > 10 {
+		return fmt.Errorf("phone_verification: code_length must be between 4 and 10")
+	}
+	if cfg.Phone.CodeTTL == 0 {
+		cfg.Phone.CodeTTL = 300 // 5 minutes
+	}
+	if cfg.Phone.MaxAttempts == 0 {
+		cfg.Phone.MaxAttempts = 5
+	}
+	if cfg.Phone.ResendInterval == 0 {
+		cfg.Phone.ResendInterval = 60
+	}
+	if cfg.Phone.Sender == "" {
+		cfg.Phone.Sender = "log"
+	}
+	switch cfg.Phone.Sender {
+	case "log":
+	case "file":
+		if cfg.Phone.FilePath == "" {
+			cfg.Phone.FilePath = "./logs/sms.log"
+		}
+	case "webhook":
+		if cfg.Phone.Webhook.URL == "" {
+			return fmt.Errorf("phone_verification: webhook url is required when sender is webhook")
+		}
+		if cfg.Phone.Webhook.Timeout == 0 {
+			cfg.Phone.Webhook.Timeout = 5
+		}
+	default:
+		return fmt.Errorf("phone_verification: unsupported sender %q", cfg.Phone.Sender)
+	}
+
 	// Logs validation
 	if cfg.Logs.Level == "" {
 		cfg.Logs.Level = "info" // default
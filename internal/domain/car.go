@@ -1,12 +1,21 @@
 package domain
 
 type Car struct {
-	ID           int64   `json:"id" db:"id"`
-	UserID       int64   `json:"user_id" db:"user_id"`
-	Brand        string  `json:"brand" db:"brand" validate:"required"`
-	Model        string  `json:"model" db:"model" validate:"required"`
-	LicensePlate string  `json:"license_plate" db:"license_plate" validate:"required"`
-	Color        *string `json:"color,omitempty" db:"color"`
-	Size         *string `json:"size,omitempty" db:"size"`
-	IsSelected   bool    `json:"is_selected" db:"is_selected"`
+	ID                     int64   `json:"id" db:"id"`
+	UserID                 int64   `json:"user_id" db:"user_id"`
+	Brand                  string  `json:"brand" db:"brand" validate:"required,max=100"`
+	Model                  string  `json:"model" db:"model" validate:"required,max=100"`
+	LicensePlate           string  `json:"license_plate" db:"license_plate" validate:"required,max=20"`
+	LicensePlateNormalized string  `json:"license_plate_normalized" db:"license_plate_normalized" validate:"required,max=20"`
+	LicensePlateFormat     string  `json:"license_plate_format" db:"license_plate_format" validate:"required"`
+	Color                  *string `json:"color,omitempty" db:"color" validate:"omitempty,max=50"`
+	Size                   *string `json:"size,omitempty" db:"size" validate:"omitempty,max=50"`
+	IsSelected             bool    `json:"is_selected" db:"is_selected"`
+	LicensePlateStatus     string  `json:"license_plate_status" db:"license_plate_status"`
 }
+
+// Состояние госномера автомобиля
+const (
+	PlateStatusConfirmed    = "confirmed"     // Номер закреплен за владельцем
+	PlateStatusPendingClaim = "pending_claim" // Номер уже есть у другого пользователя, спор ожидает решения
+)
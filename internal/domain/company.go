@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// Company компания (автомойка), которой управляют менеджеры
+type Company struct {
+	ID        int64     `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name" validate:"required,max=255"`
+	CreatedBy *int64    `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CompanyRole роль менеджера внутри компании
+type CompanyRole string
+
+const (
+	CompanyRoleOwner CompanyRole = "owner" // Владелец компании
+	CompanyRoleStaff CompanyRole = "staff" // Сотрудник компании
+)
+
+// IsValid проверяет, является ли роль в компании допустимой
+func (r CompanyRole) IsValid() bool {
+	switch r {
+	case CompanyRoleOwner, CompanyRoleStaff:
+		return true
+	default:
+		return false
+	}
+}
+
+// CompanyMember членство менеджера в компании
+type CompanyMember struct {
+	CompanyID   int64       `json:"company_id" db:"company_id"`
+	CompanyName string      `json:"company_name" db:"company_name"`
+	TGUserID    int64       `json:"tg_user_id" db:"tg_user_id"`
+	Role        CompanyRole `json:"role" db:"role"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// ConsentType вид согласия пользователя
+type ConsentType string
+
+const (
+	ConsentPersonalData ConsentType = "personal_data" // Обработка персональных данных
+	ConsentMarketing    ConsentType = "marketing"     // Рекламные рассылки
+)
+
+// UserConsent согласие, принятое пользователем в Mini App при регистрации
+type UserConsent struct {
+	ID        int64       `json:"id" db:"id"`
+	TGUserID  int64       `json:"tg_user_id" db:"tg_user_id"`
+	Type      ConsentType `json:"consent_type" db:"consent_type"`
+	GrantedAt time.Time   `json:"granted_at" db:"granted_at"`
+	RevokedAt *time.Time  `json:"revoked_at" db:"revoked_at"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// ImpersonationEvent запись журнала о запросе, выполненном от имени другого пользователя
+type ImpersonationEvent struct {
+	ID         int64     `json:"id" db:"id"`
+	ActorID    int64     `json:"actor_id" db:"actor_id"`
+	TargetID   int64     `json:"target_id" db:"target_id"`
+	Method     string    `json:"method" db:"method"`
+	Path       string    `json:"path" db:"path"`
+	StatusCode *int      `json:"status_code" db:"status_code"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
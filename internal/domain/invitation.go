@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Invitation одноразовое приглашение: принявший пользователь получает роль и,
+// если указана компания, членство в ней
+type Invitation struct {
+	ID          int64        `json:"id" db:"id"`
+	TokenHash   string       `json:"-" db:"token_hash"` // hex(SHA-256(token)), открытый токен не хранится
+	Role        Role         `json:"role" db:"role"`
+	CompanyID   *int64       `json:"company_id" db:"company_id"`
+	CompanyRole *CompanyRole `json:"company_role" db:"company_role"`
+	CreatedBy   int64        `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	ExpiresAt   time.Time    `json:"expires_at" db:"expires_at"`
+	AcceptedBy  *int64       `json:"accepted_by" db:"accepted_by"`
+	AcceptedAt  *time.Time   `json:"accepted_at" db:"accepted_at"`
+	RevokedAt   *time.Time   `json:"revoked_at" db:"revoked_at"`
+}
+
+// InvitationStatus состояние приглашения
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"  // Ожидает принятия
+	InvitationStatusAccepted InvitationStatus = "accepted" // Принято
+	InvitationStatusRevoked  InvitationStatus = "revoked"  // Отозвано
+	InvitationStatusExpired  InvitationStatus = "expired"  // Истек срок действия
+)
+
+// Status возвращает состояние приглашения на момент now
+func (i *Invitation) Status(now time.Time) InvitationStatus {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationStatusAccepted
+	case i.RevokedAt != nil:
+		return InvitationStatusRevoked
+	case !now.Before(i.ExpiresAt):
+		return InvitationStatusExpired
+	default:
+		return InvitationStatusPending
+	}
+}
@@ -0,0 +1,124 @@
+package domain
+
+import "sort"
+
+// Permission право доступа в формате ресурс:действие[:область]
+type Permission string
+
+const (
+	PermUsersReadAny       Permission = "users:read:any"             // Просмотр данных любого пользователя
+	PermUsersUpdateAny     Permission = "users:update:any"           // Изменение данных любого пользователя
+	PermUsersRoleAssign    Permission = "users:role:assign"          // Назначение ролей пользователям
+	PermUsersStatusUpdate  Permission = "users:status:update"        // Блокировка и разблокировка пользователей
+	PermCarsReadAny        Permission = "cars:read:any"              // Просмотр автомобилей любого пользователя
+	PermCarsUpdateAny      Permission = "cars:update:any"            // Изменение и выбор автомобилей любого пользователя
+	PermCarsDeleteAny      Permission = "cars:delete:any"            // Удаление автомобилей любого пользователя
+	PermRolesManage        Permission = "roles:manage"               // Создание ролей и управление их правами
+	PermUsersImpersonate   Permission = "users:impersonate"          // Выполнение запросов от имени другого пользователя
+	PermAuditRead          Permission = "audit:read"                 // Просмотр журнала аудита
+	PermCarsLookupCompany  Permission = "cars:lookup:company"        // Поиск автомобилей по госномеру от имени своей компании
+	PermCarsClaimsResolve  Permission = "cars:claims:resolve"        // Рассмотрение споров о госномерах
+	PermCompaniesManage    Permission = "companies:manage"           // Создание компаний и назначение их менеджеров
+	PermServiceCredsManage Permission = "service_credentials:manage" // Выпуск и отзыв ключей сервисов
+)
+
+// PermissionDefinition право из справочника прав
+type PermissionDefinition struct {
+	ID          int        `json:"id" db:"id"`
+	Code        Permission `json:"code" db:"code"`
+	Description *string    `json:"description" db:"description"`
+}
+
+// RoleDefinition роль из справочника с ее правами
+type RoleDefinition struct {
+	ID          int          `json:"id" db:"id"`
+	Name        Role         `json:"name" db:"name"`
+	Description *string      `json:"description" db:"description"`
+	Permissions []Permission `json:"permissions" db:"-"`
+}
+
+// Authorizer проверяет права ролей по справочнику ролей и прав.
+// Собственные данные доступны любому пользователю, к чужим нужен право с областью any.
+type Authorizer struct {
+	roles map[Role]RoleDefinition
+	perms map[Role]map[Permission]bool
+}
+
+// NewAuthorizer создает Authorizer из справочника ролей
+func NewAuthorizer(roles []RoleDefinition) *Authorizer {
+	a := &Authorizer{
+		roles: make(map[Role]RoleDefinition, len(roles)),
+		perms: make(map[Role]map[Permission]bool, len(roles)),
+	}
+	for _, role := range roles {
+		a.roles[role.Name] = role
+		set := make(map[Permission]bool, len(role.Permissions))
+		for _, p := range role.Permissions {
+			set[p] = true
+		}
+		a.perms[role.Name] = set
+	}
+	return a
+}
+
+// IsKnownRole проверяет, что роль есть в справочнике
+func (a *Authorizer) IsKnownRole(role Role) bool {
+	_, ok := a.roles[role]
+	return ok
+}
+
+// RoleID возвращает идентификатор роли в БД
+func (a *Authorizer) RoleID(role Role) (int, bool) {
+	def, ok := a.roles[role]
+	return def.ID, ok
+}
+
+// Roles возвращает справочник ролей, отсортированный по ID
+func (a *Authorizer) Roles() []RoleDefinition {
+	roles := make([]RoleDefinition, 0, len(a.roles))
+	for _, role := range a.roles {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].ID < roles[j].ID })
+	return roles
+}
+
+// Has проверяет наличие права у роли. Неизвестная роль не имеет прав.
+func (a *Authorizer) Has(role Role, permission Permission) bool {
+	return a.perms[role][permission]
+}
+
+// Permissions возвращает отсортированный список прав роли
+func (a *Authorizer) Permissions(role Role) []Permission {
+	perms := make([]Permission, 0, len(a.perms[role]))
+	for p := range a.perms[role] {
+		perms = append(perms, p)
+	}
+	sort.Slice(perms, func(i, j int) bool { return perms[i] < perms[j] })
+	return perms
+}
+
+// CanAccessUser проверяет, может ли пользователь с ролью получить доступ к данным другого пользователя
+func (a *Authorizer) CanAccessUser(role Role, targetUserID, requestUserID int64) bool {
+	return targetUserID == requestUserID || a.Has(role, PermUsersReadAny)
+}
+
+// CanModifyUser проверяет, может ли пользователь с ролью изменять данные другого пользователя
+func (a *Authorizer) CanModifyUser(role Role, targetUserID, requestUserID int64) bool {
+	return targetUserID == requestUserID || a.Has(role, PermUsersUpdateAny)
+}
+
+// CanReadCar проверяет доступ на чтение к автомобилю владельца ownerID
+func (a *Authorizer) CanReadCar(role Role, ownerID, requestUserID int64) bool {
+	return ownerID == requestUserID || a.Has(role, PermCarsReadAny)
+}
+
+// CanUpdateCar проверяет право изменять (и выбирать) автомобиль владельца ownerID
+func (a *Authorizer) CanUpdateCar(role Role, ownerID, requestUserID int64) bool {
+	return ownerID == requestUserID || a.Has(role, PermCarsUpdateAny)
+}
+
+// CanDeleteCar проверяет право удалять автомобиль владельца ownerID
+func (a *Authorizer) CanDeleteCar(role Role, ownerID, requestUserID int64) bool {
+	return ownerID == requestUserID || a.Has(role, PermCarsDeleteAny)
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// PhoneVerification активный одноразовый код подтверждения номера телефона
+type PhoneVerification struct {
+	TGUserID    int64     `json:"tg_user_id" db:"tg_user_id"`
+	PhoneNumber string    `json:"phone_number" db:"phone_number"`
+	CodeHash    string    `json:"-" db:"code_hash"`
+	Attempts    int       `json:"attempts" db:"attempts"`
+	ExpiresAt   time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// PlateClaim спор о госномере: пользователь добавил автомобиль с номером, который уже закреплен
+// за автомобилем другого пользователя
+type PlateClaim struct {
+	ID           int64            `json:"id" db:"id"`
+	CarID        *int64           `json:"car_id" db:"car_id"` // Автомобиль заявителя, nil - удален
+	ClaimantID   int64            `json:"claimant_id" db:"claimant_id"`
+	HolderCarID  *int64           `json:"holder_car_id" db:"holder_car_id"` // Автомобиль владельца номера, nil - удален
+	HolderID     int64            `json:"holder_id" db:"holder_id"`
+	LicensePlate string           `json:"license_plate_normalized" db:"license_plate_normalized"`
+	Status       PlateClaimStatus `json:"status" db:"status"`
+	ResolvedBy   *int64           `json:"resolved_by" db:"resolved_by"`
+	Comment      *string          `json:"comment" db:"comment"`
+	CreatedAt    time.Time        `json:"created_at" db:"created_at"`
+	ResolvedAt   *time.Time       `json:"resolved_at" db:"resolved_at"`
+}
+
+// PlateClaimStatus состояние спора о госномере
+type PlateClaimStatus string
+
+const (
+	PlateClaimPending     PlateClaimStatus = "pending"     // Ожидает решения
+	PlateClaimTransferred PlateClaimStatus = "transferred" // Номер передан заявителю, автомобиль владельца удален
+	PlateClaimRejected    PlateClaimStatus = "rejected"    // Заявка отклонена, автомобиль заявителя удален
+	PlateClaimShared      PlateClaimStatus = "shared"      // Номер оставлен обоим пользователям
+	PlateClaimWithdrawn   PlateClaimStatus = "withdrawn"   // Заявитель удалил автомобиль или изменил номер
+)
+
+// PlateClaimResolutions решения, которые может принять менеджер или суперпользователь
+var PlateClaimResolutions = []PlateClaimStatus{PlateClaimTransferred, PlateClaimRejected, PlateClaimShared}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// PlateLookupEvent запись журнала о поиске автомобиля по госномеру менеджером компании
+type PlateLookupEvent struct {
+	ID          int64     `json:"id" db:"id"`
+	ActorID     int64     `json:"actor_id" db:"actor_id"`
+	CompanyID   int64     `json:"company_id" db:"company_id"`
+	Plate       string    `json:"plate" db:"plate"`
+	ResultCount int       `json:"result_count" db:"result_count"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
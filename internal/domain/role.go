@@ -1,12 +1,14 @@
 package domain
 
+import "regexp"
+
 // Role представляет роль пользователя в системе
 type Role string
 
 const (
-	RoleClient     Role = "client"     // Обычный клиент автомойки (ID=1)
-	RoleManager    Role = "manager"    // Менеджер компании (автомойки) (ID=2)
-	RoleSuperUser  Role = "superuser"  // Суперпользователь с полным доступом (ID=3)
+	RoleClient    Role = "client"    // Обычный клиент автомойки (ID=1)
+	RoleManager   Role = "manager"   // Менеджер компании (автомойки) (ID=2)
+	RoleSuperUser Role = "superuser" // Суперпользователь с полным доступом (ID=3)
 )
 
 // RoleID константы для идентификаторов ролей в базе данных
@@ -16,35 +18,21 @@ const (
 	RoleIDSuperUser int = 3
 )
 
-// IsValid проверяет, является ли роль валидной
-func (r Role) IsValid() bool {
-	switch r {
-	case RoleClient, RoleManager, RoleSuperUser:
-		return true
-	default:
-		return false
-	}
-}
+// rolePattern допустимое имя роли (roles.name VARCHAR(20))
+var rolePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,19}$`)
 
-// CanAccessUser проверяет, может ли пользователь с данной ролью получить доступ к другому пользователю
-func (r Role) CanAccessUser(targetUserID, requestUserID int64) bool {
-	switch r {
-	case RoleSuperUser:
-		return true // Суперпользователь может видеть всех
-	case RoleManager, RoleClient:
-		return targetUserID == requestUserID // Клиент и менеджер могут видеть только себя
-	default:
-		return false
-	}
+// IsValid проверяет формат имени роли.
+// Кроме встроенных ролей суперпользователь может создавать собственные (например, support),
+// поэтому существование роли проверяется по справочнику ролей (Authorizer).
+func (r Role) IsValid() bool {
+	return rolePattern.MatchString(string(r))
 }
 
-// CanModifyUser проверяет, может ли пользователь с данной ролью модифицировать другого пользователя
-func (r Role) CanModifyUser(targetUserID, requestUserID int64) bool {
+// IsBuiltIn проверяет, является ли роль встроенной
+func (r Role) IsBuiltIn() bool {
 	switch r {
-	case RoleSuperUser:
-		return true // Суперпользователь может изменять всех
-	case RoleManager, RoleClient:
-		return targetUserID == requestUserID // Клиент и менеджер могут изменять только себя
+	case RoleClient, RoleManager, RoleSuperUser:
+		return true
 	default:
 		return false
 	}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// RoleChange запись об изменении роли пользователя
+type RoleChange struct {
+	ID        int64     `json:"id" db:"id"`
+	TGUserID  int64     `json:"tg_user_id" db:"tg_user_id"`
+	OldRoleID int       `json:"old_role_id" db:"old_role_id"`
+	NewRoleID int       `json:"new_role_id" db:"new_role_id"`
+	ChangedBy int64     `json:"changed_by" db:"changed_by"`
+	Reason    *string   `json:"reason" db:"reason"`
+	ChangedAt time.Time `json:"changed_at" db:"changed_at"`
+}
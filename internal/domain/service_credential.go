@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// ServiceCredential учетные данные внутреннего сервиса для подписи запросов к /internal
+type ServiceCredential struct {
+	ID                  int64      `json:"id" db:"id"`
+	ServiceName         string     `json:"service_name" db:"service_name"`
+	KeyID               string     `json:"key_id" db:"key_id"`
+	SigningKeyEncrypted []byte     `json:"-" db:"signing_key_encrypted"` // SHA-256(secret), зашифрованный KEK сервера
+	LegacySigningKey    *string    `json:"-" db:"secret_hash"`           // hex(SHA-256(secret)) ключей до шифрования, обнуляется при шифровании
+	CreatedBy           *int64     `json:"created_by" db:"created_by"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	RevokedAt           *time.Time `json:"revoked_at" db:"revoked_at"`
+}
+
+// IsActive проверяет, что ключ не отозван
+func (c *ServiceCredential) IsActive() bool {
+	return c.RevokedAt == nil
+}
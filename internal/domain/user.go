@@ -3,11 +3,36 @@ package domain
 import "time"
 
 type User struct {
-	TGUserID    int64     `json:"tg_user_id" db:"tg_user_id"`
-	Name        string    `json:"name" db:"name" validate:"required"`
-	PhoneNumber *string   `json:"phone_number" db:"phone_number" validate:"omitempty,e164"`
-	TGLink      *string   `json:"tg_link" db:"tg_link"`
-	RoleID      int       `json:"role_id" db:"role_id"`
-	Role        Role      `json:"role" db:"role_name"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	TGUserID        int64      `json:"tg_user_id" db:"tg_user_id"`
+	Name            string     `json:"name" db:"name" validate:"required,max=255"`
+	PhoneNumber     *string    `json:"phone_number" db:"phone_number" validate:"omitempty,e164"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at" db:"phone_verified_at"`
+	PhoneDuplicate  bool       `json:"phone_duplicate" db:"phone_duplicate"`
+	TGLink          *string    `json:"tg_link" db:"tg_link" validate:"omitempty,max=255"`
+	LanguageCode    *string    `json:"language_code" db:"language_code" validate:"omitempty,max=10"`
+	RoleID          int        `json:"role_id" db:"role_id"`
+	Role            Role       `json:"role" db:"role_name"`
+	Status          UserStatus `json:"status" db:"status"`
+	SuspendedUntil  *time.Time `json:"suspended_until" db:"suspended_until"`
+	StatusReason    *string    `json:"status_reason" db:"status_reason"`
+	StatusChangedBy *int64     `json:"status_changed_by" db:"status_changed_by"`
+	StatusChangedAt *time.Time `json:"status_changed_at" db:"status_changed_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	DeletedAt       *time.Time `json:"deleted_at" db:"deleted_at"`
+}
+
+// EffectiveStatus возвращает статус с учетом истечения приостановки
+func (u *User) EffectiveStatus(now time.Time) UserStatus {
+	if u.Status == UserStatusSuspended && (u.SuspendedUntil == nil || !now.Before(*u.SuspendedUntil)) {
+		return UserStatusActive
+	}
+	if u.Status == "" {
+		return UserStatusActive
+	}
+	return u.Status
+}
+
+// IsBlocked проверяет, заблокирован ли пользователь на момент now
+func (u *User) IsBlocked(now time.Time) bool {
+	return u.EffectiveStatus(now) != UserStatusActive
 }
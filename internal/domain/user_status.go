@@ -0,0 +1,20 @@
+package domain
+
+// UserStatus представляет статус аккаунта пользователя
+type UserStatus string
+
+const (
+	UserStatusActive    UserStatus = "active"    // Аккаунт активен
+	UserStatusSuspended UserStatus = "suspended" // Аккаунт приостановлен до SuspendedUntil
+	UserStatusBanned    UserStatus = "banned"    // Аккаунт заблокирован бессрочно
+)
+
+// IsValid проверяет, является ли статус допустимым
+func (s UserStatus) IsValid() bool {
+	switch s {
+	case UserStatusActive, UserStatusSuspended, UserStatusBanned:
+		return true
+	default:
+		return false
+	}
+}
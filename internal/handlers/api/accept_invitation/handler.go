@@ -0,0 +1,45 @@
+package accept_invitation
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/invitation"
+)
+
+type Handler struct {
+	service *invitation.Service
+	log     Logger
+}
+
+func NewHandler(service *invitation.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /invitations/{token}/accept
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /invitations/{token}/accept - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	result, err := h.service.AcceptInvitation(r.Context(), userID, mux.Vars(r)["token"])
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("POST /invitations/{token}/accept - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("POST /invitations/{token}/accept - Failed to accept invitation: user_id=%d, error=%v", userID, err)
+		}
+		return
+	}
+
+	h.log.Info("POST /invitations/{token}/accept - Invitation accepted: user_id=%d, invitation_id=%d, role=%s", userID, result.Invitation.ID, result.User.Role)
+	api.RespondJSON(w, http.StatusOK, result)
+}
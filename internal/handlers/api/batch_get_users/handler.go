@@ -0,0 +1,60 @@
+package batch_get_users
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+type Handler struct {
+	service      *userservice.Service
+	log          Logger
+	maxBatchSize int
+}
+
+func NewHandler(service *userservice.Service, log Logger, maxBatchSize int) *Handler {
+	return &Handler{
+		service:      service,
+		log:          log,
+		maxBatchSize: maxBatchSize,
+	}
+}
+
+// Handle POST /internal/users:batchGet
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	service := middleware.GetServiceFromContext(r.Context())
+
+	var input models.BatchGetInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /internal/users:batchGet - Invalid request body: %v, service=%s", err, service)
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("POST /internal/users:batchGet - Validation failed: %v, service=%s", err, service)
+		api.RespondValidationError(w, err)
+		return
+	}
+
+	if len(input.TGUserIDs) > h.maxBatchSize {
+		h.log.Warn("POST /internal/users:batchGet - Invalid batch size: %d, service=%s", len(input.TGUserIDs), service)
+		api.RespondBadRequest(w, fmt.Sprintf("tg_user_ids must contain at most %d IDs", h.maxBatchSize))
+		return
+	}
+
+	batch, err := h.service.GetUsersWithCars(r.Context(), input.TGUserIDs)
+	if err != nil {
+		h.log.Error("POST /internal/users:batchGet - Failed to get users: %v, service=%s", err, service)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("POST /internal/users:batchGet - success, found=%d, missing=%d, service=%s", len(batch.Users), len(batch.MissingIDs), service)
+	api.RespondJSON(w, http.StatusOK, batch)
+}
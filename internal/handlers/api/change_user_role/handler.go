@@ -0,0 +1,69 @@
+package change_user_role
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle PUT /admin/users/{tg_user_id}/role
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	actorID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("PUT /admin/users/{tg_user_id}/role - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	userIDStr := mux.Vars(r)["tg_user_id"]
+	tgUserID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		h.log.Warn("PUT /admin/users/{tg_user_id}/role - Invalid user ID format: actor_id=%d, tg_user_id=%s", actorID, userIDStr)
+		api.RespondBadRequest(w, "Invalid user ID")
+		return
+	}
+
+	var input models.ChangeRoleInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("PUT /admin/users/{tg_user_id}/role - Invalid request body: actor_id=%d, error=%v", actorID, err)
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("PUT /admin/users/{tg_user_id}/role - Validation failed: actor_id=%d, error=%v", actorID, err)
+		api.RespondValidationError(w, err)
+		return
+	}
+
+	user, err := h.service.ChangeUserRole(r.Context(), actorID, tgUserID, input)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("PUT /admin/users/{tg_user_id}/role - Request rejected: actor_id=%d, tg_user_id=%d, error=%v", actorID, tgUserID, err)
+		} else {
+			h.log.Error("PUT /admin/users/{tg_user_id}/role - Failed to change role: actor_id=%d, tg_user_id=%d, error=%v", actorID, tgUserID, err)
+		}
+		return
+	}
+
+	h.log.Info("PUT /admin/users/{tg_user_id}/role - Role changed: actor_id=%d, tg_user_id=%d, role=%s", actorID, tgUserID, user.Role)
+	api.RespondJSON(w, http.StatusOK, user)
+}
@@ -1,13 +1,13 @@
 package create_car
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/m04kA/SMC-UserService/internal/handlers/api"
 	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
 	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
 	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
 )
 
 type Handler struct {
@@ -34,22 +34,26 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 	var input models.CreateCarInputDTO
 	if err := api.DecodeJSON(r, &input); err != nil {
 		h.log.Warn("POST /users/me/cars - Invalid request body: user_id=%d, error=%v", userID, err)
-		api.RespondBadRequest(w, "Invalid request body")
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("POST /users/me/cars - Validation failed: user_id=%d, error=%v", userID, err)
+		api.RespondValidationError(w, err)
 		return
 	}
 
 	car, err := h.service.CreateCar(r.Context(), userID, input)
 	if err != nil {
-		if errors.Is(err, userservice.ErrUserNotFound) {
-			h.log.Warn("POST /users/me/cars - User not found: user_id=%d", userID)
-			api.RespondUserNotFound(w)
-			return
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("POST /users/me/cars - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("POST /users/me/cars - Failed to create car: user_id=%d, error=%v", userID, err)
 		}
-		h.log.Error("POST /users/me/cars - Failed to create car: user_id=%d, error=%v", userID, err)
-		api.RespondInternalError(w)
 		return
 	}
 
-	h.log.Info("POST /users/me/cars - Car created successfully: user_id=%d, car_id=%d", userID, car.ID)
+	h.log.Info("POST /users/me/cars - Car created successfully: user_id=%d, car_id=%d, plate_status=%s", userID, car.ID, car.LicensePlateStatus)
 	api.RespondJSON(w, http.StatusCreated, car)
 }
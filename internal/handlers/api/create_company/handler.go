@@ -0,0 +1,61 @@
+package create_company
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/company"
+	"github.com/m04kA/SMC-UserService/internal/service/company/models"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+type Handler struct {
+	service *company.Service
+	log     Logger
+}
+
+func NewHandler(service *company.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /admin/companies
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /admin/companies - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	var input models.CreateCompanyInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /admin/companies - Invalid request body: user_id=%d, error=%v", userID, err)
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("POST /admin/companies - Validation failed: user_id=%d, error=%v", userID, err)
+		api.RespondValidationError(w, err)
+		return
+	}
+
+	created, err := h.service.CreateCompany(r.Context(), input, userID)
+	if err != nil {
+		if validator.IsValidationError(err) {
+			h.log.Warn("POST /admin/companies - Validation failed: user_id=%d, error=%v", userID, err)
+			api.RespondValidationError(w, err)
+			return
+		}
+		h.log.Error("POST /admin/companies - Failed to create company: user_id=%d, error=%v", userID, err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("POST /admin/companies - Company created: user_id=%d, company_id=%d", userID, created.ID)
+	api.RespondJSON(w, http.StatusCreated, created)
+}
@@ -0,0 +1,66 @@
+package create_invitation
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/invitation"
+	"github.com/m04kA/SMC-UserService/internal/service/invitation/models"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+type Handler struct {
+	service *invitation.Service
+	log     Logger
+}
+
+func NewHandler(service *invitation.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /invitations
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /invitations - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	role, err := middleware.GetRoleFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /invitations - Role not found in context: user_id=%d", userID)
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	var input models.CreateInvitationInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /invitations - Invalid request body: user_id=%d, error=%v", userID, err)
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("POST /invitations - Validation failed: user_id=%d, error=%v", userID, err)
+		api.RespondValidationError(w, err)
+		return
+	}
+
+	created, err := h.service.CreateInvitation(r.Context(), userID, role, input)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("POST /invitations - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("POST /invitations - Failed to create invitation: user_id=%d, error=%v", userID, err)
+		}
+		return
+	}
+
+	h.log.Info("POST /invitations - Invitation created: user_id=%d, invitation_id=%d, role=%s", userID, created.ID, created.Role)
+	api.RespondJSON(w, http.StatusCreated, created)
+}
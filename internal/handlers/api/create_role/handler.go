@@ -0,0 +1,59 @@
+package create_role
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac/models"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+type Handler struct {
+	service *rbac.Service
+	log     Logger
+}
+
+func NewHandler(service *rbac.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /admin/roles
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /admin/roles - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	var input models.CreateRoleInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /admin/roles - Invalid request body: user_id=%d, error=%v", userID, err)
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("POST /admin/roles - Validation failed: user_id=%d, error=%v", userID, err)
+		api.RespondValidationError(w, err)
+		return
+	}
+
+	role, err := h.service.CreateRole(r.Context(), input)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("POST /admin/roles - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("POST /admin/roles - Failed to create role: user_id=%d, error=%v", userID, err)
+		}
+		return
+	}
+
+	h.log.Info("POST /admin/roles - Role created: user_id=%d, name=%s, permissions=%v", userID, role.Name, role.Permissions)
+	api.RespondJSON(w, http.StatusCreated, role)
+}
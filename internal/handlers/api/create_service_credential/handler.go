@@ -0,0 +1,59 @@
+package create_service_credential
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/serviceauth"
+	"github.com/m04kA/SMC-UserService/internal/service/serviceauth/models"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+type Handler struct {
+	service *serviceauth.Service
+	log     Logger
+}
+
+func NewHandler(service *serviceauth.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /admin/service-credentials
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /admin/service-credentials - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	var input models.CreateCredentialInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /admin/service-credentials - Invalid request body: user_id=%d, error=%v", userID, err)
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("POST /admin/service-credentials - Validation failed: user_id=%d, error=%v", userID, err)
+		api.RespondValidationError(w, err)
+		return
+	}
+
+	credential, err := h.service.CreateCredential(r.Context(), input, userID)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("POST /admin/service-credentials - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("POST /admin/service-credentials - Failed to create credential: user_id=%d, error=%v", userID, err)
+		}
+		return
+	}
+
+	h.log.Info("POST /admin/service-credentials - Credential created: user_id=%d, service=%s, key_id=%s", userID, credential.ServiceName, credential.KeyID)
+	api.RespondJSON(w, http.StatusCreated, credential)
+}
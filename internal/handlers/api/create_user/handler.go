@@ -1,12 +1,13 @@
 package create_user
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
 	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
 	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
 )
 
 type Handler struct {
@@ -23,22 +24,50 @@ func NewHandler(service *userservice.Service, log Logger) *Handler {
 
 // Handle POST /users
 func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /users - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
 	var input models.CreateUserInputDTO
 	if err := api.DecodeJSON(r, &input); err != nil {
 		h.log.Warn("POST /users - Invalid request body: %v", err)
-		api.RespondBadRequest(w, "Invalid request body")
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	// Регистрировать можно только собственный Telegram аккаунт
+	if input.TGUserID != 0 && input.TGUserID != userID {
+		h.log.Warn("POST /users - Attempt to register another account: user_id=%d, tg_user_id=%d", userID, input.TGUserID)
+		api.RespondForbidden(w, "Cannot register another Telegram account")
 		return
 	}
+	input.TGUserID = userID
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("POST /users - Validation failed: %v", err)
+		api.RespondValidationError(w, err)
+		return
+	}
+
+	if tgUser, ok := middleware.GetTelegramUserFromContext(r.Context()); ok {
+		input.Telegram = &models.TelegramProfileDTO{
+			Username:     tgUser.Username,
+			FirstName:    tgUser.FirstName,
+			LastName:     tgUser.LastName,
+			LanguageCode: tgUser.LanguageCode,
+		}
+	}
 
 	user, err := h.service.CreateUser(r.Context(), input)
 	if err != nil {
-		if errors.Is(err, userservice.ErrUserAlreadyExists) {
-			h.log.Warn("POST /users - User already exists: tg_user_id=%d", input.TGUserID)
-			api.RespondUserAlreadyExists(w)
-			return
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("POST /users - Request rejected: tg_user_id=%d, error=%v", input.TGUserID, err)
+		} else {
+			h.log.Error("POST /users - Failed to create user: tg_user_id=%d, error=%v", input.TGUserID, err)
 		}
-		h.log.Error("POST /users - Failed to create user: tg_user_id=%d, error=%v", input.TGUserID, err)
-		api.RespondInternalError(w)
 		return
 	}
 
@@ -1,7 +1,6 @@
 package delete_car
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -56,18 +55,11 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 
 	err = h.service.DeleteCar(r.Context(), userID, carID, role)
 	if err != nil {
-		if errors.Is(err, userservice.ErrCarNotFound) {
-			h.log.Warn("DELETE /users/me/cars/{car_id} - Car not found: user_id=%d, car_id=%d", userID, carID)
-			api.RespondCarNotFound(w)
-			return
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("DELETE /users/me/cars/{car_id} - Request rejected: user_id=%d, car_id=%d, error=%v", userID, carID, err)
+		} else {
+			h.log.Error("DELETE /users/me/cars/{car_id} - Failed to delete car: user_id=%d, car_id=%d, error=%v", userID, carID, err)
 		}
-		if errors.Is(err, userservice.ErrCarAccessDenied) {
-			h.log.Warn("DELETE /users/me/cars/{car_id} - Access denied: user_id=%d, car_id=%d, role=%s", userID, carID, role)
-			api.RespondCarAccessDenied(w)
-			return
-		}
-		h.log.Error("DELETE /users/me/cars/{car_id} - Failed to delete car: user_id=%d, car_id=%d, error=%v", userID, carID, err)
-		api.RespondInternalError(w)
 		return
 	}
 
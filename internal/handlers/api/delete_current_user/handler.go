@@ -1,7 +1,6 @@
 package delete_current_user
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/m04kA/SMC-UserService/internal/handlers/api"
@@ -32,13 +31,11 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 
 	err = h.service.DeleteUser(r.Context(), userID)
 	if err != nil {
-		if errors.Is(err, userservice.ErrUserNotFound) {
-			h.log.Warn("DELETE /users/me - User not found: user_id=%d", userID)
-			api.RespondUserNotFound(w)
-			return
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("DELETE /users/me - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("DELETE /users/me - Failed to delete user: user_id=%d, error=%v", userID, err)
 		}
-		h.log.Error("DELETE /users/me - Failed to delete user: user_id=%d, error=%v", userID, err)
-		api.RespondInternalError(w)
 		return
 	}
 
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/service/company"
+	"github.com/m04kA/SMC-UserService/internal/service/export"
+	"github.com/m04kA/SMC-UserService/internal/service/invitation"
+	"github.com/m04kA/SMC-UserService/internal/service/phone"
+	"github.com/m04kA/SMC-UserService/internal/service/plateclaim"
+	"github.com/m04kA/SMC-UserService/internal/service/platelookup"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac"
+	"github.com/m04kA/SMC-UserService/internal/service/serviceauth"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+// Коды ошибок сервисов
+const (
+	CodeUserNotFound            ErrorCode = "USER_NOT_FOUND"
+	CodeUserAlreadyExists       ErrorCode = "USER_ALREADY_EXISTS"
+	CodeUserDeleted             ErrorCode = "USER_DELETED"
+	CodeUserNotDeleted          ErrorCode = "USER_NOT_DELETED"
+	CodeRestoreExpired          ErrorCode = "RESTORE_EXPIRED"
+	CodeCarNotFound             ErrorCode = "CAR_NOT_FOUND"
+	CodeCarAccessDenied         ErrorCode = "CAR_ACCESS_DENIED"
+	CodeInvalidRole             ErrorCode = "INVALID_ROLE"
+	CodeLastSuperUser           ErrorCode = "LAST_SUPERUSER"
+	CodeInvalidStatus           ErrorCode = "INVALID_STATUS"
+	CodeSuspensionExpired       ErrorCode = "SUSPENSION_IN_PAST"
+	CodeOwnStatusChange         ErrorCode = "OWN_STATUS_CHANGE"
+	CodeInvalidFilter           ErrorCode = "INVALID_FILTER"
+	CodeInvalidCursor           ErrorCode = "INVALID_CURSOR"
+	CodeInvalidPhone            ErrorCode = "INVALID_PHONE"
+	CodePhoneNumberTaken        ErrorCode = "PHONE_NUMBER_TAKEN"
+	CodeInvalidPlate            ErrorCode = "INVALID_LICENSE_PLATE"
+	CodePhoneNotSet             ErrorCode = "PHONE_NOT_SET"
+	CodePhoneAlreadyVerified    ErrorCode = "PHONE_ALREADY_VERIFIED"
+	CodeResendTooSoon           ErrorCode = "VERIFICATION_RESEND_TOO_SOON"
+	CodeVerificationNotFound    ErrorCode = "VERIFICATION_NOT_FOUND"
+	CodeVerificationExpired     ErrorCode = "VERIFICATION_CODE_EXPIRED"
+	CodeVerificationInvalid     ErrorCode = "VERIFICATION_CODE_INVALID"
+	CodeTooManyAttempts         ErrorCode = "VERIFICATION_ATTEMPTS_EXCEEDED"
+	CodeVerificationUndelivered ErrorCode = "VERIFICATION_DELIVERY_FAILED"
+	CodeRoleNotFound            ErrorCode = "ROLE_NOT_FOUND"
+	CodeRoleAlreadyExists       ErrorCode = "ROLE_ALREADY_EXISTS"
+	CodeInvalidRoleName         ErrorCode = "INVALID_ROLE_NAME"
+	CodeUnknownPermission       ErrorCode = "UNKNOWN_PERMISSION"
+	CodeProtectedRole           ErrorCode = "PROTECTED_ROLE"
+	CodeCredentialNotFound      ErrorCode = "CREDENTIAL_NOT_FOUND"
+	CodeCredentialRevoked       ErrorCode = "CREDENTIAL_REVOKED"
+	CodeInvalidServiceName      ErrorCode = "INVALID_SERVICE_NAME"
+	CodeUnsupportedFormat       ErrorCode = "UNSUPPORTED_EXPORT_FORMAT"
+	CodeCompanyNotFound         ErrorCode = "COMPANY_NOT_FOUND"
+	CodeCompanyMemberNotFound   ErrorCode = "COMPANY_MEMBER_NOT_FOUND"
+	CodeInvalidCompanyRole      ErrorCode = "INVALID_COMPANY_ROLE"
+	CodeUserNotManager          ErrorCode = "USER_NOT_MANAGER"
+	CodeInvitationNotFound      ErrorCode = "INVITATION_NOT_FOUND"
+	CodeInvitationAccepted      ErrorCode = "INVITATION_ALREADY_ACCEPTED"
+	CodeInvitationRevoked       ErrorCode = "INVITATION_REVOKED"
+	CodeInvitationExpired       ErrorCode = "INVITATION_EXPIRED"
+	CodeInvitationForbidden     ErrorCode = "INVITATION_FORBIDDEN"
+	CodeInvalidCompanyGrant     ErrorCode = "INVALID_COMPANY_GRANT"
+	CodeInvalidInvitationTTL    ErrorCode = "INVALID_INVITATION_TTL"
+	CodeCompanyAccessDenied     ErrorCode = "COMPANY_ACCESS_DENIED"
+	CodePlateTaken              ErrorCode = "LICENSE_PLATE_TAKEN"
+	CodePlateClaimPending       ErrorCode = "PLATE_CLAIM_PENDING"
+	CodePlateClaimNotFound      ErrorCode = "PLATE_CLAIM_NOT_FOUND"
+	CodePlateClaimResolved      ErrorCode = "PLATE_CLAIM_RESOLVED"
+	CodeOwnPlateClaim           ErrorCode = "OWN_PLATE_CLAIM"
+	CodeInvalidResolution       ErrorCode = "INVALID_RESOLUTION"
+	CodeClaimForbidden          ErrorCode = "PLATE_CLAIM_FORBIDDEN"
+)
+
+// serviceError описание ответа на sentinel ошибку сервиса
+type serviceError struct {
+	err    error
+	status int
+	code   ErrorCode
+	detail string // Пустой detail - в ответ попадает текст ошибки с подробностями
+}
+
+// serviceErrors единое соответствие ошибок из contract.go сервисов кодам ответа.
+// Проверяется по порядку через errors.Is.
+var serviceErrors = []serviceError{
+	{userservice.ErrUserNotFound, http.StatusNotFound, CodeUserNotFound, "User not found"},
+	{userservice.ErrUserAlreadyExists, http.StatusConflict, CodeUserAlreadyExists, "User with this Telegram ID already exists"},
+	{userservice.ErrUserDeleted, http.StatusConflict, CodeUserDeleted, "User account is deleted, restore it via POST /users/me/restore"},
+	{userservice.ErrUserNotDeleted, http.StatusConflict, CodeUserNotDeleted, "User is not deleted"},
+	{userservice.ErrRestoreExpired, http.StatusGone, CodeRestoreExpired, "Restore period has expired"},
+	{userservice.ErrCarNotFound, http.StatusNotFound, CodeCarNotFound, "Car not found"},
+	{userservice.ErrCarAccessDenied, http.StatusForbidden, CodeCarAccessDenied, "Access denied to this car"},
+	{userservice.ErrInvalidRole, http.StatusBadRequest, CodeInvalidRole, "Invalid role"},
+	{userservice.ErrLastSuperUser, http.StatusConflict, CodeLastSuperUser, "Cannot demote or delete the last superuser"},
+	{userservice.ErrInvalidStatus, http.StatusBadRequest, CodeInvalidStatus, "Invalid status"},
+	{userservice.ErrSuspensionExpired, http.StatusBadRequest, CodeSuspensionExpired, "suspended_until must be in the future"},
+	{userservice.ErrOwnStatusChange, http.StatusConflict, CodeOwnStatusChange, "Cannot change own account status"},
+	{userservice.ErrInvalidFilter, http.StatusBadRequest, CodeInvalidFilter, ""},
+	{userservice.ErrInvalidCursor, http.StatusBadRequest, CodeInvalidCursor, ""},
+	{userservice.ErrInvalidPhone, http.StatusBadRequest, CodeInvalidPhone, "Invalid phone number"},
+	{userservice.ErrPhoneNumberTaken, http.StatusConflict, CodePhoneNumberTaken, "Phone number is used by another user"},
+	{userservice.ErrInvalidPlate, http.StatusBadRequest, CodeInvalidPlate, ""},
+	{userservice.ErrPlateTaken, http.StatusConflict, CodePlateTaken, "License plate is registered by another user"},
+	{userservice.ErrPlateClaimPending, http.StatusConflict, CodePlateClaimPending, "Car cannot be selected until the license plate claim is resolved"},
+
+	{phone.ErrPhoneNotSet, http.StatusBadRequest, CodePhoneNotSet, "Phone number is not set"},
+	{phone.ErrInvalidPhoneNumber, http.StatusBadRequest, CodeInvalidPhone, "Phone number must be in E.164 format"},
+	{phone.ErrPhoneAlreadyVerified, http.StatusConflict, CodePhoneAlreadyVerified, "Phone number is already verified"},
+	{phone.ErrResendTooSoon, http.StatusTooManyRequests, CodeResendTooSoon, "Verification code was sent recently"},
+	{phone.ErrVerificationNotFound, http.StatusNotFound, CodeVerificationNotFound, "No pending phone verification"},
+	{phone.ErrCodeExpired, http.StatusGone, CodeVerificationExpired, "Verification code has expired"},
+	{phone.ErrInvalidCode, http.StatusBadRequest, CodeVerificationInvalid, "Invalid verification code"},
+	{phone.ErrTooManyAttempts, http.StatusTooManyRequests, CodeTooManyAttempts, "Too many attempts, request a new code"},
+	{phone.ErrServiceSendCode, http.StatusBadGateway, CodeVerificationUndelivered, "Failed to send verification code"},
+
+	{rbac.ErrRoleNotFound, http.StatusNotFound, CodeRoleNotFound, "Role not found"},
+	{rbac.ErrRoleAlreadyExists, http.StatusConflict, CodeRoleAlreadyExists, "Role already exists"},
+	{rbac.ErrInvalidRoleName, http.StatusBadRequest, CodeInvalidRoleName, "Invalid role name"},
+	{rbac.ErrUnknownPermission, http.StatusBadRequest, CodeUnknownPermission, ""},
+	{rbac.ErrProtectedRole, http.StatusForbidden, CodeProtectedRole, "Permissions of this role cannot be changed"},
+
+	{serviceauth.ErrCredentialNotFound, http.StatusNotFound, CodeCredentialNotFound, "Service credential not found"},
+	{serviceauth.ErrCredentialRevoked, http.StatusUnauthorized, CodeCredentialRevoked, "Service credential is revoked"},
+	{serviceauth.ErrInvalidServiceName, http.StatusBadRequest, CodeInvalidServiceName, "Invalid service name"},
+
+	{export.ErrUnsupportedFormat, http.StatusBadRequest, CodeUnsupportedFormat, "Unsupported export format"},
+
+	{company.ErrCompanyNotFound, http.StatusNotFound, CodeCompanyNotFound, "Company not found"},
+	{company.ErrMemberNotFound, http.StatusNotFound, CodeCompanyMemberNotFound, "User is not a member of the company"},
+	{company.ErrInvalidRole, http.StatusBadRequest, CodeInvalidCompanyRole, "Invalid company role"},
+	{company.ErrUserNotManager, http.StatusConflict, CodeUserNotManager, "Only users with the manager role can be assigned to a company"},
+
+	{invitation.ErrInvitationNotFound, http.StatusNotFound, CodeInvitationNotFound, "Invitation not found"},
+	{invitation.ErrInvitationAccepted, http.StatusConflict, CodeInvitationAccepted, "Invitation has already been accepted"},
+	{invitation.ErrInvitationRevoked, http.StatusGone, CodeInvitationRevoked, "Invitation is revoked"},
+	{invitation.ErrInvitationExpired, http.StatusGone, CodeInvitationExpired, "Invitation has expired"},
+	{invitation.ErrInvitationForbidden, http.StatusForbidden, CodeInvitationForbidden, "Not allowed to manage invitations for this role or company"},
+	{invitation.ErrInvalidCompanyGrant, http.StatusBadRequest, CodeInvalidCompanyGrant, "company_id and company_role must be set together and require the manager role"},
+	{invitation.ErrInvalidTTL, http.StatusBadRequest, CodeInvalidInvitationTTL, "Invitation ttl exceeds the maximum"},
+
+	{platelookup.ErrNotCompanyMember, http.StatusForbidden, CodeCompanyAccessDenied, "User is not a member of the company"},
+
+	{plateclaim.ErrPlateClaimNotFound, http.StatusNotFound, CodePlateClaimNotFound, "Plate claim not found"},
+	{plateclaim.ErrPlateClaimResolved, http.StatusConflict, CodePlateClaimResolved, "Plate claim is already resolved"},
+	{plateclaim.ErrOwnPlateClaim, http.StatusConflict, CodeOwnPlateClaim, "Cannot resolve a plate claim involving own car"},
+	{plateclaim.ErrInvalidResolution, http.StatusUnprocessableEntity, CodeInvalidResolution, "Resolution must be one of: transferred, rejected, shared"},
+	{plateclaim.ErrClaimForbidden, http.StatusForbidden, CodeClaimForbidden, "Plate claim does not involve members of your companies"},
+	{plateclaim.ErrInvalidFilter, http.StatusBadRequest, CodeInvalidFilter, ""},
+}
+
+// RespondServiceError отправляет ответ на ошибку сервиса по таблице serviceErrors.
+// Ошибки проверки полей - 422, неизвестные ошибки - 500.
+// Возвращает false для неизвестной ошибки: ее обработчик записывает в лог.
+func RespondServiceError(w http.ResponseWriter, err error) bool {
+	if validator.IsValidationError(err) {
+		RespondValidationError(w, err)
+		return true
+	}
+	for _, se := range serviceErrors {
+		if errors.Is(err, se.err) {
+			detail := se.detail
+			if detail == "" {
+				detail = err.Error()
+			}
+			RespondProblem(w, se.status, se.code, detail)
+			return true
+		}
+	}
+	RespondInternalError(w)
+	return false
+}
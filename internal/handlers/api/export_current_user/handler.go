@@ -0,0 +1,79 @@
+package export_current_user
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/export"
+	"github.com/m04kA/SMC-UserService/internal/service/export/models"
+)
+
+// exportWriteTimeout время на запись выгрузки
+const exportWriteTimeout = 5 * time.Minute
+
+var contentTypes = map[models.Format]string{
+	models.FormatJSON: "application/json",
+	models.FormatZip:  "application/zip",
+}
+
+type Handler struct {
+	service *export.Service
+	log     Logger
+}
+
+func NewHandler(service *export.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /users/me/export?format=json|zip
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("GET /users/me/export - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	format := models.FormatJSON
+	if v := r.URL.Query().Get("format"); v != "" {
+		format = models.Format(v)
+	}
+	if !format.IsValid() {
+		h.log.Warn("GET /users/me/export - Unsupported format: user_id=%d, format=%s", userID, format)
+		api.RespondBadRequest(w, "Unsupported format, expected json or zip")
+		return
+	}
+
+	profile, err := h.service.GetProfile(r.Context(), userID)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("GET /users/me/export - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("GET /users/me/export - Failed to get user: user_id=%d, error=%v", userID, err)
+		}
+		return
+	}
+
+	// Выгрузка может писаться дольше общего write_timeout сервера
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(exportWriteTimeout)); err != nil {
+		h.log.Warn("GET /users/me/export - Failed to extend write deadline: %v", err)
+	}
+
+	w.Header().Set("Content-Type", contentTypes[format])
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%d-export.%s"`, userID, format))
+	w.WriteHeader(http.StatusOK)
+
+	// Заголовки уже отправлены: при ошибке соединение разрывается, чтобы клиент не принял неполный файл за выгрузку
+	if err := h.service.Write(r.Context(), w, format, profile); err != nil {
+		h.log.Error("GET /users/me/export - Failed to write export: user_id=%d, error=%v", userID, err)
+		panic(http.ErrAbortHandler)
+	}
+
+	h.log.Info("GET /users/me/export - Export completed: user_id=%d, format=%s", userID, format)
+}
@@ -0,0 +1,40 @@
+package find_cars_by_plate
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /internal/cars?plate=
+// Номер в логи не пишется: по нему можно установить владельца.
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	service := middleware.GetServiceFromContext(r.Context())
+
+	cars, err := h.service.FindCarsByPlate(r.Context(), r.URL.Query().Get("plate"))
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("GET /internal/cars - request rejected: %v, service=%s", err, service)
+		} else {
+			h.log.Error("GET /internal/cars - failed to find cars: %v, service=%s", err, service)
+		}
+		return
+	}
+
+	h.log.Info("GET /internal/cars - success, cars=%d, service=%s", len(cars.Cars), service)
+	api.RespondJSON(w, http.StatusOK, cars)
+}
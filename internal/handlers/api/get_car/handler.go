@@ -0,0 +1,62 @@
+package get_car
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /users/me/cars/{car_id}
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("GET /users/me/cars/{car_id} - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	role, err := middleware.GetRoleFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("GET /users/me/cars/{car_id} - Cannot extract role: user_id=%d", userID)
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	carIDStr := vars["car_id"]
+	carID, err := strconv.ParseInt(carIDStr, 10, 64)
+	if err != nil {
+		h.log.Warn("GET /users/me/cars/{car_id} - Invalid car_id: user_id=%d, car_id=%s", userID, carIDStr)
+		api.RespondBadRequest(w, "Invalid car_id")
+		return
+	}
+
+	car, err := h.service.GetCar(r.Context(), userID, carID, role)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("GET /users/me/cars/{car_id} - Request rejected: user_id=%d, car_id=%d, error=%v", userID, carID, err)
+		} else {
+			h.log.Error("GET /users/me/cars/{car_id} - Failed to get car: user_id=%d, car_id=%d, error=%v", userID, carID, err)
+		}
+		return
+	}
+
+	h.log.Info("GET /users/me/cars/{car_id} - Car retrieved: user_id=%d, car_id=%d", userID, carID)
+	api.RespondJSON(w, http.StatusOK, car)
+}
@@ -1,7 +1,6 @@
 package get_current_user
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/m04kA/SMC-UserService/internal/handlers/api"
@@ -32,13 +31,11 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.service.GetUserWithCars(r.Context(), userID)
 	if err != nil {
-		if errors.Is(err, userservice.ErrUserNotFound) {
-			h.log.Warn("GET /users/me - User not found: user_id=%d", userID)
-			api.RespondUserNotFound(w)
-			return
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("GET /users/me - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("GET /users/me - Failed to get user: user_id=%d, error=%v", userID, err)
 		}
-		h.log.Error("GET /users/me - Failed to get user: user_id=%d, error=%v", userID, err)
-		api.RespondInternalError(w)
 		return
 	}
 
@@ -0,0 +1,41 @@
+package get_my_companies
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/company"
+)
+
+type Handler struct {
+	service *company.Service
+	log     Logger
+}
+
+func NewHandler(service *company.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /users/me/companies
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("GET /users/me/companies - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	companies, err := h.service.GetUserCompanies(r.Context(), userID)
+	if err != nil {
+		h.log.Error("GET /users/me/companies - Failed to get companies: user_id=%d, error=%v", userID, err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("GET /users/me/companies - success: user_id=%d, companies=%d", userID, len(companies.Companies))
+	api.RespondJSON(w, http.StatusOK, companies)
+}
@@ -0,0 +1,48 @@
+package get_my_permissions
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac"
+)
+
+type Handler struct {
+	service *rbac.Service
+	log     Logger
+}
+
+func NewHandler(service *rbac.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /users/me/permissions
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("GET /users/me/permissions - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	role, err := middleware.GetRoleFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("GET /users/me/permissions - Role not found in context: user_id=%d", userID)
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	permissions, err := h.service.GetRolePermissions(r.Context(), role)
+	if err != nil {
+		h.log.Error("GET /users/me/permissions - Failed to get permissions: user_id=%d, role=%s, error=%v", userID, role, err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("GET /users/me/permissions - success: user_id=%d, role=%s, permissions=%d", userID, role, len(permissions.Permissions))
+	api.RespondJSON(w, http.StatusOK, permissions)
+}
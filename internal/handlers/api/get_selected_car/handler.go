@@ -1,12 +1,12 @@
 package get_selected_car
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
 	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
 )
 
@@ -24,32 +24,28 @@ func NewHandler(service *userservice.Service, log Logger) *Handler {
 
 // Handle GET /internal/users/{tg_user_id}/cars/selected
 func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	service := middleware.GetServiceFromContext(r.Context())
+
 	vars := mux.Vars(r)
 	userIDStr := vars["tg_user_id"]
 
 	userID, err := strconv.ParseInt(userIDStr, 10, 64)
 	if err != nil {
-		h.log.Warn("GET /internal/users/{tg_user_id}/cars/selected - Invalid user_id format: %s", userIDStr)
-		api.RespondJSON(w, http.StatusBadRequest, map[string]string{
-			"error": "Invalid user_id format",
-		})
+		h.log.Warn("GET /internal/users/{tg_user_id}/cars/selected - Invalid user_id format: %s, service=%s", userIDStr, service)
+		api.RespondBadRequest(w, "Invalid user_id format")
 		return
 	}
 
 	car, err := h.service.GetSelectedCar(r.Context(), userID)
 	if err != nil {
-		if errors.Is(err, userservice.ErrCarNotFound) {
-			h.log.Warn("GET /internal/users/{tg_user_id}/cars/selected - No selected car: user_id=%d", userID)
-			api.RespondJSON(w, http.StatusNotFound, map[string]string{
-				"error": "No selected car found",
-			})
-			return
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("GET /internal/users/{tg_user_id}/cars/selected - Request rejected: user_id=%d, error=%v, service=%s", userID, err, service)
+		} else {
+			h.log.Error("GET /internal/users/{tg_user_id}/cars/selected - Failed to get selected car: user_id=%d, error=%v, service=%s", userID, err, service)
 		}
-		h.log.Error("GET /internal/users/{tg_user_id}/cars/selected - Failed to get selected car: user_id=%d, error=%v", userID, err)
-		api.RespondInternalError(w)
 		return
 	}
 
-	h.log.Info("GET /internal/users/{tg_user_id}/cars/selected - Selected car retrieved: user_id=%d, car_id=%d", userID, car.ID)
+	h.log.Info("GET /internal/users/{tg_user_id}/cars/selected - Selected car retrieved: user_id=%d, car_id=%d, service=%s", userID, car.ID, service)
 	api.RespondJSON(w, http.StatusOK, car)
 }
@@ -4,13 +4,10 @@ import (
 	"net/http"
 
 	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
 	"github.com/m04kA/SMC-UserService/internal/service/user"
 )
 
-const (
-	ErrInternalServer = "внутренняя ошибка сервера"
-)
-
 type Handler struct {
 	service *user.Service
 	log     Logger
@@ -29,14 +26,16 @@ type Response struct {
 }
 
 func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	service := middleware.GetServiceFromContext(r.Context())
+
 	// Получаем список всех superuser ID
 	superUserIDs, err := h.service.GetSuperUsers(r.Context())
 	if err != nil {
-		h.log.Error("GET /internal/users/superusers - failed to get superusers: %v", err)
-		api.RespondError(w, http.StatusInternalServerError, ErrInternalServer)
+		h.log.Error("GET /internal/users/superusers - failed to get superusers: %v, service=%s", err, service)
+		api.RespondInternalError(w)
 		return
 	}
 
-	h.log.Info("GET /internal/users/superusers - success, found %d superusers", len(superUserIDs))
+	h.log.Info("GET /internal/users/superusers - success, found %d superusers, service=%s", len(superUserIDs), service)
 	api.RespondJSON(w, http.StatusOK, Response{SuperUserIDs: superUserIDs})
 }
@@ -1,36 +1,49 @@
 package get_user_by_id
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 
 	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/company"
+	companymodels "github.com/m04kA/SMC-UserService/internal/service/company/models"
 	"github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
 )
 
 type Handler struct {
-	service *user.Service
-	log     Logger
+	service   *user.Service
+	companies *company.Service
+	log       Logger
 }
 
-func NewHandler(service *user.Service, log Logger) *Handler {
+func NewHandler(service *user.Service, companies *company.Service, log Logger) *Handler {
 	return &Handler{
-		service: service,
-		log:     log,
+		service:   service,
+		companies: companies,
+		log:       log,
 	}
 }
 
+// Response пользователь с автомобилями и компаниями, в которых он менеджер
+type Response struct {
+	models.UserWithCarsDTO
+	Companies []companymodels.MembershipDTO `json:"companies"`
+}
+
 func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	service := middleware.GetServiceFromContext(r.Context())
+
 	vars := mux.Vars(r)
 	userIDStr := vars["tg_user_id"]
 
 	// Парсим tg_user_id из URL
 	tgUserID, err := strconv.ParseInt(userIDStr, 10, 64)
 	if err != nil {
-		h.log.Warn("GET /internal/users/{tg_user_id} - invalid user ID format: %s", userIDStr)
+		h.log.Warn("GET /internal/users/{tg_user_id} - invalid user ID format: %s, service=%s", userIDStr, service)
 		api.RespondError(w, http.StatusBadRequest, "invalid user ID format")
 		return
 	}
@@ -38,17 +51,25 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 	// Получаем пользователя с автомобилями
 	userWithCars, err := h.service.GetUserWithCars(r.Context(), tgUserID)
 	if err != nil {
-		if errors.Is(err, user.ErrUserNotFound) {
-			h.log.Warn("GET /internal/users/%d - user not found", tgUserID)
-			api.RespondError(w, http.StatusNotFound, "user not found")
-			return
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("GET /internal/users/%d - request rejected: %v, service=%s", tgUserID, err, service)
+		} else {
+			h.log.Error("GET /internal/users/%d - failed to get user: %v, service=%s", tgUserID, err, service)
 		}
+		return
+	}
 
-		h.log.Error("GET /internal/users/%d - failed to get user: %v", tgUserID, err)
-		api.RespondError(w, http.StatusInternalServerError, "internal server error")
+	// Членства в компаниях нужны другим сервисам для проверки прав менеджера
+	memberships, err := h.companies.GetUserCompanies(r.Context(), tgUserID)
+	if err != nil {
+		h.log.Error("GET /internal/users/%d - failed to get companies: %v, service=%s", tgUserID, err, service)
+		api.RespondInternalError(w)
 		return
 	}
 
-	h.log.Info("GET /internal/users/%d - success", tgUserID)
-	api.RespondJSON(w, http.StatusOK, userWithCars)
+	h.log.Info("GET /internal/users/%d - success, service=%s", tgUserID, service)
+	api.RespondJSON(w, http.StatusOK, Response{
+		UserWithCarsDTO: *userWithCars,
+		Companies:       memberships.Companies,
+	})
 }
@@ -0,0 +1,42 @@
+package get_users_by_phone
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *user.Service
+	log     Logger
+}
+
+func NewHandler(service *user.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /internal/users/by-phone/{phone}
+// Номер в логи не пишется: это персональные данные.
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	service := middleware.GetServiceFromContext(r.Context())
+
+	users, err := h.service.GetUsersByPhone(r.Context(), mux.Vars(r)["phone"])
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("GET /internal/users/by-phone - request rejected: %v, service=%s", err, service)
+		} else {
+			h.log.Error("GET /internal/users/by-phone - failed to get users: %v, service=%s", err, service)
+		}
+		return
+	}
+
+	h.log.Info("GET /internal/users/by-phone - success, users=%d, service=%s", len(users.Users), service)
+	api.RespondJSON(w, http.StatusOK, users)
+}
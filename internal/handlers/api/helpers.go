@@ -2,13 +2,12 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
-)
 
-type ErrorResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-}
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
 
 // RespondJSON отправляет JSON ответ
 func RespondJSON(w http.ResponseWriter, status int, payload interface{}) {
@@ -19,12 +18,10 @@ func RespondJSON(w http.ResponseWriter, status int, payload interface{}) {
 	}
 }
 
-// RespondError отправляет ошибку в формате JSON
+// RespondError отправляет ошибку с общим для статуса кодом (BAD_REQUEST, NOT_FOUND, ...).
+// Для ошибок сервисов используйте RespondServiceError.
 func RespondError(w http.ResponseWriter, status int, message string) {
-	RespondJSON(w, status, ErrorResponse{
-		Code:    status,
-		Message: message,
-	})
+	RespondProblem(w, status, codeForStatus(status), message)
 }
 
 // DecodeJSON парсит JSON из request body
@@ -34,29 +31,46 @@ func DecodeJSON(r *http.Request, v interface{}) error {
 
 // Error handlers
 func RespondUserNotFound(w http.ResponseWriter) {
-	RespondError(w, http.StatusNotFound, "User not found")
-}
-
-func RespondUserAlreadyExists(w http.ResponseWriter) {
-	RespondError(w, http.StatusConflict, "User with this Telegram ID already exists")
+	RespondServiceError(w, userservice.ErrUserNotFound)
 }
 
 func RespondCarNotFound(w http.ResponseWriter) {
-	RespondError(w, http.StatusNotFound, "Car not found")
+	RespondServiceError(w, userservice.ErrCarNotFound)
 }
 
 func RespondCarAccessDenied(w http.ResponseWriter) {
-	RespondError(w, http.StatusForbidden, "Access denied to this car")
+	RespondServiceError(w, userservice.ErrCarAccessDenied)
 }
 
 func RespondBadRequest(w http.ResponseWriter, message string) {
-	RespondError(w, http.StatusBadRequest, message)
+	RespondProblem(w, http.StatusBadRequest, CodeBadRequest, message)
+}
+
+// RespondInvalidBody отвечает на тело запроса, которое не удалось разобрать
+func RespondInvalidBody(w http.ResponseWriter) {
+	RespondProblem(w, http.StatusBadRequest, CodeInvalidBody, "Invalid request body")
 }
 
 func RespondUnauthorized(w http.ResponseWriter, message string) {
-	RespondError(w, http.StatusUnauthorized, message)
+	RespondProblem(w, http.StatusUnauthorized, CodeUnauthorized, message)
+}
+
+func RespondForbidden(w http.ResponseWriter, message string) {
+	RespondProblem(w, http.StatusForbidden, CodeForbidden, message)
 }
 
 func RespondInternalError(w http.ResponseWriter) {
-	RespondError(w, http.StatusInternalServerError, "Internal server error")
+	RespondProblem(w, http.StatusInternalServerError, CodeInternal, "Internal server error")
+}
+
+// RespondValidationError отправляет 422 со списком ошибок полей из validator.Errors
+func RespondValidationError(w http.ResponseWriter, err error) {
+	var errs validator.Errors
+	errors.As(err, &errs)
+	writeProblem(w, Problem{
+		Status: http.StatusUnprocessableEntity,
+		Code:   CodeValidationFailed,
+		Detail: "Validation failed",
+		Errors: errs,
+	})
 }
@@ -0,0 +1,46 @@
+package list_cars
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /users/me/cars
+// Параметры: sort (created, brand, selected; префикс "-" - в обратном порядке)
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("GET /users/me/cars - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	sort := r.URL.Query().Get("sort")
+	cars, err := h.service.ListCars(r.Context(), userID, sort)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("GET /users/me/cars - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("GET /users/me/cars - Failed to list cars: user_id=%d, error=%v", userID, err)
+		}
+		return
+	}
+
+	h.log.Info("GET /users/me/cars - Cars retrieved: user_id=%d, count=%d", userID, len(cars.Cars))
+	api.RespondJSON(w, http.StatusOK, cars)
+}
@@ -0,0 +1,33 @@
+package list_companies
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/service/company"
+)
+
+type Handler struct {
+	service *company.Service
+	log     Logger
+}
+
+func NewHandler(service *company.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /admin/companies
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	companies, err := h.service.ListCompanies(r.Context())
+	if err != nil {
+		h.log.Error("GET /admin/companies - Failed to list companies: %v", err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("GET /admin/companies - success, found %d companies", len(companies.Companies))
+	api.RespondJSON(w, http.StatusOK, companies)
+}
@@ -0,0 +1,71 @@
+package list_impersonation_audit
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/service/impersonation"
+	"github.com/m04kA/SMC-UserService/internal/service/impersonation/models"
+)
+
+type Handler struct {
+	service *impersonation.Service
+	log     Logger
+}
+
+func NewHandler(service *impersonation.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Response структура для ответа с журналом имперсонации
+type Response struct {
+	Events []models.AuditEventDTO `json:"events"`
+}
+
+// Handle GET /admin/impersonation-audit?actor_id=&target_id=&limit=
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var filter impersonation.AuditFilter
+	query := r.URL.Query()
+
+	if v := query.Get("actor_id"); v != "" {
+		actorID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			h.log.Warn("GET /admin/impersonation-audit - Invalid actor_id: %s", v)
+			api.RespondBadRequest(w, "Invalid actor_id")
+			return
+		}
+		filter.ActorID = actorID
+	}
+	if v := query.Get("target_id"); v != "" {
+		targetID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			h.log.Warn("GET /admin/impersonation-audit - Invalid target_id: %s", v)
+			api.RespondBadRequest(w, "Invalid target_id")
+			return
+		}
+		filter.TargetID = targetID
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			h.log.Warn("GET /admin/impersonation-audit - Invalid limit: %s", v)
+			api.RespondBadRequest(w, "Invalid limit")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	events, err := h.service.ListEvents(r.Context(), filter)
+	if err != nil {
+		h.log.Error("GET /admin/impersonation-audit - Failed to list events: %v", err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("GET /admin/impersonation-audit - success, found %d events", len(events))
+	api.RespondJSON(w, http.StatusOK, Response{Events: events})
+}
@@ -0,0 +1,51 @@
+package list_invitations
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/invitation"
+)
+
+type Handler struct {
+	service *invitation.Service
+	log     Logger
+}
+
+func NewHandler(service *invitation.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /invitations
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("GET /invitations - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	role, err := middleware.GetRoleFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("GET /invitations - Role not found in context: user_id=%d", userID)
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	invitations, err := h.service.ListInvitations(r.Context(), userID, role)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("GET /invitations - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("GET /invitations - Failed to list invitations: user_id=%d, error=%v", userID, err)
+		}
+		return
+	}
+
+	h.log.Info("GET /invitations - success: user_id=%d, found %d invitations", userID, len(invitations.Invitations))
+	api.RespondJSON(w, http.StatusOK, invitations)
+}
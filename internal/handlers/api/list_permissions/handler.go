@@ -0,0 +1,39 @@
+package list_permissions
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac/models"
+)
+
+type Handler struct {
+	service *rbac.Service
+	log     Logger
+}
+
+func NewHandler(service *rbac.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Response структура для ответа со справочником прав
+type Response struct {
+	Permissions []models.PermissionDTO `json:"permissions"`
+}
+
+// Handle GET /admin/permissions
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	permissions, err := h.service.ListPermissions(r.Context())
+	if err != nil {
+		h.log.Error("GET /admin/permissions - Failed to list permissions: %v", err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("GET /admin/permissions - success, found %d permissions", len(permissions))
+	api.RespondJSON(w, http.StatusOK, Response{Permissions: permissions})
+}
@@ -0,0 +1,66 @@
+package list_plate_claims
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/service/plateclaim"
+	"github.com/m04kA/SMC-UserService/internal/service/plateclaim/models"
+)
+
+type Handler struct {
+	service *plateclaim.Service
+	log     Logger
+}
+
+func NewHandler(service *plateclaim.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Response структура для ответа со списком споров о госномерах
+type Response struct {
+	Claims []models.PlateClaimDTO `json:"claims"`
+}
+
+// Handle GET /admin/plate-claims?status=&tg_user_id=&limit=
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := plateclaim.ClaimFilter{Status: domain.PlateClaimStatus(query.Get("status"))}
+
+	if v := query.Get("tg_user_id"); v != "" {
+		userID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			h.log.Warn("GET /admin/plate-claims - Invalid tg_user_id: %s", v)
+			api.RespondBadRequest(w, "Invalid tg_user_id")
+			return
+		}
+		filter.UserID = userID
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			h.log.Warn("GET /admin/plate-claims - Invalid limit: %s", v)
+			api.RespondBadRequest(w, "Invalid limit")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	claims, err := h.service.ListClaims(r.Context(), filter)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("GET /admin/plate-claims - Request rejected: %v", err)
+		} else {
+			h.log.Error("GET /admin/plate-claims - Failed to list claims: %v", err)
+		}
+		return
+	}
+
+	h.log.Info("GET /admin/plate-claims - success, found %d claims", len(claims))
+	api.RespondJSON(w, http.StatusOK, Response{Claims: claims})
+}
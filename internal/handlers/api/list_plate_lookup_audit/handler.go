@@ -0,0 +1,71 @@
+package list_plate_lookup_audit
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/service/platelookup"
+	"github.com/m04kA/SMC-UserService/internal/service/platelookup/models"
+)
+
+type Handler struct {
+	service *platelookup.Service
+	log     Logger
+}
+
+func NewHandler(service *platelookup.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Response структура для ответа с журналом поиска по госномеру
+type Response struct {
+	Events []models.AuditEventDTO `json:"events"`
+}
+
+// Handle GET /admin/plate-lookup-audit?actor_id=&company_id=&limit=
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var filter platelookup.AuditFilter
+	query := r.URL.Query()
+
+	if v := query.Get("actor_id"); v != "" {
+		actorID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			h.log.Warn("GET /admin/plate-lookup-audit - Invalid actor_id: %s", v)
+			api.RespondBadRequest(w, "Invalid actor_id")
+			return
+		}
+		filter.ActorID = actorID
+	}
+	if v := query.Get("company_id"); v != "" {
+		companyID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			h.log.Warn("GET /admin/plate-lookup-audit - Invalid company_id: %s", v)
+			api.RespondBadRequest(w, "Invalid company_id")
+			return
+		}
+		filter.CompanyID = companyID
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			h.log.Warn("GET /admin/plate-lookup-audit - Invalid limit: %s", v)
+			api.RespondBadRequest(w, "Invalid limit")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	events, err := h.service.ListEvents(r.Context(), filter)
+	if err != nil {
+		h.log.Error("GET /admin/plate-lookup-audit - Failed to list events: %v", err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("GET /admin/plate-lookup-audit - success, found %d events", len(events))
+	api.RespondJSON(w, http.StatusOK, Response{Events: events})
+}
@@ -0,0 +1,39 @@
+package list_roles
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac/models"
+)
+
+type Handler struct {
+	service *rbac.Service
+	log     Logger
+}
+
+func NewHandler(service *rbac.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Response структура для ответа со списком ролей
+type Response struct {
+	Roles []models.RoleDTO `json:"roles"`
+}
+
+// Handle GET /admin/roles
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.service.ListRoles(r.Context())
+	if err != nil {
+		h.log.Error("GET /admin/roles - Failed to list roles: %v", err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("GET /admin/roles - success, found %d roles", len(roles))
+	api.RespondJSON(w, http.StatusOK, Response{Roles: roles})
+}
@@ -0,0 +1,39 @@
+package list_service_credentials
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/service/serviceauth"
+	"github.com/m04kA/SMC-UserService/internal/service/serviceauth/models"
+)
+
+type Handler struct {
+	service *serviceauth.Service
+	log     Logger
+}
+
+func NewHandler(service *serviceauth.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Response структура для ответа со списком ключей сервисов
+type Response struct {
+	Credentials []models.CredentialDTO `json:"credentials"`
+}
+
+// Handle GET /admin/service-credentials
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	credentials, err := h.service.ListCredentials(r.Context())
+	if err != nil {
+		h.log.Error("GET /admin/service-credentials - Failed to list credentials: %v", err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("GET /admin/service-credentials - success, found %d credentials", len(credentials))
+	api.RespondJSON(w, http.StatusOK, Response{Credentials: credentials})
+}
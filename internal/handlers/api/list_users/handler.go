@@ -0,0 +1,105 @@
+package list_users
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /admin/users и GET /internal/users
+// Параметры: role, created_from, created_to (RFC3339), has_car, q, sort, cursor, limit
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	route := r.Method + " " + r.URL.Path
+	caller := callerFromContext(r)
+
+	input, err := parseQuery(r.URL.Query())
+	if err != nil {
+		h.log.Warn("%s - Invalid query: %v, %s", route, err, caller)
+		api.RespondBadRequest(w, err.Error())
+		return
+	}
+
+	page, err := h.service.ListUsers(r.Context(), input)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("%s - Request rejected: %v, %s", route, err, caller)
+		} else {
+			h.log.Error("%s - Failed to list users: %v, %s", route, err, caller)
+		}
+		return
+	}
+
+	h.log.Info("%s - success, found %d users, %s", route, len(page.Users), caller)
+	api.RespondJSON(w, http.StatusOK, page)
+}
+
+func parseQuery(query url.Values) (models.ListUsersInputDTO, error) {
+	input := models.ListUsersInputDTO{
+		Search: query.Get("q"),
+		Sort:   query.Get("sort"),
+		Cursor: query.Get("cursor"),
+	}
+
+	if v := query.Get("role"); v != "" {
+		role := domain.Role(v)
+		input.Role = &role
+	}
+	if v := query.Get("created_from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return input, fmt.Errorf("invalid created_from: expected RFC3339")
+		}
+		input.CreatedFrom = &t
+	}
+	if v := query.Get("created_to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return input, fmt.Errorf("invalid created_to: expected RFC3339")
+		}
+		input.CreatedTo = &t
+	}
+	if v := query.Get("has_car"); v != "" {
+		hasCar, err := strconv.ParseBool(v)
+		if err != nil {
+			return input, fmt.Errorf("invalid has_car: expected true or false")
+		}
+		input.HasCar = &hasCar
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return input, fmt.Errorf("invalid limit: expected positive integer")
+		}
+		input.Limit = limit
+	}
+
+	return input, nil
+}
+
+// callerFromContext описывает вызывающего для логов: пользователя (admin) или сервис (internal)
+func callerFromContext(r *http.Request) string {
+	if userID, err := middleware.GetUserIDFromContext(r.Context()); err == nil {
+		return fmt.Sprintf("user_id=%d", userID)
+	}
+	return "service=" + middleware.GetServiceFromContext(r.Context())
+}
@@ -0,0 +1,62 @@
+package lookup_company_cars
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/platelookup"
+)
+
+type Handler struct {
+	service *platelookup.Service
+	log     Logger
+}
+
+func NewHandler(service *platelookup.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /companies/{company_id}/cars?plate=
+// Номер в логи не пишется: поиск записывается в журнал plate_lookup_audit.
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("GET /companies/{company_id}/cars - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	role, err := middleware.GetRoleFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("GET /companies/{company_id}/cars - Cannot extract role: user_id=%d", userID)
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	companyIDStr := mux.Vars(r)["company_id"]
+	companyID, err := strconv.ParseInt(companyIDStr, 10, 64)
+	if err != nil {
+		h.log.Warn("GET /companies/{company_id}/cars - Invalid company_id: user_id=%d, company_id=%s", userID, companyIDStr)
+		api.RespondBadRequest(w, "Invalid company_id")
+		return
+	}
+
+	cars, err := h.service.LookupForCompany(r.Context(), userID, role, companyID, r.URL.Query().Get("plate"))
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("GET /companies/{company_id}/cars - Request rejected: user_id=%d, company_id=%d, error=%v", userID, companyID, err)
+		} else {
+			h.log.Error("GET /companies/{company_id}/cars - Failed to look up cars: user_id=%d, company_id=%d, error=%v", userID, companyID, err)
+		}
+		return
+	}
+
+	h.log.Info("GET /companies/{company_id}/cars - success: user_id=%d, company_id=%d, cars=%d", userID, companyID, len(cars.Cars))
+	api.RespondJSON(w, http.StatusOK, cars)
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/i18n"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+// ProblemContentType тип ответа с ошибкой (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// HeaderRequestID заголовок с идентификатором запроса, выставляется middleware.RequestID
+const HeaderRequestID = "X-Request-ID"
+
+// HeaderContentLanguage язык ответа, выставляется middleware.Language; по нему переводятся сообщения
+const HeaderContentLanguage = "Content-Language"
+
+// problemTypePrefix префикс type: тип проблемы однозначно определяется кодом
+const problemTypePrefix = "urn:smc-user-service:error:"
+
+// ErrorCode стабильный машиночитаемый код ошибки, клиенты ветвятся по нему, а не по тексту
+type ErrorCode string
+
+// Общие коды, не привязанные к конкретной ошибке сервиса
+const (
+	CodeBadRequest        ErrorCode = "BAD_REQUEST"
+	CodeInvalidBody       ErrorCode = "INVALID_REQUEST_BODY"
+	CodeValidationFailed  ErrorCode = "VALIDATION_FAILED"
+	CodeUnauthorized      ErrorCode = "UNAUTHORIZED"
+	CodeForbidden         ErrorCode = "FORBIDDEN"
+	CodePermissionDenied  ErrorCode = "PERMISSION_DENIED"
+	CodeNotFound          ErrorCode = "NOT_FOUND"
+	CodeRouteNotFound     ErrorCode = "ROUTE_NOT_FOUND"
+	CodeMethodNotAllowed  ErrorCode = "METHOD_NOT_ALLOWED"
+	CodeConflict          ErrorCode = "CONFLICT"
+	CodeGone              ErrorCode = "GONE"
+	CodePayloadTooLarge   ErrorCode = "PAYLOAD_TOO_LARGE"
+	CodeRateLimited       ErrorCode = "RATE_LIMITED"
+	CodeInternal          ErrorCode = "INTERNAL_ERROR"
+	CodeUpstreamFailed    ErrorCode = "UPSTREAM_FAILED"
+	CodeAccountBanned     ErrorCode = "ACCOUNT_BANNED"
+	CodeAccountSuspended  ErrorCode = "ACCOUNT_SUSPENDED"
+	CodeServiceAuthFailed ErrorCode = "SERVICE_AUTH_FAILED"
+	CodeImpersonation     ErrorCode = "IMPERSONATION_FORBIDDEN"
+)
+
+// genericCodes общие коды; вместе с serviceErrors задают ключи каталога сообщений
+var genericCodes = []ErrorCode{
+	CodeBadRequest, CodeInvalidBody, CodeValidationFailed, CodeUnauthorized, CodeForbidden,
+	CodePermissionDenied, CodeNotFound, CodeRouteNotFound, CodeMethodNotAllowed, CodeConflict,
+	CodeGone, CodePayloadTooLarge, CodeRateLimited, CodeInternal, CodeUpstreamFailed,
+	CodeAccountBanned, CodeAccountSuspended, CodeServiceAuthFailed, CodeImpersonation,
+}
+
+// Problem тело ответа с ошибкой в формате application/problem+json.
+// Title - сообщение для пользователя на языке ответа, Detail - подробности для разработчика.
+// Code и RequestID - расширения RFC 7807, Errors заполняется только для VALIDATION_FAILED.
+type Problem struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Code      ErrorCode              `json:"code"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Errors    []validator.FieldError `json:"errors,omitempty"`
+}
+
+// RespondProblem отправляет ошибку в формате application/problem+json
+func RespondProblem(w http.ResponseWriter, status int, code ErrorCode, detail string) {
+	writeProblem(w, Problem{Status: status, Code: code, Detail: detail})
+}
+
+func writeProblem(w http.ResponseWriter, p Problem) {
+	lang := responseLanguage(w)
+	p.Type = problemTypePrefix + string(p.Code)
+	p.Title = i18n.Message(lang, string(p.Code))
+	p.RequestID = w.Header().Get(HeaderRequestID)
+	for i, fe := range p.Errors {
+		p.Errors[i].Message = fieldMessage(lang, fe)
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// responseLanguage язык, выбранный middleware.Language
+func responseLanguage(w http.ResponseWriter) string {
+	if lang, ok := i18n.Normalize(w.Header().Get(HeaderContentLanguage)); ok {
+		return lang
+	}
+	return i18n.DefaultLanguage
+}
+
+// fieldMessage переводит сообщение об ошибке поля
+func fieldMessage(lang string, fe validator.FieldError) string {
+	if fe.Rule == "" {
+		return fe.Message
+	}
+	if fe.Param != "" {
+		return i18n.Message(lang, "validation."+fe.Rule, fe.Param)
+	}
+	return i18n.Message(lang, "validation."+fe.Rule)
+}
+
+// CheckMessages проверяет, что у каждого кода ошибки и правила проверки есть перевод на все языки
+func CheckMessages() error {
+	keys := make([]string, 0, len(genericCodes)+len(serviceErrors)+len(validator.Rules))
+	for _, code := range genericCodes {
+		keys = append(keys, string(code))
+	}
+	for _, se := range serviceErrors {
+		keys = append(keys, string(se.code))
+	}
+	for _, rule := range validator.Rules {
+		keys = append(keys, "validation."+rule)
+	}
+	return i18n.Check(keys)
+}
+
+// codeForStatus общий код для ошибок без собственного кода
+func codeForStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeGone
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusUnprocessableEntity:
+		return CodeValidationFailed
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusBadGateway:
+		return CodeUpstreamFailed
+	}
+	return CodeInternal
+}
+
+// NotFoundHandler ответ роутера на неизвестный путь
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		RespondProblem(w, http.StatusNotFound, CodeRouteNotFound, "Route not found")
+	})
+}
+
+// MethodNotAllowedHandler ответ роутера на неподдерживаемый метод
+func MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		RespondProblem(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "Method "+r.Method+" is not allowed")
+	})
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/m04kA/SMC-UserService/internal/i18n"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+// TestCheckMessages проверяет, что у каждого кода ошибки и правила проверки есть перевод на все языки
+func TestCheckMessages(t *testing.T) {
+	if err := CheckMessages(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// ruleSample структура, ошибки проверки которой покрывают все правила валидатора
+type ruleSample struct {
+	Required  string   `json:"required" validate:"required"`
+	Phone     string   `json:"phone" validate:"e164"`
+	Format    string   `json:"format" validate:"oneof=private taxi"`
+	MinString string   `json:"min_string" validate:"min=3"`
+	MaxString string   `json:"max_string" validate:"max=1"`
+	MinItems  []string `json:"min_items" validate:"min=2"`
+	MaxItems  []string `json:"max_items" validate:"max=1"`
+	MinNumber int      `json:"min_number" validate:"min=10"`
+	MaxNumber int      `json:"max_number" validate:"max=1"`
+}
+
+// TestValidationRules проверяет, что validator.Rules перечисляет все правила, которые выдает валидатор,
+// и что сообщение каждого правила переведено на все языки с корректной подстановкой параметра
+func TestValidationRules(t *testing.T) {
+	err := validator.Struct(ruleSample{
+		Phone:     "8999",
+		Format:    "bus",
+		MinString: "ab",
+		MaxString: "ab",
+		MinItems:  []string{"a"},
+		MaxItems:  []string{"a", "b"},
+		MinNumber: 1,
+		MaxNumber: 2,
+	})
+
+	var fieldErrors validator.Errors
+	if !errors.As(err, &fieldErrors) {
+		t.Fatalf("expected validation errors, got %v", err)
+	}
+
+	rules := make(map[string]struct{}, len(validator.Rules))
+	for _, rule := range validator.Rules {
+		rules[rule] = struct{}{}
+	}
+
+	emitted := make(map[string]struct{}, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		emitted[fe.Rule] = struct{}{}
+		if _, ok := rules[fe.Rule]; !ok {
+			t.Errorf("rule %q of field %s is missing in validator.Rules", fe.Rule, fe.Field)
+		}
+
+		for _, lang := range i18n.Languages() {
+			msg := fieldMessage(lang, fe)
+			if msg == "validation."+fe.Rule || strings.Contains(msg, "%!") {
+				t.Errorf("%s: bad message for rule %q: %q", lang, fe.Rule, msg)
+			}
+			if fe.Param != "" && !strings.Contains(msg, fe.Param) {
+				t.Errorf("%s: message for rule %q does not contain parameter %q: %q", lang, fe.Rule, fe.Param, msg)
+			}
+		}
+	}
+
+	for _, rule := range validator.Rules {
+		if _, ok := emitted[rule]; !ok {
+			t.Errorf("rule %q from validator.Rules is not covered by the sample", rule)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package remove_company_member
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/company"
+)
+
+type Handler struct {
+	service *company.Service
+	log     Logger
+}
+
+func NewHandler(service *company.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle DELETE /admin/companies/{company_id}/members/{tg_user_id}
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	actorID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("DELETE /admin/companies/{company_id}/members/{tg_user_id} - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	companyID, err := strconv.ParseInt(vars["company_id"], 10, 64)
+	if err != nil {
+		h.log.Warn("DELETE /admin/companies/{company_id}/members/{tg_user_id} - Invalid company ID: actor_id=%d, company_id=%s", actorID, vars["company_id"])
+		api.RespondBadRequest(w, "Invalid company ID")
+		return
+	}
+	tgUserID, err := strconv.ParseInt(vars["tg_user_id"], 10, 64)
+	if err != nil {
+		h.log.Warn("DELETE /admin/companies/{company_id}/members/{tg_user_id} - Invalid user ID: actor_id=%d, tg_user_id=%s", actorID, vars["tg_user_id"])
+		api.RespondBadRequest(w, "Invalid user ID")
+		return
+	}
+
+	err = h.service.RemoveMember(r.Context(), companyID, tgUserID)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("DELETE /admin/companies/{company_id}/members/{tg_user_id} - Request rejected: actor_id=%d, company_id=%d, tg_user_id=%d, error=%v", actorID, companyID, tgUserID, err)
+		} else {
+			h.log.Error("DELETE /admin/companies/{company_id}/members/{tg_user_id} - Failed to remove member: actor_id=%d, company_id=%d, tg_user_id=%d, error=%v", actorID, companyID, tgUserID, err)
+		}
+		return
+	}
+
+	h.log.Info("DELETE /admin/companies/{company_id}/members/{tg_user_id} - Member removed: actor_id=%d, company_id=%d, tg_user_id=%d", actorID, companyID, tgUserID)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,51 @@
+package request_phone_verification
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/phone"
+)
+
+type Handler struct {
+	service *phone.Service
+	log     Logger
+}
+
+func NewHandler(service *phone.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /users/me/phone/verification
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /users/me/phone/verification - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	verification, err := h.service.StartVerification(r.Context(), userID)
+	if err != nil {
+		// Сбой отправки кода не связан с запросом клиента
+		if errors.Is(err, phone.ErrServiceSendCode) {
+			h.log.Error("POST /users/me/phone/verification - Failed to send code: user_id=%d, error=%v", userID, err)
+			api.RespondServiceError(w, err)
+			return
+		}
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("POST /users/me/phone/verification - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("POST /users/me/phone/verification - Failed to start verification: user_id=%d, error=%v", userID, err)
+		}
+		return
+	}
+
+	h.log.Info("POST /users/me/phone/verification - Code sent: user_id=%d", userID)
+	api.RespondJSON(w, http.StatusOK, verification)
+}
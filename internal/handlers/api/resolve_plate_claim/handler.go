@@ -0,0 +1,83 @@
+package resolve_plate_claim
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/plateclaim"
+	"github.com/m04kA/SMC-UserService/internal/service/plateclaim/models"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+type Handler struct {
+	service *plateclaim.Service
+	log     Logger
+}
+
+func NewHandler(service *plateclaim.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /admin/plate-claims/{claim_id}/resolve
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	actorID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /admin/plate-claims/{claim_id}/resolve - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	role, err := middleware.GetRoleFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /admin/plate-claims/{claim_id}/resolve - Failed to get role: actor_id=%d", actorID)
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	claimIDStr := mux.Vars(r)["claim_id"]
+	claimID, err := strconv.ParseInt(claimIDStr, 10, 64)
+	if err != nil {
+		h.log.Warn("POST /admin/plate-claims/{claim_id}/resolve - Invalid claim_id: actor_id=%d, claim_id=%s", actorID, claimIDStr)
+		api.RespondBadRequest(w, "Invalid claim_id")
+		return
+	}
+
+	var input models.ResolveClaimInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /admin/plate-claims/{claim_id}/resolve - Invalid request body: actor_id=%d, error=%v", actorID, err)
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("POST /admin/plate-claims/{claim_id}/resolve - Validation failed: actor_id=%d, error=%v", actorID, err)
+		api.RespondValidationError(w, err)
+		return
+	}
+
+	claim, err := h.service.ResolveClaim(r.Context(), actorID, role, claimID, input)
+	if err != nil {
+		// Решение сохранено, не доставлено только уведомление
+		if errors.Is(err, plateclaim.ErrServiceNotify) {
+			h.log.Warn("POST /admin/plate-claims/{claim_id}/resolve - Claim resolved, notification failed: actor_id=%d, claim_id=%d, error=%v", actorID, claimID, err)
+			api.RespondJSON(w, http.StatusOK, claim)
+			return
+		}
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("POST /admin/plate-claims/{claim_id}/resolve - Request rejected: actor_id=%d, claim_id=%d, error=%v", actorID, claimID, err)
+		} else {
+			h.log.Error("POST /admin/plate-claims/{claim_id}/resolve - Failed to resolve claim: actor_id=%d, claim_id=%d, error=%v", actorID, claimID, err)
+		}
+		return
+	}
+
+	h.log.Info("POST /admin/plate-claims/{claim_id}/resolve - Claim resolved: actor_id=%d, claim_id=%d, status=%s", actorID, claimID, claim.Status)
+	api.RespondJSON(w, http.StatusOK, claim)
+}
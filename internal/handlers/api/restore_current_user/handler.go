@@ -0,0 +1,44 @@
+package restore_current_user
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /users/me/restore
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /users/me/restore - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	user, err := h.service.RestoreUser(r.Context(), userID)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("POST /users/me/restore - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("POST /users/me/restore - Failed to restore user: user_id=%d, error=%v", userID, err)
+		}
+		return
+	}
+
+	h.log.Info("POST /users/me/restore - User restored successfully: user_id=%d", userID)
+	api.RespondJSON(w, http.StatusOK, user)
+}
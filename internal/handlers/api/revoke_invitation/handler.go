@@ -0,0 +1,61 @@
+package revoke_invitation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/invitation"
+)
+
+type Handler struct {
+	service *invitation.Service
+	log     Logger
+}
+
+func NewHandler(service *invitation.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle DELETE /invitations/{id}
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("DELETE /invitations/{id} - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	role, err := middleware.GetRoleFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("DELETE /invitations/{id} - Role not found in context: user_id=%d", userID)
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.log.Warn("DELETE /invitations/{id} - Invalid invitation ID: user_id=%d, id=%s", userID, idStr)
+		api.RespondBadRequest(w, "Invalid invitation ID")
+		return
+	}
+
+	err = h.service.RevokeInvitation(r.Context(), userID, role, id)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("DELETE /invitations/{id} - Request rejected: user_id=%d, id=%d, error=%v", userID, id, err)
+		} else {
+			h.log.Error("DELETE /invitations/{id} - Failed to revoke invitation: user_id=%d, id=%d, error=%v", userID, id, err)
+		}
+		return
+	}
+
+	h.log.Info("DELETE /invitations/{id} - Invitation revoked: user_id=%d, id=%d", userID, id)
+	w.WriteHeader(http.StatusNoContent)
+}
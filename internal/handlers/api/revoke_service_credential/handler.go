@@ -0,0 +1,54 @@
+package revoke_service_credential
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/serviceauth"
+)
+
+type Handler struct {
+	service *serviceauth.Service
+	log     Logger
+}
+
+func NewHandler(service *serviceauth.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle DELETE /admin/service-credentials/{id}
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("DELETE /admin/service-credentials/{id} - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.log.Warn("DELETE /admin/service-credentials/{id} - Invalid credential ID: user_id=%d, id=%s", userID, idStr)
+		api.RespondBadRequest(w, "Invalid credential ID")
+		return
+	}
+
+	err = h.service.RevokeCredential(r.Context(), id)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("DELETE /admin/service-credentials/{id} - Request rejected: user_id=%d, id=%d, error=%v", userID, id, err)
+		} else {
+			h.log.Error("DELETE /admin/service-credentials/{id} - Failed to revoke credential: user_id=%d, id=%d, error=%v", userID, id, err)
+		}
+		return
+	}
+
+	h.log.Info("DELETE /admin/service-credentials/{id} - Credential revoked: user_id=%d, id=%d", userID, id)
+	w.WriteHeader(http.StatusNoContent)
+}
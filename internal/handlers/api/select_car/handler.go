@@ -1,7 +1,6 @@
 package select_car
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -44,28 +43,17 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 	carID, err := strconv.ParseInt(carIDStr, 10, 64)
 	if err != nil {
 		h.log.Warn("PUT /users/me/cars/{car_id}/select - Invalid car_id: user_id=%d, car_id=%s", userID, carIDStr)
-		api.RespondJSON(w, http.StatusBadRequest, map[string]string{
-			"error": "Invalid car_id",
-		})
+		api.RespondBadRequest(w, "Invalid car_id")
 		return
 	}
 
 	car, err := h.service.SetSelectedCar(r.Context(), userID, carID, role)
 	if err != nil {
-		if errors.Is(err, userservice.ErrCarNotFound) {
-			h.log.Warn("PUT /users/me/cars/{car_id}/select - Car not found: user_id=%d, car_id=%d", userID, carID)
-			api.RespondJSON(w, http.StatusNotFound, map[string]string{
-				"error": "Car not found",
-			})
-			return
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("PUT /users/me/cars/{car_id}/select - Request rejected: user_id=%d, car_id=%d, error=%v", userID, carID, err)
+		} else {
+			h.log.Error("PUT /users/me/cars/{car_id}/select - Failed to select car: user_id=%d, car_id=%d, error=%v", userID, carID, err)
 		}
-		if errors.Is(err, userservice.ErrCarAccessDenied) {
-			h.log.Warn("PUT /users/me/cars/{car_id}/select - Access denied: user_id=%d, car_id=%d", userID, carID)
-			api.RespondCarAccessDenied(w)
-			return
-		}
-		h.log.Error("PUT /users/me/cars/{car_id}/select - Failed to select car: user_id=%d, car_id=%d, error=%v", userID, carID, err)
-		api.RespondInternalError(w)
 		return
 	}
 
@@ -0,0 +1,75 @@
+package set_company_member
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/company"
+	"github.com/m04kA/SMC-UserService/internal/service/company/models"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+type Handler struct {
+	service *company.Service
+	log     Logger
+}
+
+func NewHandler(service *company.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle PUT /admin/companies/{company_id}/members/{tg_user_id}
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	actorID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("PUT /admin/companies/{company_id}/members/{tg_user_id} - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	companyID, err := strconv.ParseInt(vars["company_id"], 10, 64)
+	if err != nil {
+		h.log.Warn("PUT /admin/companies/{company_id}/members/{tg_user_id} - Invalid company ID: actor_id=%d, company_id=%s", actorID, vars["company_id"])
+		api.RespondBadRequest(w, "Invalid company ID")
+		return
+	}
+	tgUserID, err := strconv.ParseInt(vars["tg_user_id"], 10, 64)
+	if err != nil {
+		h.log.Warn("PUT /admin/companies/{company_id}/members/{tg_user_id} - Invalid user ID: actor_id=%d, tg_user_id=%s", actorID, vars["tg_user_id"])
+		api.RespondBadRequest(w, "Invalid user ID")
+		return
+	}
+
+	var input models.SetMemberInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("PUT /admin/companies/{company_id}/members/{tg_user_id} - Invalid request body: actor_id=%d, error=%v", actorID, err)
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("PUT /admin/companies/{company_id}/members/{tg_user_id} - Validation failed: actor_id=%d, error=%v", actorID, err)
+		api.RespondValidationError(w, err)
+		return
+	}
+
+	member, err := h.service.SetMember(r.Context(), companyID, tgUserID, input)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("PUT /admin/companies/{company_id}/members/{tg_user_id} - Request rejected: actor_id=%d, company_id=%d, tg_user_id=%d, error=%v", actorID, companyID, tgUserID, err)
+		} else {
+			h.log.Error("PUT /admin/companies/{company_id}/members/{tg_user_id} - Failed to set member: actor_id=%d, company_id=%d, tg_user_id=%d, error=%v", actorID, companyID, tgUserID, err)
+		}
+		return
+	}
+
+	h.log.Info("PUT /admin/companies/{company_id}/members/{tg_user_id} - Member set: actor_id=%d, company_id=%d, tg_user_id=%d, role=%s", actorID, companyID, tgUserID, member.Role)
+	api.RespondJSON(w, http.StatusOK, member)
+}
@@ -0,0 +1,63 @@
+package set_role_permissions
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac/models"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+type Handler struct {
+	service *rbac.Service
+	log     Logger
+}
+
+func NewHandler(service *rbac.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle PUT /admin/roles/{name}/permissions
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("PUT /admin/roles/{name}/permissions - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	name := domain.Role(mux.Vars(r)["name"])
+
+	var input models.SetRolePermissionsInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("PUT /admin/roles/{name}/permissions - Invalid request body: user_id=%d, error=%v", userID, err)
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("PUT /admin/roles/{name}/permissions - Validation failed: user_id=%d, error=%v", userID, err)
+		api.RespondValidationError(w, err)
+		return
+	}
+
+	role, err := h.service.SetRolePermissions(r.Context(), name, input)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("PUT /admin/roles/{name}/permissions - Request rejected: user_id=%d, name=%s, error=%v", userID, name, err)
+		} else {
+			h.log.Error("PUT /admin/roles/{name}/permissions - Failed to set permissions: user_id=%d, name=%s, error=%v", userID, name, err)
+		}
+		return
+	}
+
+	h.log.Info("PUT /admin/roles/{name}/permissions - Permissions updated: user_id=%d, name=%s, permissions=%v", userID, role.Name, role.Permissions)
+	api.RespondJSON(w, http.StatusOK, role)
+}
@@ -1,7 +1,6 @@
 package update_car
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -10,6 +9,8 @@ import (
 	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
 	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
 	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+	"github.com/m04kA/SMC-UserService/pkg/mergepatch"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
 )
 
 type Handler struct {
@@ -58,24 +59,28 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 	var input models.UpdateCarInputDTO
 	if err = api.DecodeJSON(r, &input); err != nil {
 		h.log.Warn("PATCH /users/me/cars/{car_id} - Invalid request body: user_id=%d, car_id=%d, error=%v", userID, carID, err)
-		api.RespondBadRequest(w, "Invalid request body")
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	// Без application/merge-patch+json null, как и раньше, означает "не менять"
+	if !mergepatch.IsMergePatch(r.Header.Get("Content-Type")) {
+		input.IgnoreNulls()
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("PATCH /users/me/cars/{car_id} - Validation failed: user_id=%d, car_id=%d, error=%v", userID, carID, err)
+		api.RespondValidationError(w, err)
 		return
 	}
 
 	car, err := h.service.UpdateCar(r.Context(), userID, carID, input, role)
 	if err != nil {
-		if errors.Is(err, userservice.ErrCarNotFound) {
-			h.log.Warn("PATCH /users/me/cars/{car_id} - Car not found: user_id=%d, car_id=%d", userID, carID)
-			api.RespondCarNotFound(w)
-			return
-		}
-		if errors.Is(err, userservice.ErrCarAccessDenied) {
-			h.log.Warn("PATCH /users/me/cars/{car_id} - Access denied: user_id=%d, car_id=%d, role=%s", userID, carID, role)
-			api.RespondCarAccessDenied(w)
-			return
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("PATCH /users/me/cars/{car_id} - Request rejected: user_id=%d, car_id=%d, error=%v", userID, carID, err)
+		} else {
+			h.log.Error("PATCH /users/me/cars/{car_id} - Failed to update car: user_id=%d, car_id=%d, error=%v", userID, carID, err)
 		}
-		h.log.Error("PATCH /users/me/cars/{car_id} - Failed to update car: user_id=%d, car_id=%d, error=%v", userID, carID, err)
-		api.RespondInternalError(w)
 		return
 	}
 
@@ -1,13 +1,14 @@
 package update_current_user
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/m04kA/SMC-UserService/internal/handlers/api"
 	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
 	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
 	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+	"github.com/m04kA/SMC-UserService/pkg/mergepatch"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
 )
 
 type Handler struct {
@@ -34,19 +35,28 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 	var input models.UpdateUserInputDTO
 	if err := api.DecodeJSON(r, &input); err != nil {
 		h.log.Warn("PUT /users/me - Invalid request body: user_id=%d, error=%v", userID, err)
-		api.RespondBadRequest(w, "Invalid request body")
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	// Без application/merge-patch+json null, как и раньше, означает "не менять"
+	if !mergepatch.IsMergePatch(r.Header.Get("Content-Type")) {
+		input.IgnoreNulls()
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("PUT /users/me - Validation failed: user_id=%d, error=%v", userID, err)
+		api.RespondValidationError(w, err)
 		return
 	}
 
 	user, err := h.service.UpdateUser(r.Context(), userID, input)
 	if err != nil {
-		if errors.Is(err, userservice.ErrUserNotFound) {
-			h.log.Warn("PUT /users/me - User not found: user_id=%d", userID)
-			api.RespondUserNotFound(w)
-			return
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("PUT /users/me - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("PUT /users/me - Failed to update user: user_id=%d, error=%v", userID, err)
 		}
-		h.log.Error("PUT /users/me - Failed to update user: user_id=%d, error=%v", userID, err)
-		api.RespondInternalError(w)
 		return
 	}
 
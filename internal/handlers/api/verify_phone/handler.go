@@ -0,0 +1,59 @@
+package verify_phone
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/service/phone"
+	"github.com/m04kA/SMC-UserService/internal/service/phone/models"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+type Handler struct {
+	service *phone.Service
+	log     Logger
+}
+
+func NewHandler(service *phone.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /users/me/phone/verify
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /users/me/phone/verify - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	var input models.VerifyPhoneInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /users/me/phone/verify - Invalid request body: %v", err)
+		api.RespondInvalidBody(w)
+		return
+	}
+
+	if err := validator.Struct(input); err != nil {
+		h.log.Warn("POST /users/me/phone/verify - Validation failed: %v", err)
+		api.RespondValidationError(w, err)
+		return
+	}
+
+	status, err := h.service.VerifyCode(r.Context(), userID, input)
+	if err != nil {
+		if api.RespondServiceError(w, err) {
+			h.log.Warn("POST /users/me/phone/verify - Request rejected: user_id=%d, error=%v", userID, err)
+		} else {
+			h.log.Error("POST /users/me/phone/verify - Failed to verify code: user_id=%d, error=%v", userID, err)
+		}
+		return
+	}
+
+	h.log.Info("POST /users/me/phone/verify - Phone verified: user_id=%d", userID)
+	api.RespondJSON(w, http.StatusOK, status)
+}
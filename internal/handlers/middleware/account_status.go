@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+// StatusProvider возвращает статус аккаунта пользователя
+type StatusProvider interface {
+	// GetUserStatus возвращает active для незарегистрированного пользователя
+	GetUserStatus(ctx context.Context, tgID int64) (*models.UserStatusDTO, error)
+}
+
+// RequireActiveUser middleware отклоняет запросы заблокированных и приостановленных пользователей.
+// Должен стоять после Authenticate.
+func RequireActiveUser(statuses StatusProvider) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, err := GetUserIDFromContext(r.Context())
+			if err != nil {
+				api.RespondProblem(w, http.StatusUnauthorized, api.CodeUnauthorized, "unauthorized")
+				return
+			}
+
+			status, err := statuses.GetUserStatus(r.Context(), userID)
+			if err != nil {
+				api.RespondProblem(w, http.StatusInternalServerError, api.CodeInternal, "failed to check account status")
+				return
+			}
+
+			switch status.Status {
+			case domain.UserStatusBanned:
+				api.RespondProblem(w, http.StatusForbidden, api.CodeAccountBanned, "account is banned")
+				return
+			case domain.UserStatusSuspended:
+				message := "account is suspended"
+				if status.SuspendedUntil != nil {
+					message += " until " + status.SuspendedUntil.UTC().Format(time.RFC3339)
+				}
+				api.RespondProblem(w, http.StatusForbidden, api.CodeAccountSuspended, message)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -7,62 +7,111 @@ import (
 	"strconv"
 
 	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/pkg/telegram"
 )
 
 type contextKey string
 
 const (
-	UserIDKey contextKey = "userID"
-	RoleKey   contextKey = "role"
+	UserIDKey       contextKey = "userID"
+	RoleKey         contextKey = "role"
+	TelegramUserKey contextKey = "telegramUser"
 )
 
 var (
-	ErrMissingUserID = errors.New("missing X-User-ID header")
+	ErrNoCredentials = errors.New("missing credentials")
 	ErrInvalidUserID = errors.New("invalid user ID format")
-	ErrMissingRole   = errors.New("missing X-User-Role header")
 	ErrInvalidRole   = errors.New("invalid role")
 )
 
-// UserIDAuth извлекает user ID и role из заголовков X-User-ID и X-User-Role
-func UserIDAuth(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		userIDStr := r.Header.Get("X-User-ID")
-		if userIDStr == "" {
-			http.Error(w, ErrMissingUserID.Error(), http.StatusUnauthorized)
-			return
-		}
+// Identity аутентифицированный пользователь запроса
+type Identity struct {
+	UserID int64
+	// Role может быть пустой, если способ аутентификации не передает роль
+	Role domain.Role
+	// Telegram профиль пользователя, если запрос аутентифицирован через initData Mini App
+	Telegram *telegram.User
+}
 
-		userID, err := strconv.ParseInt(userIDStr, 10, 64)
-		if err != nil {
-			http.Error(w, ErrInvalidUserID.Error(), http.StatusBadRequest)
-			return
-		}
-		ctx := r.Context()
-		roleStr := r.Header.Get("X-User-Role")
-		if roleStr != "" {
-			role := domain.Role(roleStr)
-			if !role.IsValid() {
-				http.Error(w, ErrInvalidRole.Error(), http.StatusBadRequest)
+// Authenticator проверяет учетные данные запроса одного типа
+type Authenticator interface {
+	// Authenticate возвращает ErrNoCredentials, если запрос не содержит учетных данных этого типа
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+// Authenticate middleware последовательно пробует аутентификаторы.
+// Решение принимает первый аутентификатор, нашедший в запросе свои учетные данные.
+func Authenticate(authenticators ...Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, a := range authenticators {
+				identity, err := a.Authenticate(r)
+				if errors.Is(err, ErrNoCredentials) {
+					continue
+				}
+				if err != nil {
+					switch {
+					case errors.Is(err, ErrResolveRole):
+						api.RespondProblem(w, http.StatusInternalServerError, api.CodeInternal, ErrResolveRole.Error())
+					case errors.Is(err, ErrInvalidUserID), errors.Is(err, ErrInvalidRole):
+						api.RespondProblem(w, http.StatusBadRequest, api.CodeBadRequest, err.Error())
+					default:
+						api.RespondProblem(w, http.StatusUnauthorized, api.CodeUnauthorized, err.Error())
+					}
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(withIdentity(r.Context(), identity)))
 				return
 			}
-			ctx = context.WithValue(ctx, RoleKey, role)
+
+			api.RespondProblem(w, http.StatusUnauthorized, api.CodeUnauthorized, ErrNoCredentials.Error())
+		})
+	}
+}
+
+// withIdentity кладет пользователя в контекст
+func withIdentity(ctx context.Context, identity *Identity) context.Context {
+	ctx = context.WithValue(ctx, UserIDKey, identity.UserID)
+	if identity.Role != "" {
+		ctx = context.WithValue(ctx, RoleKey, identity.Role)
+	}
+	if identity.Telegram != nil {
+		ctx = context.WithValue(ctx, TelegramUserKey, identity.Telegram)
+	}
+	return ctx
+}
+
+// HeaderAuthenticator извлекает user ID и role из заголовков X-User-ID и X-User-Role.
+// Используется только в режиме trusted_gateway, когда заголовки выставляет доверенный API Gateway.
+type HeaderAuthenticator struct{}
+
+func NewHeaderAuthenticator() *HeaderAuthenticator {
+	return &HeaderAuthenticator{}
+}
+
+func (a *HeaderAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	userIDStr := r.Header.Get("X-User-ID")
+	if userIDStr == "" {
+		return nil, ErrNoCredentials
+	}
+
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidUserID
+	}
+
+	identity := &Identity{UserID: userID}
+	if roleStr := r.Header.Get("X-User-Role"); roleStr != "" {
+		role := domain.Role(roleStr)
+		if !role.IsValid() {
+			return nil, ErrInvalidRole
 		}
+		identity.Role = role
+	}
 
-		// if roleStr == "" {
-		// 	http.Error(w, ErrMissingRole.Error(), http.StatusUnauthorized)
-		// 	return
-		// }
-
-		// role := domain.Role(roleStr)
-		// if !role.IsValid() {
-		// 	http.Error(w, ErrInvalidRole.Error(), http.StatusBadRequest)
-		// 	return
-		// }
-
-		ctx = context.WithValue(ctx, UserIDKey, userID)
-		// ctx = context.WithValue(ctx, RoleKey, role)
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+	return identity, nil
 }
 
 // GetUserIDFromContext извлекает user ID из контекста
@@ -74,6 +123,12 @@ func GetUserIDFromContext(ctx context.Context) (int64, error) {
 	return userID, nil
 }
 
+// GetTelegramUserFromContext извлекает профиль Telegram из контекста
+func GetTelegramUserFromContext(ctx context.Context) (*telegram.User, bool) {
+	user, ok := ctx.Value(TelegramUserKey).(*telegram.User)
+	return user, ok && user != nil
+}
+
 // GetRoleFromContext извлекает role из контекста
 func GetRoleFromContext(ctx context.Context) (domain.Role, error) {
 	role, ok := ctx.Value(RoleKey).(domain.Role)
@@ -82,21 +137,3 @@ func GetRoleFromContext(ctx context.Context) (domain.Role, error) {
 	}
 	return role, nil
 }
-
-// RequireSuperUser middleware проверяет, что пользователь имеет роль superuser
-func RequireSuperUser(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		role, err := GetRoleFromContext(r.Context())
-		if err != nil {
-			http.Error(w, "unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if role != domain.RoleSuperUser {
-			http.Error(w, "forbidden: superuser role required", http.StatusForbidden)
-			return
-		}
-
-		next.ServeHTTP(w, r)
-	})
-}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+	"github.com/m04kA/SMC-UserService/pkg/telegram"
+)
+
+const (
+	ActorIDKey   contextKey = "actorID"
+	ActorRoleKey contextKey = "actorRole"
+
+	// HeaderActAs заголовок с tg_user_id пользователя, от имени которого выполняется запрос
+	HeaderActAs = "X-Act-As"
+)
+
+// Actor реальный пользователь, выполняющий запрос от имени другого
+type Actor struct {
+	UserID int64
+	Role   domain.Role
+}
+
+// ImpersonationTargets возвращает пользователя, от имени которого выполняется запрос
+type ImpersonationTargets interface {
+	GetUserByID(ctx context.Context, tgID int64) (*models.UserDTO, error)
+}
+
+// ImpersonationAuditor записывает запросы, выполненные от имени другого пользователя
+type ImpersonationAuditor interface {
+	StartImpersonation(ctx context.Context, actorID, targetID int64, method, path string) (int64, error)
+	FinishImpersonation(ctx context.Context, eventID int64, statusCode int) error
+}
+
+// Impersonate middleware позволяет пользователю с правом users:impersonate выполнить запрос
+// от имени другого пользователя (заголовок X-Act-As). Должен стоять после Authenticate.
+//
+// В контексте UserIDKey и RoleKey заменяются на целевого пользователя, реальный пользователь
+// доступен через GetActorFromContext. Нельзя действовать от имени пользователя, у роли которого
+// есть права, отсутствующие у реального пользователя, а также от имени заблокированного, приостановленного
+// или удаленного пользователя. При readOnly разрешены только безопасные методы.
+// Каждый запрос записывается в журнал до выполнения; если журнал недоступен, запрос отклоняется.
+func Impersonate(policy AuthorizerProvider, targets ImpersonationTargets, audit ImpersonationAuditor, readOnly bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actAs := r.Header.Get(HeaderActAs)
+			if actAs == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			targetID, err := strconv.ParseInt(actAs, 10, 64)
+			if err != nil {
+				api.RespondProblem(w, http.StatusBadRequest, api.CodeBadRequest, "invalid "+HeaderActAs+" header")
+				return
+			}
+
+			actorID, err := GetUserIDFromContext(r.Context())
+			if err != nil {
+				api.RespondProblem(w, http.StatusUnauthorized, api.CodeUnauthorized, "unauthorized")
+				return
+			}
+			actorRole, _ := GetRoleFromContext(r.Context())
+
+			if targetID == actorID {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			authz, err := policy.Authorizer(r.Context())
+			if err != nil {
+				api.RespondProblem(w, http.StatusInternalServerError, api.CodeInternal, "failed to load permissions")
+				return
+			}
+			if !authz.Has(actorRole, domain.PermUsersImpersonate) {
+				api.RespondProblem(w, http.StatusForbidden, api.CodePermissionDenied, "permission "+string(domain.PermUsersImpersonate)+" required")
+				return
+			}
+
+			target, err := targets.GetUserByID(r.Context(), targetID)
+			if err != nil {
+				if errors.Is(err, userservice.ErrUserNotFound) {
+					api.RespondProblem(w, http.StatusNotFound, api.CodeUserNotFound, "impersonated user not found")
+					return
+				}
+				api.RespondProblem(w, http.StatusInternalServerError, api.CodeInternal, "failed to load impersonated user")
+				return
+			}
+
+			// RequireActiveUser проверяет реального пользователя, статус целевого проверяется здесь.
+			// Удаленный аккаунт GetUserByID не возвращает (ErrUserNotFound выше).
+			if target.Status != domain.UserStatusActive {
+				api.RespondProblem(w, http.StatusForbidden, api.CodeImpersonation, "cannot impersonate "+string(target.Status)+" user")
+				return
+			}
+
+			for _, p := range authz.Permissions(target.Role) {
+				if !authz.Has(actorRole, p) {
+					api.RespondProblem(w, http.StatusForbidden, api.CodeImpersonation, "cannot impersonate user with broader permissions")
+					return
+				}
+			}
+
+			eventID, err := audit.StartImpersonation(r.Context(), actorID, targetID, r.Method, r.URL.RequestURI())
+			if err != nil {
+				api.RespondProblem(w, http.StatusInternalServerError, api.CodeInternal, "failed to record impersonation audit")
+				return
+			}
+
+			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			if readOnly && !isSafeMethod(r.Method) {
+				api.RespondProblem(rw, http.StatusForbidden, api.CodeImpersonation, "write operations are not allowed while impersonating")
+			} else {
+				ctx := context.WithValue(r.Context(), ActorIDKey, actorID)
+				ctx = context.WithValue(ctx, ActorRoleKey, actorRole)
+				ctx = context.WithValue(ctx, UserIDKey, target.TGUserID)
+				ctx = context.WithValue(ctx, RoleKey, target.Role)
+				// Профиль Telegram принадлежит реальному пользователю
+				ctx = context.WithValue(ctx, TelegramUserKey, (*telegram.User)(nil))
+				next.ServeHTTP(rw, r.WithContext(ctx))
+			}
+
+			_ = audit.FinishImpersonation(context.WithoutCancel(r.Context()), eventID, rw.statusCode)
+		})
+	}
+}
+
+// GetActorFromContext возвращает реального пользователя, если запрос выполняется от имени другого
+func GetActorFromContext(ctx context.Context) (Actor, bool) {
+	actorID, ok := ctx.Value(ActorIDKey).(int64)
+	if !ok {
+		return Actor{}, false
+	}
+	role, _ := ctx.Value(ActorRoleKey).(domain.Role)
+	return Actor{UserID: actorID, Role: role}, true
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
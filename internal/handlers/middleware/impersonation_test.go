@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+type stubPolicy struct {
+	authz *domain.Authorizer
+}
+
+func (p stubPolicy) Authorizer(context.Context) (*domain.Authorizer, error) {
+	return p.authz, nil
+}
+
+// stubTargets пользователи по tg_user_id; отсутствующий (в том числе удаленный) - ErrUserNotFound
+type stubTargets map[int64]*models.UserDTO
+
+func (s stubTargets) GetUserByID(_ context.Context, tgID int64) (*models.UserDTO, error) {
+	if user, ok := s[tgID]; ok {
+		return user, nil
+	}
+	return nil, userservice.ErrUserNotFound
+}
+
+type stubAuditor struct {
+	started int
+}
+
+func (a *stubAuditor) StartImpersonation(context.Context, int64, int64, string, string) (int64, error) {
+	a.started++
+	return int64(a.started), nil
+}
+
+func (a *stubAuditor) FinishImpersonation(context.Context, int64, int) error {
+	return nil
+}
+
+func TestImpersonateTargetStatus(t *testing.T) {
+	const actorID = 1
+
+	policy := stubPolicy{authz: domain.NewAuthorizer([]domain.RoleDefinition{
+		{Name: domain.RoleClient},
+		{Name: domain.RoleSuperUser, Permissions: []domain.Permission{domain.PermUsersImpersonate}},
+	})}
+	target := func(id int64, status domain.UserStatus) *models.UserDTO {
+		return &models.UserDTO{TGUserID: id, Role: domain.RoleClient, UserStatusDTO: models.UserStatusDTO{Status: status}}
+	}
+	targets := stubTargets{
+		10: target(10, domain.UserStatusActive),
+		11: target(11, domain.UserStatusSuspended),
+		12: target(12, domain.UserStatusBanned),
+	}
+
+	tests := []struct {
+		name       string
+		targetID   int64
+		wantStatus int
+		wantAudit  bool
+	}{
+		{name: "active target", targetID: 10, wantStatus: http.StatusOK, wantAudit: true},
+		{name: "suspended target", targetID: 11, wantStatus: http.StatusForbidden},
+		{name: "banned target", targetID: 12, wantStatus: http.StatusForbidden},
+		{name: "deleted target", targetID: 13, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &stubAuditor{}
+			var gotUserID int64
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUserID, _ = GetUserIDFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := Impersonate(policy, targets, audit, false)(next)
+
+			r := httptest.NewRequest(http.MethodGet, "/users/me", nil)
+			r.Header.Set(HeaderActAs, strconv.FormatInt(tt.targetID, 10))
+			ctx := context.WithValue(r.Context(), UserIDKey, int64(actorID))
+			ctx = context.WithValue(ctx, RoleKey, domain.RoleSuperUser)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r.WithContext(ctx))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if (audit.started > 0) != tt.wantAudit {
+				t.Errorf("audit records = %d, want recorded %v", audit.started, tt.wantAudit)
+			}
+			if tt.wantStatus == http.StatusOK && gotUserID != tt.targetID {
+				t.Errorf("handler saw user %d, want %d", gotUserID, tt.targetID)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/pkg/jwtkeys"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Claims полезная нагрузка JWT токена пользователя
+type Claims struct {
+	TGUserID int64       `json:"tg_user_id,omitempty"`
+	Role     domain.Role `json:"role,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// UserID возвращает Telegram ID из claim tg_user_id, при его отсутствии - из sub
+func (c *Claims) UserID() (int64, error) {
+	if c.TGUserID != 0 {
+		return c.TGUserID, nil
+	}
+	if c.Subject == "" {
+		return 0, ErrInvalidUserID
+	}
+	userID, err := strconv.ParseInt(c.Subject, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidUserID
+	}
+	return userID, nil
+}
+
+// TokenVerifier проверяет подпись и стандартные claims JWT
+type TokenVerifier struct {
+	keys   *jwtkeys.KeySet
+	parser *jwt.Parser
+}
+
+// NewTokenVerifier создает верификатор токенов.
+// Пустые issuer и audience не проверяются.
+func NewTokenVerifier(keys *jwtkeys.KeySet, issuer, audience string, leeway time.Duration) *TokenVerifier {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(keys.Methods()),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+
+	return &TokenVerifier{
+		keys:   keys,
+		parser: jwt.NewParser(opts...),
+	}
+}
+
+// Verify проверяет токен и возвращает его claims
+func (v *TokenVerifier) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	if _, err := v.parser.ParseWithClaims(tokenString, claims, v.keys.Keyfunc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+// JWTAuthenticator извлекает пользователя из подписанного токена в заголовке Authorization: Bearer <token>.
+// Если claim role отсутствует, пользователь считается клиентом.
+type JWTAuthenticator struct {
+	verifier *TokenVerifier
+}
+
+func NewJWTAuthenticator(verifier *TokenVerifier) *JWTAuthenticator {
+	return &JWTAuthenticator{verifier: verifier}
+}
+
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	tokenString, ok := authorizationCredentials(r, "Bearer")
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	claims, err := a.verifier.Verify(tokenString)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	role := claims.Role
+	if role == "" {
+		role = domain.RoleClient
+	}
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, ErrInvalidRole)
+	}
+
+	return &Identity{UserID: userID, Role: role}, nil
+}
+
+// authorizationCredentials извлекает учетные данные указанной схемы из заголовка Authorization
+func authorizationCredentials(r *http.Request, scheme string) (string, bool) {
+	header := r.Header.Get("Authorization")
+	gotScheme, credentials, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(gotScheme, scheme) {
+		return "", false
+	}
+	credentials = strings.TrimSpace(credentials)
+	return credentials, credentials != ""
+}
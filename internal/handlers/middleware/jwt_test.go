@@ -0,0 +1,230 @@
+package middleware
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/pkg/jwtkeys"
+)
+
+const (
+	testIssuer   = "smc-auth"
+	testAudience = "smc-userservice"
+)
+
+var testSecret = []byte("current-secret")
+
+func newTestVerifier(t *testing.T) (*TokenVerifier, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate Ed25519 key: %v", err)
+	}
+	keys, err := jwtkeys.NewKeySet(
+		jwtkeys.Key{ID: "hs", Method: jwt.SigningMethodHS256, Verify: testSecret},
+		jwtkeys.Key{ID: "ed", Method: jwt.SigningMethodEdDSA, Verify: pub},
+	)
+	if err != nil {
+		t.Fatalf("NewKeySet: %v", err)
+	}
+	return NewTokenVerifier(keys, testIssuer, testAudience, 30*time.Second), priv
+}
+
+// validClaims claims токена, который проходит все проверки верификатора
+func validClaims() *Claims {
+	now := time.Now()
+	return &Claims{
+		TGUserID: 42,
+		Role:     domain.RoleManager,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    testIssuer,
+			Audience:  jwt.ClaimStrings{testAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+}
+
+func signClaims(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims *Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+func TestTokenVerifierVerify(t *testing.T) {
+	verifier, edKey := newTestVerifier(t)
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr bool
+	}{
+		{
+			name:  "HS256 key selected by kid",
+			token: func() string { return signClaims(t, jwt.SigningMethodHS256, "hs", testSecret, validClaims()) },
+		},
+		{
+			name:  "EdDSA key selected by kid",
+			token: func() string { return signClaims(t, jwt.SigningMethodEdDSA, "ed", edKey, validClaims()) },
+		},
+		{
+			name: "expired within leeway",
+			token: func() string {
+				c := validClaims()
+				c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-10 * time.Second))
+				return signClaims(t, jwt.SigningMethodHS256, "hs", testSecret, c)
+			},
+		},
+		{
+			name: "expired",
+			token: func() string {
+				c := validClaims()
+				c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+				return signClaims(t, jwt.SigningMethodHS256, "hs", testSecret, c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "no exp",
+			token: func() string {
+				c := validClaims()
+				c.ExpiresAt = nil
+				return signClaims(t, jwt.SigningMethodHS256, "hs", testSecret, c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				c := validClaims()
+				c.Issuer = "other-issuer"
+				return signClaims(t, jwt.SigningMethodHS256, "hs", testSecret, c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				c := validClaims()
+				c.Audience = jwt.ClaimStrings{"other-service"}
+				return signClaims(t, jwt.SigningMethodHS256, "hs", testSecret, c)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "HS256 token against EdDSA kid",
+			token:   func() string { return signClaims(t, jwt.SigningMethodHS256, "ed", testSecret, validClaims()) },
+			wantErr: true,
+		},
+		{
+			name:    "unknown kid",
+			token:   func() string { return signClaims(t, jwt.SigningMethodHS256, "retired", testSecret, validClaims()) },
+			wantErr: true,
+		},
+		{
+			name: "wrong secret",
+			token: func() string {
+				return signClaims(t, jwt.SigningMethodHS256, "hs", []byte("other-secret"), validClaims())
+			},
+			wantErr: true,
+		},
+		{
+			name: "alg none",
+			token: func() string {
+				return signClaims(t, jwt.SigningMethodNone, "hs", jwt.UnsafeAllowNoneSignatureType, validClaims())
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(tt.token())
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("Verify() error = %v, want %v", err, ErrInvalidToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if claims.TGUserID != 42 || claims.Role != domain.RoleManager {
+				t.Errorf("Verify() claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorAuthenticate(t *testing.T) {
+	verifier, _ := newTestVerifier(t)
+	auth := NewJWTAuthenticator(verifier)
+
+	tests := []struct {
+		name     string
+		header   string
+		claims   func(c *Claims)
+		wantID   int64
+		wantRole domain.Role
+		wantErr  error
+	}{
+		{name: "claims", wantID: 42, wantRole: domain.RoleManager},
+		{
+			name:     "user ID from sub and default role",
+			claims:   func(c *Claims) { c.TGUserID, c.Subject, c.Role = 0, "77", "" },
+			wantID:   77,
+			wantRole: domain.RoleClient,
+		},
+		{name: "no user ID", claims: func(c *Claims) { c.TGUserID = 0 }, wantErr: ErrInvalidToken},
+		{name: "malformed role", claims: func(c *Claims) { c.Role = "Super User" }, wantErr: ErrInvalidToken},
+		{name: "no header", header: "-", wantErr: ErrNoCredentials},
+		{name: "other scheme", header: "Basic Zm9vOmJhcg==", wantErr: ErrNoCredentials},
+		{name: "garbage token", header: "Bearer not.a.token", wantErr: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := tt.header
+			if header == "" {
+				c := validClaims()
+				if tt.claims != nil {
+					tt.claims(c)
+				}
+				header = "Bearer " + signClaims(t, jwt.SigningMethodHS256, "hs", testSecret, c)
+			}
+
+			r := httptest.NewRequest("GET", "/users/me", nil)
+			if header != "-" {
+				r.Header.Set("Authorization", header)
+			}
+
+			identity, err := auth.Authenticate(r)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if identity.UserID != tt.wantID || identity.Role != tt.wantRole {
+				t.Errorf("Authenticate() = %+v, want user_id=%d role=%s", identity, tt.wantID, tt.wantRole)
+			}
+		})
+	}
+}
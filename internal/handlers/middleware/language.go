@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/i18n"
+)
+
+const (
+	LanguageKey         contextKey = "language"
+	languageExplicitKey contextKey = "languageExplicit"
+)
+
+// LanguageProvider возвращает язык, сохраненный в профиле пользователя
+type LanguageProvider interface {
+	// GetUserLanguage возвращает пустую строку, если язык не указан
+	GetUserLanguage(ctx context.Context, tgID int64) (string, error)
+}
+
+// Language middleware выбирает язык ответа по Accept-Language, иначе defaultLang.
+// Язык выставляется в заголовок Content-Language, по нему переводятся сообщения об ошибках.
+// Оборачивает весь роутер, чтобы переводились и ответы 404/405 роутера.
+func Language(defaultLang string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lang, explicit := i18n.Negotiate(r.Header.Get("Accept-Language"))
+			if !explicit {
+				lang = defaultLang
+			}
+
+			w.Header().Set(api.HeaderContentLanguage, lang)
+			ctx := context.WithValue(r.Context(), LanguageKey, lang)
+			ctx = context.WithValue(ctx, languageExplicitKey, explicit)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UserLanguage middleware для запросов без поддерживаемого Accept-Language берет язык
+// из профиля пользователя, а для незарегистрированного - из initData Telegram.
+// Должен стоять после Authenticate и до Impersonate, чтобы учитывался язык реального пользователя.
+func UserLanguage(users LanguageProvider) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if explicit, _ := r.Context().Value(languageExplicitKey).(bool); explicit {
+				next.ServeHTTP(w, r)
+				return
+			}
+			userID, err := GetUserIDFromContext(r.Context())
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Ошибка чтения профиля не должна ломать запрос, остается язык по умолчанию
+			tag, _ := users.GetUserLanguage(r.Context(), userID)
+			if tag == "" {
+				if tgUser, ok := GetTelegramUserFromContext(r.Context()); ok {
+					tag = tgUser.LanguageCode
+				}
+			}
+			lang, ok := i18n.Normalize(tag)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set(api.HeaderContentLanguage, lang)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), LanguageKey, lang)))
+		})
+	}
+}
+
+// GetLanguageFromContext извлекает язык ответа из контекста
+func GetLanguageFromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(LanguageKey).(string); ok {
+		return lang
+	}
+	return i18n.DefaultLanguage
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+)
+
+// AuthorizerProvider возвращает справочник ролей и прав
+type AuthorizerProvider interface {
+	Authorizer(ctx context.Context) (*domain.Authorizer, error)
+}
+
+// RequirePermission middleware проверяет, что роль пользователя имеет право permission
+func RequirePermission(policy AuthorizerProvider, permission domain.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, err := GetRoleFromContext(r.Context())
+			if err != nil {
+				api.RespondProblem(w, http.StatusUnauthorized, api.CodeUnauthorized, "unauthorized")
+				return
+			}
+
+			authz, err := policy.Authorizer(r.Context())
+			if err != nil {
+				api.RespondProblem(w, http.StatusInternalServerError, api.CodeInternal, "failed to load permissions")
+				return
+			}
+
+			if !authz.Has(role, permission) {
+				api.RespondProblem(w, http.StatusForbidden, api.CodePermissionDenied, "permission "+string(permission)+" required")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/pkg/ratelimit"
+)
+
+// RateLimitKey определяет, по чему считается лимит
+type RateLimitKey string
+
+const (
+	RateLimitByUser    RateLimitKey = "user"    // По аутентифицированному пользователю (иначе по IP)
+	RateLimitByIP      RateLimitKey = "ip"      // По IP клиента
+	RateLimitByService RateLimitKey = "service" // По вызывающему сервису (иначе по IP)
+)
+
+var (
+	rateLimitRejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limit_rejections_total",
+			Help: "Total number of requests rejected by rate limiter",
+		},
+		[]string{"rule", "key"},
+	)
+
+	rateLimitStoreErrorsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limit_store_errors_total",
+			Help: "Total number of rate limit store errors (requests are allowed on error)",
+		},
+		[]string{"rule"},
+	)
+)
+
+// RateLimitRule лимит для группы маршрутов
+type RateLimitRule struct {
+	Key   RateLimitKey
+	Limit ratelimit.Limit
+}
+
+// RateLimiter ограничивает частоту запросов по правилам, заданным для маршрутов
+type RateLimiter struct {
+	store          ratelimit.Store
+	rules          map[string]RateLimitRule
+	trustedProxies []*net.IPNet
+}
+
+// NewRateLimiter создает ограничитель. X-Forwarded-For учитывается только
+// если запрос пришел с адреса из trustedProxies.
+func NewRateLimiter(store ratelimit.Store, rules map[string]RateLimitRule, trustedProxies []*net.IPNet) *RateLimiter {
+	return &RateLimiter{
+		store:          store,
+		rules:          rules,
+		trustedProxies: trustedProxies,
+	}
+}
+
+// Limit возвращает middleware для правила name; если правило не настроено, запросы не ограничиваются.
+// Для ключа user middleware должен стоять после аутентификации, для ключа service - после ServiceAuth.
+func (l *RateLimiter) Limit(name string) func(http.Handler) http.Handler {
+	rule, ok := l.rules[name]
+	if !ok {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keyType, key := l.key(r, rule.Key)
+
+			result, err := l.store.Take(r.Context(), name+":"+string(keyType)+":"+key, rule.Limit)
+			if err != nil {
+				// Недоступность хранилища не должна останавливать сервис
+				rateLimitStoreErrorsTotal.WithLabelValues(name).Inc()
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("RateLimit-Reset", ceilSeconds(result.Reset))
+
+			if !result.Allowed {
+				rateLimitRejectionsTotal.WithLabelValues(name, string(keyType)).Inc()
+				w.Header().Set("Retry-After", ceilSeconds(result.RetryAfter))
+				api.RespondProblem(w, http.StatusTooManyRequests, api.CodeRateLimited, "too many requests")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// key возвращает фактический тип ключа и его значение
+func (l *RateLimiter) key(r *http.Request, keyType RateLimitKey) (RateLimitKey, string) {
+	switch keyType {
+	case RateLimitByUser:
+		if userID, err := GetUserIDFromContext(r.Context()); err == nil {
+			return RateLimitByUser, strconv.FormatInt(userID, 10)
+		}
+	case RateLimitByService:
+		if service, ok := r.Context().Value(ServiceNameKey).(string); ok {
+			return RateLimitByService, service
+		}
+	}
+	return RateLimitByIP, l.clientIP(r)
+}
+
+// clientIP возвращает IP клиента. Если запрос пришел от доверенного прокси, X-Forwarded-For
+// просматривается справа налево до первого адреса, не принадлежащего доверенным прокси.
+func (l *RateLimiter) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !l.isTrustedProxy(ip) {
+		return host
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	client := host
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		client = hop.String()
+		if !l.isTrustedProxy(hop) {
+			break
+		}
+	}
+	return client
+}
+
+func (l *RateLimiter) isTrustedProxy(ip net.IP) bool {
+	for _, network := range l.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+)
+
+const RequestIDKey contextKey = "requestID"
+
+// requestIDPattern допустимый идентификатор от клиента или gateway
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID middleware присваивает запросу идентификатор: берет X-Request-ID из запроса
+// или генерирует новый. Идентификатор возвращается в заголовке ответа и в теле ошибок.
+// Оборачивает весь роутер, чтобы идентификатор был и у ответов 404/405 роутера.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(api.HeaderRequestID)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+
+		w.Header().Set(api.HeaderRequestID, requestID)
+		ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// GetRequestIDFromContext извлекает идентификатор запроса из контекста
+func GetRequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/service/serviceauth"
+	"github.com/m04kA/SMC-UserService/internal/service/serviceauth/models"
+	"github.com/m04kA/SMC-UserService/pkg/servicesign"
+)
+
+const (
+	ServiceNameKey contextKey = "serviceName"
+
+	// maxSignedBodySize максимальный размер тела подписанного запроса
+	maxSignedBodySize = 1 << 20
+)
+
+var (
+	internalRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "internal_requests_total",
+			Help: "Total number of internal requests by calling service",
+		},
+		[]string{"service", "method", "endpoint", "status"},
+	)
+
+	serviceAuthFailuresTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "service_auth_failures_total",
+			Help: "Total number of rejected internal requests by reason",
+		},
+		[]string{"reason"},
+	)
+)
+
+// CallerProvider возвращает вызывающий сервис и его ключ подписи
+type CallerProvider interface {
+	GetCaller(ctx context.Context, keyID string) (*models.CallerDTO, error)
+}
+
+// NonceStore запоминает использованные nonce подписанных запросов.
+// Для нескольких инстансов хранилище должно быть общим (Postgres), иначе запрос можно повторить на другом инстансе.
+type NonceStore interface {
+	// Use запоминает key на ttl; возвращает false, если key уже использовался и еще не истек
+	Use(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// ServiceAuth middleware проверяет HMAC подпись межсервисного запроса
+// (метод, путь, метка времени, nonce, хеш тела) и защищает от повторов:
+// метка времени должна попадать в окно skew, а nonce не должен повторяться в пределах окна.
+func ServiceAuth(callers CallerProvider, nonces NonceStore, skew time.Duration) func(http.Handler) http.Handler {
+	nonceTTL := 2 * skew
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keyID := r.Header.Get(servicesign.HeaderKeyID)
+			signature := r.Header.Get(servicesign.HeaderSignature)
+			nonce := r.Header.Get(servicesign.HeaderNonce)
+			timestampStr := r.Header.Get(servicesign.HeaderTimestamp)
+			if keyID == "" || signature == "" || nonce == "" || timestampStr == "" {
+				rejectService(w, "missing_headers", http.StatusUnauthorized, api.CodeServiceAuthFailed, "missing service signature headers")
+				return
+			}
+
+			timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+			if err != nil || !servicesign.WithinSkew(timestamp, time.Now(), skew) {
+				rejectService(w, "stale_timestamp", http.StatusUnauthorized, api.CodeServiceAuthFailed, "service request timestamp is invalid or expired")
+				return
+			}
+
+			caller, err := callers.GetCaller(r.Context(), keyID)
+			if err != nil {
+				if errors.Is(err, serviceauth.ErrCredentialNotFound) || errors.Is(err, serviceauth.ErrCredentialRevoked) {
+					rejectService(w, "unknown_key", http.StatusUnauthorized, api.CodeServiceAuthFailed, "invalid service credentials")
+					return
+				}
+				rejectService(w, "lookup_error", http.StatusInternalServerError, api.CodeInternal, "failed to verify service credentials")
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxSignedBodySize+1))
+			if err != nil || len(body) > maxSignedBodySize {
+				rejectService(w, "body", http.StatusRequestEntityTooLarge, api.CodePayloadTooLarge, "request body is too large or unreadable")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			if !servicesign.Verify(caller.SigningKey, signature, r.Method, r.URL.RequestURI(), timestamp, nonce, body) {
+				rejectService(w, "bad_signature", http.StatusUnauthorized, api.CodeServiceAuthFailed, "invalid service signature")
+				return
+			}
+
+			fresh, err := nonces.Use(r.Context(), keyID+":"+nonce, nonceTTL)
+			if err != nil {
+				rejectService(w, "nonce_error", http.StatusInternalServerError, api.CodeInternal, "failed to check service request nonce")
+				return
+			}
+			if !fresh {
+				rejectService(w, "replay", http.StatusUnauthorized, api.CodeServiceAuthFailed, "service request replay detected")
+				return
+			}
+
+			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			ctx := context.WithValue(r.Context(), ServiceNameKey, caller.ServiceName)
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			endpoint := r.URL.Path
+			if route := mux.CurrentRoute(r); route != nil {
+				if pathTemplate, err := route.GetPathTemplate(); err == nil {
+					endpoint = pathTemplate
+				}
+			}
+			internalRequestsTotal.WithLabelValues(caller.ServiceName, r.Method, endpoint, strconv.Itoa(rw.statusCode)).Inc()
+		})
+	}
+}
+
+// GetServiceFromContext извлекает имя вызывающего сервиса из контекста
+func GetServiceFromContext(ctx context.Context) string {
+	service, ok := ctx.Value(ServiceNameKey).(string)
+	if !ok {
+		return "unknown"
+	}
+	return service
+}
+
+func rejectService(w http.ResponseWriter, reason string, status int, code api.ErrorCode, message string) {
+	serviceAuthFailuresTotal.WithLabelValues(reason).Inc()
+	api.RespondProblem(w, status, code, message)
+}
+
+// MemoryNonceStore хранит использованные nonce в памяти процесса (подходит только для одного инстанса)
+type MemoryNonceStore struct {
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{
+		seen:      make(map[string]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *MemoryNonceStore) Use(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, expires := range s.seen {
+			if now.After(expires) {
+				delete(s.seen, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if expires, ok := s.seen[key]; ok && now.Before(expires) {
+		return false, nil
+	}
+	s.seen[key] = now.Add(ttl)
+	return true, nil
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/pkg/telegram"
+)
+
+var (
+	ErrInvalidInitData = errors.New("invalid telegram init data")
+	ErrResolveRole     = errors.New("failed to resolve user role")
+)
+
+// RoleProvider возвращает роль зарегистрированного пользователя
+type RoleProvider interface {
+	// GetUserRole возвращает domain.RoleClient для незарегистрированного пользователя
+	GetUserRole(ctx context.Context, tgID int64) (domain.Role, error)
+}
+
+// TelegramAuthenticator проверяет initData Telegram Mini App из заголовка
+// Authorization: tma <initData> (или X-Telegram-Init-Data) и берет user ID из подписанного профиля.
+// Роль в initData отсутствует, поэтому она загружается из хранилища.
+type TelegramAuthenticator struct {
+	validator *telegram.Validator
+	roles     RoleProvider
+}
+
+func NewTelegramAuthenticator(validator *telegram.Validator, roles RoleProvider) *TelegramAuthenticator {
+	return &TelegramAuthenticator{validator: validator, roles: roles}
+}
+
+func (a *TelegramAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	raw, ok := authorizationCredentials(r, "tma")
+	if !ok {
+		raw = r.Header.Get("X-Telegram-Init-Data")
+	}
+	if raw == "" {
+		return nil, ErrNoCredentials
+	}
+
+	initData, err := a.validator.Validate(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInitData, err)
+	}
+
+	role, err := a.roles.GetUserRole(r.Context(), initData.User.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrResolveRole, err)
+	}
+
+	return &Identity{
+		UserID:   initData.User.ID,
+		Role:     role,
+		Telegram: &initData.User,
+	}, nil
+}
@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage язык сообщений, если клиент не указал поддерживаемый язык
+const DefaultLanguage = "ru"
+
+// catalogs сообщения по языкам; ключ - код ошибки API или "validation.<правило>"
+var catalogs = map[string]map[string]string{
+	"ru": messagesRU,
+	"en": messagesEN,
+}
+
+// Languages поддерживаемые языки в алфавитном порядке
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Normalize приводит языковой тег (en-US, ru_RU, RU) к поддерживаемому языку.
+// Возвращает false, если язык не поддерживается.
+func Normalize(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	_, ok := catalogs[tag]
+	return tag, ok
+}
+
+// Negotiate выбирает язык по заголовку Accept-Language с учетом весов q.
+// Возвращает false, если ни один из языков заголовка не поддерживается.
+func Negotiate(acceptLanguage string) (string, bool) {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		if lang, ok := Normalize(c.tag); ok {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// Message возвращает сообщение key на языке lang с подстановкой args.
+// Если перевода нет, используется DefaultLanguage, затем сам ключ.
+func Message(lang, key string, args ...any) string {
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		msg, ok = catalogs[DefaultLanguage][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Check проверяет полноту каталогов: каждый из keys и каждый ключ любого языка
+// должен быть переведен на все языки. Вызывается при старте сервиса.
+func Check(keys []string) error {
+	required := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		required[key] = struct{}{}
+	}
+	for _, catalog := range catalogs {
+		for key := range catalog {
+			required[key] = struct{}{}
+		}
+	}
+
+	var missing []string
+	for _, lang := range Languages() {
+		for key := range required {
+			if _, ok := catalogs[lang][key]; !ok {
+				missing = append(missing, lang+":"+key)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("i18n: missing messages: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
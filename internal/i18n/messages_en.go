@@ -0,0 +1,100 @@
+package i18n
+
+var messagesEN = map[string]string{
+	// Общие ошибки
+	"BAD_REQUEST":             "Bad request",
+	"INVALID_REQUEST_BODY":    "Invalid request body",
+	"VALIDATION_FAILED":       "Validation failed",
+	"UNAUTHORIZED":            "Authentication required",
+	"FORBIDDEN":               "Access denied",
+	"PERMISSION_DENIED":       "Insufficient permissions",
+	"NOT_FOUND":               "Not found",
+	"ROUTE_NOT_FOUND":         "Route not found",
+	"METHOD_NOT_ALLOWED":      "Method not allowed",
+	"CONFLICT":                "Conflict with the current state",
+	"GONE":                    "Resource is no longer available",
+	"PAYLOAD_TOO_LARGE":       "Request is too large",
+	"RATE_LIMITED":            "Too many requests, try again later",
+	"INTERNAL_ERROR":          "Internal server error",
+	"UPSTREAM_FAILED":         "External service is unavailable",
+	"ACCOUNT_BANNED":          "Account is banned",
+	"ACCOUNT_SUSPENDED":       "Account is suspended",
+	"SERVICE_AUTH_FAILED":     "Service signature verification failed",
+	"IMPERSONATION_FORBIDDEN": "Acting as this user is not allowed",
+
+	// Пользователи и автомобили
+	"USER_NOT_FOUND":        "User not found",
+	"USER_ALREADY_EXISTS":   "User with this Telegram ID already exists",
+	"USER_DELETED":          "Account is deleted and can be restored",
+	"USER_NOT_DELETED":      "Account is not deleted",
+	"RESTORE_EXPIRED":       "Restore period has expired",
+	"CAR_NOT_FOUND":         "Car not found",
+	"CAR_ACCESS_DENIED":     "Access denied to this car",
+	"INVALID_ROLE":          "Invalid role",
+	"LAST_SUPERUSER":        "Cannot demote or delete the last superuser",
+	"INVALID_STATUS":        "Invalid account status",
+	"SUSPENSION_IN_PAST":    "Suspension end must be in the future",
+	"OWN_STATUS_CHANGE":     "Cannot change own account status",
+	"INVALID_FILTER":        "Invalid list filter",
+	"INVALID_CURSOR":        "Invalid pagination cursor",
+	"INVALID_PHONE":         "Invalid phone number",
+	"PHONE_NUMBER_TAKEN":    "Phone number is used by another user",
+	"INVALID_LICENSE_PLATE": "License plate does not match the format",
+	"LICENSE_PLATE_TAKEN":   "This license plate is already registered by another user",
+	"PLATE_CLAIM_PENDING":   "The car cannot be selected until the license plate claim is resolved",
+
+	// Подтверждение телефона
+	"PHONE_NOT_SET":                  "Phone number is not set",
+	"PHONE_ALREADY_VERIFIED":         "Phone number is already verified",
+	"VERIFICATION_RESEND_TOO_SOON":   "Code was sent recently, try again later",
+	"VERIFICATION_NOT_FOUND":         "No pending phone verification",
+	"VERIFICATION_CODE_EXPIRED":      "Verification code has expired",
+	"VERIFICATION_CODE_INVALID":      "Invalid verification code",
+	"VERIFICATION_ATTEMPTS_EXCEEDED": "Too many attempts, request a new code",
+	"VERIFICATION_DELIVERY_FAILED":   "Failed to send verification code",
+
+	// Роли и ключи сервисов
+	"ROLE_NOT_FOUND":            "Role not found",
+	"ROLE_ALREADY_EXISTS":       "Role already exists",
+	"INVALID_ROLE_NAME":         "Invalid role name",
+	"UNKNOWN_PERMISSION":        "Unknown permission",
+	"PROTECTED_ROLE":            "Permissions of this role cannot be changed",
+	"CREDENTIAL_NOT_FOUND":      "Service credential not found",
+	"CREDENTIAL_REVOKED":        "Service credential is revoked",
+	"INVALID_SERVICE_NAME":      "Invalid service name",
+	"UNSUPPORTED_EXPORT_FORMAT": "Unsupported export format",
+
+	// Компании
+	"COMPANY_NOT_FOUND":        "Company not found",
+	"COMPANY_MEMBER_NOT_FOUND": "The user is not a member of this company",
+	"INVALID_COMPANY_ROLE":     "Invalid role in the company",
+	"USER_NOT_MANAGER":         "Only managers can be assigned to a company",
+	"COMPANY_ACCESS_DENIED":    "You are not a member of this company",
+
+	// Приглашения
+	"INVITATION_NOT_FOUND":        "Invitation not found",
+	"INVITATION_ALREADY_ACCEPTED": "The invitation has already been used",
+	"INVITATION_REVOKED":          "The invitation has been revoked",
+	"INVITATION_EXPIRED":          "The invitation has expired",
+	"INVITATION_FORBIDDEN":        "You cannot manage invitations for this role or company",
+	"INVALID_COMPANY_GRANT":       "Company membership can only be granted together with the manager role",
+	"INVALID_INVITATION_TTL":      "The invitation lifetime is too long",
+
+	// Споры о госномерах
+	"PLATE_CLAIM_NOT_FOUND": "License plate claim not found",
+	"PLATE_CLAIM_RESOLVED":  "The claim has already been resolved",
+	"OWN_PLATE_CLAIM":       "You cannot resolve a claim involving your own car",
+	"INVALID_RESOLUTION":    "Resolution must be one of: transferred, rejected, shared",
+	"PLATE_CLAIM_FORBIDDEN": "The claim does not involve members of your companies",
+
+	// Проверка полей
+	"validation.required":   "is required",
+	"validation.e164":       "must be a phone number in E.164 format",
+	"validation.oneof":      "must be one of: %s",
+	"validation.min.string": "must be at least %s characters",
+	"validation.min.items":  "must contain at least %s items",
+	"validation.min.number": "must be at least %s",
+	"validation.max.string": "must be at most %s characters",
+	"validation.max.items":  "must contain at most %s items",
+	"validation.max.number": "must be at most %s",
+}
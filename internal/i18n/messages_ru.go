@@ -0,0 +1,100 @@
+package i18n
+
+var messagesRU = map[string]string{
+	// Общие ошибки
+	"BAD_REQUEST":             "Некорректный запрос",
+	"INVALID_REQUEST_BODY":    "Не удалось разобрать тело запроса",
+	"VALIDATION_FAILED":       "Данные заполнены с ошибками",
+	"UNAUTHORIZED":            "Требуется авторизация",
+	"FORBIDDEN":               "Доступ запрещен",
+	"PERMISSION_DENIED":       "Недостаточно прав",
+	"NOT_FOUND":               "Не найдено",
+	"ROUTE_NOT_FOUND":         "Адрес не найден",
+	"METHOD_NOT_ALLOWED":      "Метод не поддерживается",
+	"CONFLICT":                "Конфликт с текущим состоянием",
+	"GONE":                    "Ресурс больше недоступен",
+	"PAYLOAD_TOO_LARGE":       "Слишком большой запрос",
+	"RATE_LIMITED":            "Слишком много запросов, повторите позже",
+	"INTERNAL_ERROR":          "Внутренняя ошибка сервера",
+	"UPSTREAM_FAILED":         "Внешний сервис недоступен",
+	"ACCOUNT_BANNED":          "Аккаунт заблокирован",
+	"ACCOUNT_SUSPENDED":       "Аккаунт временно приостановлен",
+	"SERVICE_AUTH_FAILED":     "Не удалось проверить подпись сервиса",
+	"IMPERSONATION_FORBIDDEN": "Действие от имени этого пользователя запрещено",
+
+	// Пользователи и автомобили
+	"USER_NOT_FOUND":        "Пользователь не найден",
+	"USER_ALREADY_EXISTS":   "Пользователь с этим Telegram ID уже зарегистрирован",
+	"USER_DELETED":          "Аккаунт удален, его можно восстановить",
+	"USER_NOT_DELETED":      "Аккаунт не удален",
+	"RESTORE_EXPIRED":       "Срок восстановления аккаунта истек",
+	"CAR_NOT_FOUND":         "Автомобиль не найден",
+	"CAR_ACCESS_DENIED":     "Нет доступа к этому автомобилю",
+	"INVALID_ROLE":          "Неизвестная роль",
+	"LAST_SUPERUSER":        "Нельзя снять роль с последнего суперпользователя или удалить его",
+	"INVALID_STATUS":        "Неизвестный статус аккаунта",
+	"SUSPENSION_IN_PAST":    "Дата окончания приостановки должна быть в будущем",
+	"OWN_STATUS_CHANGE":     "Нельзя изменить статус собственного аккаунта",
+	"INVALID_FILTER":        "Некорректный фильтр списка",
+	"INVALID_CURSOR":        "Некорректный курсор страницы",
+	"INVALID_PHONE":         "Некорректный номер телефона",
+	"PHONE_NUMBER_TAKEN":    "Номер телефона уже указан у другого пользователя",
+	"INVALID_LICENSE_PLATE": "Госномер не соответствует формату",
+	"LICENSE_PLATE_TAKEN":   "Этот госномер уже зарегистрирован другим пользователем",
+	"PLATE_CLAIM_PENDING":   "Автомобиль нельзя выбрать, пока спор о госномере не решен",
+
+	// Подтверждение телефона
+	"PHONE_NOT_SET":                  "Номер телефона не указан",
+	"PHONE_ALREADY_VERIFIED":         "Номер телефона уже подтвержден",
+	"VERIFICATION_RESEND_TOO_SOON":   "Код уже отправлен, повторите позже",
+	"VERIFICATION_NOT_FOUND":         "Нет активного запроса на подтверждение",
+	"VERIFICATION_CODE_EXPIRED":      "Срок действия кода истек",
+	"VERIFICATION_CODE_INVALID":      "Неверный код подтверждения",
+	"VERIFICATION_ATTEMPTS_EXCEEDED": "Слишком много попыток, запросите новый код",
+	"VERIFICATION_DELIVERY_FAILED":   "Не удалось отправить код подтверждения",
+
+	// Роли и ключи сервисов
+	"ROLE_NOT_FOUND":            "Роль не найдена",
+	"ROLE_ALREADY_EXISTS":       "Роль уже существует",
+	"INVALID_ROLE_NAME":         "Некорректное имя роли",
+	"UNKNOWN_PERMISSION":        "Неизвестное право",
+	"PROTECTED_ROLE":            "Права этой роли нельзя изменить",
+	"CREDENTIAL_NOT_FOUND":      "Ключ сервиса не найден",
+	"CREDENTIAL_REVOKED":        "Ключ сервиса отозван",
+	"INVALID_SERVICE_NAME":      "Некорректное имя сервиса",
+	"UNSUPPORTED_EXPORT_FORMAT": "Неподдерживаемый формат выгрузки",
+
+	// Компании
+	"COMPANY_NOT_FOUND":        "Компания не найдена",
+	"COMPANY_MEMBER_NOT_FOUND": "Пользователь не состоит в этой компании",
+	"INVALID_COMPANY_ROLE":     "Недопустимая роль в компании",
+	"USER_NOT_MANAGER":         "Назначить в компанию можно только менеджера",
+	"COMPANY_ACCESS_DENIED":    "Вы не состоите в этой компании",
+
+	// Приглашения
+	"INVITATION_NOT_FOUND":        "Приглашение не найдено",
+	"INVITATION_ALREADY_ACCEPTED": "Приглашение уже использовано",
+	"INVITATION_REVOKED":          "Приглашение отозвано",
+	"INVITATION_EXPIRED":          "Срок действия приглашения истек",
+	"INVITATION_FORBIDDEN":        "Нельзя управлять приглашениями для этой роли или компании",
+	"INVALID_COMPANY_GRANT":       "Членство в компании выдается только вместе с ролью менеджера",
+	"INVALID_INVITATION_TTL":      "Слишком большой срок действия приглашения",
+
+	// Споры о госномерах
+	"PLATE_CLAIM_NOT_FOUND": "Спор о госномере не найден",
+	"PLATE_CLAIM_RESOLVED":  "Решение по спору уже принято",
+	"OWN_PLATE_CLAIM":       "Нельзя решать спор о собственном автомобиле",
+	"INVALID_RESOLUTION":    "Допустимые решения: transferred, rejected, shared",
+	"PLATE_CLAIM_FORBIDDEN": "Спор не касается участников ваших компаний",
+
+	// Проверка полей
+	"validation.required":   "обязательное поле",
+	"validation.e164":       "номер телефона должен быть в формате E.164",
+	"validation.oneof":      "допустимые значения: %s",
+	"validation.min.string": "не короче %s символов",
+	"validation.min.items":  "не меньше %s элементов",
+	"validation.min.number": "не меньше %s",
+	"validation.max.string": "не длиннее %s символов",
+	"validation.max.items":  "не больше %s элементов",
+	"validation.max.number": "не больше %s",
+}
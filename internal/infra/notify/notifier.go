@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/service/plateclaim/models"
+)
+
+var (
+	ErrNotify = errors.New("failed to send notification")
+)
+
+// EventPlateClaimResolved событие о решении по спору о госномере
+const EventPlateClaimResolved = "plate_claim_resolved"
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+}
+
+// LogNotifier пишет уведомления в лог приложения. Для разработки и стендов без сервиса уведомлений.
+type LogNotifier struct {
+	log Logger
+}
+
+func NewLogNotifier(log Logger) *LogNotifier {
+	return &LogNotifier{log: log}
+}
+
+func (n *LogNotifier) NotifyPlateClaimResolved(_ context.Context, tgUserID int64, claim models.PlateClaimDTO) error {
+	n.log.Info("Notification to %d: plate claim %d resolved as %s", tgUserID, claim.ID, claim.Status)
+	return nil
+}
+
+// WebhookNotifier передает уведомление внешнему сервису (например, Telegram боту) через HTTP POST.
+// Тело запроса: {"tg_user_id": ..., "event": "plate_claim_resolved", "plate_claim": {...}},
+// текст сообщения формирует получатель. Успешный ответ - любой 2xx.
+type WebhookNotifier struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url, token string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type webhookPayload struct {
+	TGUserID   int64                `json:"tg_user_id"`
+	Event      string               `json:"event"`
+	PlateClaim models.PlateClaimDTO `json:"plate_claim"`
+}
+
+func (n *WebhookNotifier) NotifyPlateClaimResolved(ctx context.Context, tgUserID int64, claim models.PlateClaimDTO) error {
+	body, err := json.Marshal(webhookPayload{TGUserID: tgUserID, Event: EventPlateClaimResolved, PlateClaim: claim})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotify, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotify, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.token)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotify, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: webhook responded with status %d", ErrNotify, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	ErrSend = errors.New("failed to send message")
+)
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+}
+
+// LogSender пишет сообщения в лог приложения. Только для разработки: код виден в логах.
+type LogSender struct {
+	log Logger
+}
+
+func NewLogSender(log Logger) *LogSender {
+	return &LogSender{log: log}
+}
+
+func (s *LogSender) Send(_ context.Context, phoneNumber, message string) error {
+	s.log.Info("SMS to %s: %s", phoneNumber, message)
+	return nil
+}
+
+// FileSender дописывает сообщения в файл. Только для разработки и тестовых стендов.
+type FileSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSender(path string) *FileSender {
+	return &FileSender{path: path}
+}
+
+func (s *FileSender) Send(_ context.Context, phoneNumber, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSend, err)
+	}
+	defer f.Close()
+
+	if _, err = fmt.Fprintf(f, "%s\t%s\t%s\n", time.Now().UTC().Format(time.RFC3339), phoneNumber, message); err != nil {
+		return fmt.Errorf("%w: %v", ErrSend, err)
+	}
+
+	return nil
+}
+
+// WebhookSender передает сообщение внешнему шлюзу SMS через HTTP POST.
+// Тело запроса: {"phone_number": "...", "message": "..."}, успешный ответ - любой 2xx.
+type WebhookSender struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewWebhookSender(url, token string, timeout time.Duration) *WebhookSender {
+	return &WebhookSender{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type webhookPayload struct {
+	PhoneNumber string `json:"phone_number"`
+	Message     string `json:"message"`
+}
+
+func (s *WebhookSender) Send(ctx context.Context, phoneNumber, message string) error {
+	body, err := json.Marshal(webhookPayload{PhoneNumber: phoneNumber, Message: message})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSend, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSend, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSend, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: webhook responded with status %d", ErrSend, resp.StatusCode)
+	}
+
+	return nil
+}
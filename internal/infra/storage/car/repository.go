@@ -8,8 +8,10 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/m04kA/SMC-UserService/internal/domain"
 	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/pkg/dbtx"
 	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
 )
 
@@ -18,9 +20,27 @@ var (
 	ErrGetCar     = errors.New("failed to get car from database")
 	ErrUpdateCar  = errors.New("failed to update car in database")
 	ErrDeleteCar  = errors.New("failed to delete car from database")
+	ErrLockCars   = errors.New("failed to lock cars in database")
 	ErrBuildQuery = errors.New("failed to build SQL query")
 )
 
+// ownerNotDeleted исключает автомобили пользователей, удаленных мягким удалением
+const ownerNotDeleted = "EXISTS (SELECT 1 FROM users u WHERE u.tg_user_id = cars.user_id AND u.deleted_at IS NULL)"
+
+var carColumns = []string{
+	"id",
+	"user_id",
+	"brand",
+	"model",
+	"license_plate",
+	"license_plate_normalized",
+	"license_plate_format",
+	"color",
+	"size",
+	"is_selected",
+	"license_plate_status",
+}
+
 type Repository struct {
 	db *sqlx.DB
 }
@@ -31,11 +51,16 @@ func NewRepository(executor *sqlx.DB) *Repository {
 	}
 }
 
+// conn возвращает транзакцию из ctx (dbtx.Manager.WithTx) или соединение с БД
+func (r *Repository) conn(ctx context.Context) dbtx.Executor {
+	return dbtx.From(ctx, r.db)
+}
+
 // Create создает новый автомобиль и возвращает его с присвоенным ID
 func (r *Repository) Create(ctx context.Context, car *domain.Car) (*domain.Car, error) {
 	query, args, err := psqlbuilder.Insert("cars").
-		Columns("user_id", "brand", "model", "license_plate", "color", "size", "is_selected").
-		Values(car.UserID, car.Brand, car.Model, car.LicensePlate, car.Color, car.Size, car.IsSelected).
+		Columns("user_id", "brand", "model", "license_plate", "license_plate_normalized", "license_plate_format", "color", "size", "is_selected", "license_plate_status").
+		Values(car.UserID, car.Brand, car.Model, car.LicensePlate, car.LicensePlateNormalized, car.LicensePlateFormat, car.Color, car.Size, car.IsSelected, car.LicensePlateStatus).
 		Suffix("RETURNING id").
 		ToSql()
 	if err != nil {
@@ -43,7 +68,7 @@ func (r *Repository) Create(ctx context.Context, car *domain.Car) (*domain.Car,
 	}
 
 	var carID int64
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(&carID)
+	err = r.conn(ctx).GetContext(ctx, &carID, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrCreateCar, err)
 	}
@@ -54,8 +79,9 @@ func (r *Repository) Create(ctx context.Context, car *domain.Car) (*domain.Car,
 
 // GetByID получает автомобиль по ID
 func (r *Repository) GetByID(ctx context.Context, carID int64) (*domain.Car, error) {
-	query, args, err := psqlbuilder.Select("id", "user_id", "brand", "model", "license_plate", "color", "size", "is_selected").
+	query, args, err := psqlbuilder.Select(carColumns...).
 		From("cars").
+		Where(ownerNotDeleted).
 		Where(squirrel.Eq{"id": carID}).
 		ToSql()
 	if err != nil {
@@ -63,7 +89,7 @@ func (r *Repository) GetByID(ctx context.Context, carID int64) (*domain.Car, err
 	}
 
 	var car domain.Car
-	err = r.db.GetContext(ctx, &car, query, args...)
+	err = r.conn(ctx).GetContext(ctx, &car, query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, userservice.ErrCarNotFound
@@ -74,18 +100,64 @@ func (r *Repository) GetByID(ctx context.Context, carID int64) (*domain.Car, err
 	return &car, nil
 }
 
-// GetByUserID получает все автомобили пользователя
+// GetByUserID получает все автомобили пользователя в порядке добавления
 func (r *Repository) GetByUserID(ctx context.Context, userID int64) ([]*domain.Car, error) {
-	query, args, err := psqlbuilder.Select("id", "user_id", "brand", "model", "license_plate", "color", "size", "is_selected").
+	query, args, err := psqlbuilder.Select(carColumns...).
 		From("cars").
+		Where(ownerNotDeleted).
 		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("id").
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
 	var cars []*domain.Car
-	err = r.db.SelectContext(ctx, &cars, query, args...)
+	err = r.conn(ctx).SelectContext(ctx, &cars, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetCar, err)
+	}
+
+	if cars == nil {
+		cars = []*domain.Car{}
+	}
+
+	return cars, nil
+}
+
+// ListByUserID получает автомобили пользователя в порядке filter
+func (r *Repository) ListByUserID(ctx context.Context, filter userservice.CarListFilter) ([]*domain.Car, error) {
+	direction := " ASC"
+	if filter.SortDesc {
+		direction = " DESC"
+	}
+
+	builder := psqlbuilder.Select(carColumns...).
+		From("cars").
+		Where(ownerNotDeleted).
+		Where(squirrel.Eq{"user_id": filter.UserID})
+
+	switch filter.SortField {
+	case userservice.CarSortBrand:
+		builder = builder.OrderBy("LOWER(brand)"+direction, "LOWER(model)"+direction, "id"+direction)
+	case userservice.CarSortSelected:
+		// По возрастанию выбранный автомобиль первый
+		selectedDirection := " DESC"
+		if filter.SortDesc {
+			selectedDirection = " ASC"
+		}
+		builder = builder.OrderBy("is_selected"+selectedDirection, "id"+direction)
+	default:
+		builder = builder.OrderBy("id" + direction)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var cars []*domain.Car
+	err = r.conn(ctx).SelectContext(ctx, &cars, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrGetCar, err)
 	}
@@ -97,22 +169,142 @@ func (r *Repository) GetByUserID(ctx context.Context, userID int64) ([]*domain.C
 	return cars, nil
 }
 
+// LockByUserID блокирует до конца транзакции автомобили пользователя и возвращает их.
+// Сначала блокируется строка пользователя (FOR NO KEY UPDATE не мешает вставке автомобилей
+// по внешнему ключу вне транзакции), поэтому параллельные изменения автомобилей одного
+// пользователя, включая добавление первого, выполняются по очереди.
+// Вызывается только внутри транзакции (dbtx.Manager.WithTx).
+func (r *Repository) LockByUserID(ctx context.Context, userID int64) ([]*domain.Car, error) {
+	lockQuery, lockArgs, err := psqlbuilder.Select("tg_user_id").
+		From("users").
+		Where(squirrel.Eq{"tg_user_id": userID, "deleted_at": nil}).
+		Suffix("FOR NO KEY UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var lockedID int64
+	err = r.conn(ctx).GetContext(ctx, &lockedID, lockQuery, lockArgs...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, userservice.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrLockCars, err)
+	}
+
+	query, args, err := psqlbuilder.Select(carColumns...).
+		From("cars").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("id").
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var cars []*domain.Car
+	err = r.conn(ctx).SelectContext(ctx, &cars, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLockCars, err)
+	}
+
+	return cars, nil
+}
+
+// LockPlate блокирует нормализованный номер до конца транзакции (advisory lock), чтобы два пользователя
+// не закрепили один номер параллельно. Вызывается только внутри транзакции (dbtx.Manager.WithTx).
+func (r *Repository) LockPlate(ctx context.Context, plate string) error {
+	_, err := r.conn(ctx).ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "license_plate:"+plate)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrLockCars, err)
+	}
+	return nil
+}
+
+// GetByUserIDs получает автомобили нескольких пользователей одним запросом
+func (r *Repository) GetByUserIDs(ctx context.Context, userIDs []int64) ([]*domain.Car, error) {
+	query, args, err := psqlbuilder.Select(carColumns...).
+		From("cars").
+		Where(ownerNotDeleted).
+		Where("user_id = ANY(?)", pq.Array(userIDs)).
+		OrderBy("user_id", "id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var cars []*domain.Car
+	err = r.conn(ctx).SelectContext(ctx, &cars, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetCar, err)
+	}
+
+	return cars, nil
+}
+
+// GetByNormalizedPlate получает автомобили с нормализованным номером plate.
+// Автомобили со спорным номером (pending_claim) не возвращаются.
+func (r *Repository) GetByNormalizedPlate(ctx context.Context, plate string) ([]*domain.Car, error) {
+	query, args, err := psqlbuilder.Select(carColumns...).
+		From("cars").
+		Where(ownerNotDeleted).
+		Where(squirrel.Eq{"license_plate_normalized": plate, "license_plate_status": domain.PlateStatusConfirmed}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var cars []*domain.Car
+	err = r.conn(ctx).SelectContext(ctx, &cars, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetCar, err)
+	}
+
+	return cars, nil
+}
+
+// GetSelectedByUserIDs получает выбранные автомобили нескольких пользователей одним запросом
+func (r *Repository) GetSelectedByUserIDs(ctx context.Context, userIDs []int64) ([]*domain.Car, error) {
+	query, args, err := psqlbuilder.Select(carColumns...).
+		From("cars").
+		Where(ownerNotDeleted).
+		Where("user_id = ANY(?)", pq.Array(userIDs)).
+		Where(squirrel.Eq{"is_selected": true}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var cars []*domain.Car
+	err = r.conn(ctx).SelectContext(ctx, &cars, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetCar, err)
+	}
+
+	return cars, nil
+}
+
 // Update обновляет данные автомобиля
 func (r *Repository) Update(ctx context.Context, car *domain.Car) error {
 	query, args, err := psqlbuilder.Update("cars").
 		Set("brand", car.Brand).
 		Set("model", car.Model).
 		Set("license_plate", car.LicensePlate).
+		Set("license_plate_normalized", car.LicensePlateNormalized).
+		Set("license_plate_format", car.LicensePlateFormat).
 		Set("color", car.Color).
 		Set("size", car.Size).
 		Set("is_selected", car.IsSelected).
+		Set("license_plate_status", car.LicensePlateStatus).
 		Where(squirrel.Eq{"id": car.ID}).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
+	result, err := r.conn(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrUpdateCar, err)
 	}
@@ -138,7 +330,7 @@ func (r *Repository) Delete(ctx context.Context, carID int64) error {
 		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
+	result, err := r.conn(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrDeleteCar, err)
 	}
@@ -157,8 +349,9 @@ func (r *Repository) Delete(ctx context.Context, carID int64) error {
 
 // GetSelectedByUserID получает выбранный автомобиль пользователя
 func (r *Repository) GetSelectedByUserID(ctx context.Context, userID int64) (*domain.Car, error) {
-	query, args, err := psqlbuilder.Select("id", "user_id", "brand", "model", "license_plate", "color", "size", "is_selected").
+	query, args, err := psqlbuilder.Select(carColumns...).
 		From("cars").
+		Where(ownerNotDeleted).
 		Where(squirrel.Eq{"user_id": userID, "is_selected": true}).
 		ToSql()
 	if err != nil {
@@ -166,7 +359,7 @@ func (r *Repository) GetSelectedByUserID(ctx context.Context, userID int64) (*do
 	}
 
 	var car domain.Car
-	err = r.db.GetContext(ctx, &car, query, args...)
+	err = r.conn(ctx).GetContext(ctx, &car, query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, userservice.ErrCarNotFound
@@ -187,7 +380,7 @@ func (r *Repository) UnselectAllByUserID(ctx context.Context, userID int64) erro
 		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
-	_, err = r.db.ExecContext(ctx, query, args...)
+	_, err = r.conn(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrUpdateCar, err)
 	}
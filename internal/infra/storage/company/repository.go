@@ -0,0 +1,183 @@
+package company
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/company"
+	"github.com/m04kA/SMC-UserService/pkg/dbtx"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrCreateCompany = errors.New("failed to create company in database")
+	ErrGetCompany    = errors.New("failed to get company from database")
+	ErrSetMember     = errors.New("failed to set company member in database")
+	ErrRemoveMember  = errors.New("failed to remove company member from database")
+	ErrGetMembers    = errors.New("failed to get company members from database")
+	ErrBuildQuery    = errors.New("failed to build SQL query")
+)
+
+var companyColumns = []string{"id", "name", "created_by", "created_at"}
+
+// memberReturning колонки членства вместе с названием компании
+const memberReturning = "RETURNING company_id, tg_user_id, role, created_at, " +
+	"(SELECT name FROM companies WHERE id = company_members.company_id) AS company_name"
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db: executor,
+	}
+}
+
+// conn возвращает транзакцию из ctx (dbtx.Manager.WithTx) или соединение с БД
+func (r *Repository) conn(ctx context.Context) dbtx.Executor {
+	return dbtx.From(ctx, r.db)
+}
+
+// Create сохраняет компанию и возвращает ее с присвоенным ID
+func (r *Repository) Create(ctx context.Context, c *domain.Company) (*domain.Company, error) {
+	query, args, err := psqlbuilder.Insert("companies").
+		Columns("name", "created_by", "created_at").
+		Values(c.Name, c.CreatedBy, c.CreatedAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	err = r.conn(ctx).GetContext(ctx, &c.ID, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCreateCompany, err)
+	}
+
+	return c, nil
+}
+
+// GetByID находит компанию по ID
+func (r *Repository) GetByID(ctx context.Context, id int64) (*domain.Company, error) {
+	query, args, err := psqlbuilder.Select(companyColumns...).
+		From("companies").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var c domain.Company
+	err = r.conn(ctx).GetContext(ctx, &c, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, company.ErrCompanyNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrGetCompany, err)
+	}
+
+	return &c, nil
+}
+
+// List возвращает все компании
+func (r *Repository) List(ctx context.Context) ([]*domain.Company, error) {
+	query, args, err := psqlbuilder.Select(companyColumns...).
+		From("companies").
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var companies []*domain.Company
+	err = r.conn(ctx).SelectContext(ctx, &companies, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetCompany, err)
+	}
+
+	return companies, nil
+}
+
+// SetMember добавляет менеджера в компанию или меняет его роль, дата назначения сохраняется.
+// Вставка идет через SELECT из companies, поэтому для несуществующей компании строк не будет.
+func (r *Repository) SetMember(ctx context.Context, member *domain.CompanyMember) (*domain.CompanyMember, error) {
+	source := squirrel.Select("id").
+		Column("?::bigint", member.TGUserID).
+		Column("?::varchar", string(member.Role)).
+		Column("?::timestamp", member.CreatedAt).
+		From("companies").
+		Where(squirrel.Eq{"id": member.CompanyID})
+
+	query, args, err := psqlbuilder.Insert("company_members").
+		Columns("company_id", "tg_user_id", "role", "created_at").
+		Select(source).
+		Suffix("ON CONFLICT (company_id, tg_user_id) DO UPDATE SET role = EXCLUDED.role " + memberReturning).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var saved domain.CompanyMember
+	err = r.conn(ctx).GetContext(ctx, &saved, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, company.ErrCompanyNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrSetMember, err)
+	}
+
+	return &saved, nil
+}
+
+// RemoveMember исключает менеджера из компании
+func (r *Repository) RemoveMember(ctx context.Context, companyID, tgID int64) error {
+	query, args, err := psqlbuilder.Delete("company_members").
+		Where(squirrel.Eq{"company_id": companyID, "tg_user_id": tgID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRemoveMember, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrRemoveMember, err)
+	}
+
+	if rowsAffected == 0 {
+		return company.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+// GetMembershipsByUserID возвращает членства пользователя с названиями компаний
+func (r *Repository) GetMembershipsByUserID(ctx context.Context, tgID int64) ([]*domain.CompanyMember, error) {
+	query, args, err := psqlbuilder.Select("m.company_id", "c.name AS company_name", "m.tg_user_id", "m.role", "m.created_at").
+		From("company_members m").
+		Join("companies c ON c.id = m.company_id").
+		Where(squirrel.Eq{"m.tg_user_id": tgID}).
+		OrderBy("m.company_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var members []*domain.CompanyMember
+	err = r.conn(ctx).SelectContext(ctx, &members, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetMembers, err)
+	}
+
+	return members, nil
+}
@@ -0,0 +1,141 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/export"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrGetRoleChanges = errors.New("failed to get role changes from database")
+	ErrGetEvents      = errors.New("failed to get impersonation audit events from database")
+	ErrGetConsents    = errors.New("failed to get user consents from database")
+	ErrGetMemberships = errors.New("failed to get company memberships from database")
+	ErrGetInvitations = errors.New("failed to get invitations from database")
+	ErrGetPlateClaims = errors.New("failed to get plate claims from database")
+	ErrBuildQuery     = errors.New("failed to build SQL query")
+)
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db: executor,
+	}
+}
+
+// StreamRoleChanges передает в fn историю ролей пользователя в хронологическом порядке
+func (r *Repository) StreamRoleChanges(ctx context.Context, tgID int64, fn func(export.RoleChangeRecord) error) error {
+	query := psqlbuilder.Select(
+		"rc.id", "rc.tg_user_id", "rc.old_role_id", "rc.new_role_id", "rc.changed_by", "rc.reason", "rc.changed_at",
+		"COALESCE(old_r.name, '') AS old_role_name",
+		"COALESCE(new_r.name, '') AS new_role_name",
+	).
+		From("role_changes rc").
+		LeftJoin("roles old_r ON rc.old_role_id = old_r.id").
+		LeftJoin("roles new_r ON rc.new_role_id = new_r.id").
+		Where(squirrel.Eq{"rc.tg_user_id": tgID}).
+		OrderBy("rc.changed_at", "rc.id")
+
+	return stream(ctx, r.db, query, ErrGetRoleChanges, fn)
+}
+
+// StreamImpersonationEvents передает в fn записи журнала имперсонации, где пользователь был инициатором или целью
+func (r *Repository) StreamImpersonationEvents(ctx context.Context, tgID int64, fn func(domain.ImpersonationEvent) error) error {
+	query := psqlbuilder.Select("id", "actor_id", "target_id", "method", "path", "status_code", "created_at").
+		From("impersonation_audit").
+		Where(squirrel.Or{
+			squirrel.Eq{"actor_id": tgID},
+			squirrel.Eq{"target_id": tgID},
+		}).
+		OrderBy("created_at", "id")
+
+	return stream(ctx, r.db, query, ErrGetEvents, fn)
+}
+
+// StreamConsents передает в fn согласия пользователя в порядке принятия
+func (r *Repository) StreamConsents(ctx context.Context, tgID int64, fn func(domain.UserConsent) error) error {
+	query := psqlbuilder.Select("id", "tg_user_id", "consent_type", "granted_at", "revoked_at").
+		From("user_consents").
+		Where(squirrel.Eq{"tg_user_id": tgID}).
+		OrderBy("granted_at", "id")
+
+	return stream(ctx, r.db, query, ErrGetConsents, fn)
+}
+
+// StreamCompanyMemberships передает в fn членство пользователя в компаниях
+func (r *Repository) StreamCompanyMemberships(ctx context.Context, tgID int64, fn func(domain.CompanyMember) error) error {
+	query := psqlbuilder.Select("m.company_id", "c.name AS company_name", "m.tg_user_id", "m.role", "m.created_at").
+		From("company_members m").
+		Join("companies c ON c.id = m.company_id").
+		Where(squirrel.Eq{"m.tg_user_id": tgID}).
+		OrderBy("m.created_at", "m.company_id")
+
+	return stream(ctx, r.db, query, ErrGetMemberships, fn)
+}
+
+// StreamInvitations передает в fn приглашения, которые пользователь выпустил или принял (без хеша токена)
+func (r *Repository) StreamInvitations(ctx context.Context, tgID int64, fn func(domain.Invitation) error) error {
+	query := psqlbuilder.Select("id", "role", "company_id", "company_role", "created_by", "created_at", "expires_at",
+		"accepted_by", "accepted_at", "revoked_at").
+		From("invitations").
+		Where(squirrel.Or{
+			squirrel.Eq{"created_by": tgID},
+			squirrel.Eq{"accepted_by": tgID},
+		}).
+		OrderBy("created_at", "id")
+
+	return stream(ctx, r.db, query, ErrGetInvitations, fn)
+}
+
+// StreamPlateClaims передает в fn споры о госномерах, где пользователь заявитель или владелец номера
+func (r *Repository) StreamPlateClaims(ctx context.Context, tgID int64, fn func(domain.PlateClaim) error) error {
+	query := psqlbuilder.Select("id", "car_id", "claimant_id", "holder_car_id", "holder_id", "license_plate_normalized",
+		"status", "resolved_by", "comment", "created_at", "resolved_at").
+		From("plate_claims").
+		Where(squirrel.Or{
+			squirrel.Eq{"claimant_id": tgID},
+			squirrel.Eq{"holder_id": tgID},
+		}).
+		OrderBy("created_at", "id")
+
+	return stream(ctx, r.db, query, ErrGetPlateClaims, fn)
+}
+
+// stream выполняет запрос и построчно передает записи в fn, не загружая результат в память.
+// Ошибки БД оборачиваются в errGet, ошибка fn возвращается как есть и прекращает обход.
+func stream[T any](ctx context.Context, db *sqlx.DB, builder squirrel.SelectBuilder, errGet error, fn func(T) error) error {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errGet, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record T
+		if err := rows.StructScan(&record); err != nil {
+			return fmt.Errorf("%w: %v", errGet, err)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%w: %v", errGet, err)
+	}
+
+	return nil
+}
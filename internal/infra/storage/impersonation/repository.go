@@ -0,0 +1,93 @@
+package impersonation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/impersonation"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrCreateEvent = errors.New("failed to create impersonation audit event in database")
+	ErrUpdateEvent = errors.New("failed to update impersonation audit event in database")
+	ErrGetEvents   = errors.New("failed to get impersonation audit events from database")
+	ErrBuildQuery  = errors.New("failed to build SQL query")
+)
+
+var eventColumns = []string{"id", "actor_id", "target_id", "method", "path", "status_code", "created_at"}
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db: executor,
+	}
+}
+
+// Create сохраняет запись журнала и присваивает ей ID
+func (r *Repository) Create(ctx context.Context, event *domain.ImpersonationEvent) error {
+	query, args, err := psqlbuilder.Insert("impersonation_audit").
+		Columns("actor_id", "target_id", "method", "path", "created_at").
+		Values(event.ActorID, event.TargetID, event.Method, event.Path, event.CreatedAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&event.ID); err != nil {
+		return fmt.Errorf("%w: %v", ErrCreateEvent, err)
+	}
+
+	return nil
+}
+
+// SetStatus сохраняет HTTP статус запроса
+func (r *Repository) SetStatus(ctx context.Context, id int64, statusCode int) error {
+	query, args, err := psqlbuilder.Update("impersonation_audit").
+		Set("status_code", statusCode).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateEvent, err)
+	}
+
+	return nil
+}
+
+// List возвращает записи журнала по фильтру, новые первыми
+func (r *Repository) List(ctx context.Context, filter impersonation.AuditFilter) ([]*domain.ImpersonationEvent, error) {
+	builder := psqlbuilder.Select(eventColumns...).
+		From("impersonation_audit").
+		OrderBy("created_at DESC", "id DESC").
+		Limit(filter.Limit)
+	if filter.ActorID != 0 {
+		builder = builder.Where(squirrel.Eq{"actor_id": filter.ActorID})
+	}
+	if filter.TargetID != 0 {
+		builder = builder.Where(squirrel.Eq{"target_id": filter.TargetID})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var events []*domain.ImpersonationEvent
+	if err := r.db.SelectContext(ctx, &events, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetEvents, err)
+	}
+
+	return events, nil
+}
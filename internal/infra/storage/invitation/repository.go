@@ -0,0 +1,179 @@
+package invitation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/invitation"
+	"github.com/m04kA/SMC-UserService/pkg/dbtx"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrCreateInvitation = errors.New("failed to create invitation in database")
+	ErrGetInvitation    = errors.New("failed to get invitation from database")
+	ErrUpdateInvitation = errors.New("failed to update invitation in database")
+	ErrBuildQuery       = errors.New("failed to build SQL query")
+)
+
+var invitationColumns = []string{
+	"id", "token_hash", "role", "company_id", "company_role", "created_by",
+	"created_at", "expires_at", "accepted_by", "accepted_at", "revoked_at",
+}
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db: executor,
+	}
+}
+
+// conn возвращает транзакцию из ctx (dbtx.Manager.WithTx) или соединение с БД
+func (r *Repository) conn(ctx context.Context) dbtx.Executor {
+	return dbtx.From(ctx, r.db)
+}
+
+// Create сохраняет приглашение и возвращает его с присвоенным ID
+func (r *Repository) Create(ctx context.Context, inv *domain.Invitation) (*domain.Invitation, error) {
+	query, args, err := psqlbuilder.Insert("invitations").
+		Columns("token_hash", "role", "company_id", "company_role", "created_by", "created_at", "expires_at").
+		Values(inv.TokenHash, inv.Role, inv.CompanyID, inv.CompanyRole, inv.CreatedBy, inv.CreatedAt, inv.ExpiresAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	err = r.conn(ctx).GetContext(ctx, &inv.ID, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCreateInvitation, err)
+	}
+
+	return inv, nil
+}
+
+// GetByID находит приглашение по ID
+func (r *Repository) GetByID(ctx context.Context, id int64) (*domain.Invitation, error) {
+	return r.getOne(ctx, squirrel.Eq{"id": id})
+}
+
+// GetByTokenHash находит приглашение по хешу токена
+func (r *Repository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	return r.getOne(ctx, squirrel.Eq{"token_hash": tokenHash})
+}
+
+// List возвращает все приглашения, новые первыми
+func (r *Repository) List(ctx context.Context) ([]*domain.Invitation, error) {
+	return r.list(ctx, nil)
+}
+
+// ListByCompanyIDs возвращает приглашения в указанные компании, новые первыми
+func (r *Repository) ListByCompanyIDs(ctx context.Context, companyIDs []int64) ([]*domain.Invitation, error) {
+	return r.list(ctx, squirrel.Eq{"company_id": companyIDs})
+}
+
+// Claim атомарно отмечает действующее приглашение принятым: параллельные попытки принять
+// одно приглашение не могут пройти обе
+func (r *Repository) Claim(ctx context.Context, tokenHash string, tgID int64, acceptedAt time.Time) (*domain.Invitation, error) {
+	query, args, err := psqlbuilder.Update("invitations").
+		Set("accepted_by", tgID).
+		Set("accepted_at", acceptedAt).
+		Where(squirrel.Eq{"token_hash": tokenHash, "accepted_at": nil, "revoked_at": nil}).
+		Where(squirrel.Gt{"expires_at": acceptedAt}).
+		Suffix("RETURNING " + strings.Join(invitationColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var inv domain.Invitation
+	err = r.conn(ctx).GetContext(ctx, &inv, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, invitation.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUpdateInvitation, err)
+	}
+
+	return &inv, nil
+}
+
+// Revoke помечает непринятое приглашение отозванным (повторный отзыв не меняет дату)
+func (r *Repository) Revoke(ctx context.Context, id int64) error {
+	query, args, err := psqlbuilder.Update("invitations").
+		Set("revoked_at", squirrel.Expr("COALESCE(revoked_at, NOW())")).
+		Where(squirrel.Eq{"id": id, "accepted_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateInvitation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrUpdateInvitation, err)
+	}
+
+	if rowsAffected == 0 {
+		return invitation.ErrInvitationNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) getOne(ctx context.Context, where squirrel.Sqlizer) (*domain.Invitation, error) {
+	query, args, err := psqlbuilder.Select(invitationColumns...).
+		From("invitations").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var inv domain.Invitation
+	err = r.conn(ctx).GetContext(ctx, &inv, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, invitation.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrGetInvitation, err)
+	}
+
+	return &inv, nil
+}
+
+func (r *Repository) list(ctx context.Context, where squirrel.Sqlizer) ([]*domain.Invitation, error) {
+	builder := psqlbuilder.Select(invitationColumns...).
+		From("invitations").
+		OrderBy("id DESC")
+	if where != nil {
+		builder = builder.Where(where)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var invitations []*domain.Invitation
+	err = r.conn(ctx).SelectContext(ctx, &invitations, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetInvitation, err)
+	}
+
+	return invitations, nil
+}
@@ -0,0 +1,175 @@
+package phone
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/phone"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrSaveVerification   = errors.New("failed to save phone verification in database")
+	ErrGetVerification    = errors.New("failed to get phone verification from database")
+	ErrUpdateVerification = errors.New("failed to update phone verification in database")
+	ErrDeleteVerification = errors.New("failed to delete phone verification from database")
+	ErrConfirmPhone       = errors.New("failed to confirm phone number in database")
+	ErrBuildQuery         = errors.New("failed to build SQL query")
+)
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db: executor,
+	}
+}
+
+// Save сохраняет код подтверждения; предыдущий код пользователя и счетчик попыток сбрасываются
+func (r *Repository) Save(ctx context.Context, verification *domain.PhoneVerification) error {
+	query, args, err := psqlbuilder.Insert("phone_verifications").
+		Columns("tg_user_id", "phone_number", "code_hash", "attempts", "expires_at", "created_at").
+		Values(verification.TGUserID, verification.PhoneNumber, verification.CodeHash, 0, verification.ExpiresAt, verification.CreatedAt).
+		Suffix(`ON CONFLICT (tg_user_id) DO UPDATE SET
+			phone_number = EXCLUDED.phone_number,
+			code_hash = EXCLUDED.code_hash,
+			attempts = 0,
+			expires_at = EXCLUDED.expires_at,
+			created_at = EXCLUDED.created_at`).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err = r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrSaveVerification, err)
+	}
+
+	return nil
+}
+
+// Get возвращает активный код подтверждения пользователя
+func (r *Repository) Get(ctx context.Context, tgID int64) (*domain.PhoneVerification, error) {
+	query, args, err := psqlbuilder.Select("tg_user_id", "phone_number", "code_hash", "attempts", "expires_at", "created_at").
+		From("phone_verifications").
+		Where(squirrel.Eq{"tg_user_id": tgID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var verification domain.PhoneVerification
+	if err = r.db.GetContext(ctx, &verification, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, phone.ErrVerificationNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrGetVerification, err)
+	}
+
+	return &verification, nil
+}
+
+// Delete удаляет код подтверждения пользователя
+func (r *Repository) Delete(ctx context.Context, tgID int64) error {
+	query, args, err := psqlbuilder.Delete("phone_verifications").
+		Where(squirrel.Eq{"tg_user_id": tgID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err = r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrDeleteVerification, err)
+	}
+
+	return nil
+}
+
+// IncrementAttempts атомарно увеличивает счетчик попыток, если лимит не исчерпан
+func (r *Repository) IncrementAttempts(ctx context.Context, tgID int64, maxAttempts int) error {
+	query, args, err := psqlbuilder.Update("phone_verifications").
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Where(squirrel.Eq{"tg_user_id": tgID}).
+		Where(squirrel.Lt{"attempts": maxAttempts}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateVerification, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrUpdateVerification, err)
+	}
+
+	if rowsAffected == 0 {
+		return phone.ErrTooManyAttempts
+	}
+
+	return nil
+}
+
+// Confirm в одной транзакции отмечает номер подтвержденным и удаляет код.
+// Номер подтверждается, только если он не изменился в профиле после отправки кода.
+func (r *Repository) Confirm(ctx context.Context, tgID int64, phoneNumber string, verifiedAt time.Time) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to begin transaction: %v", ErrConfirmPhone, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	updateQuery, updateArgs, err := psqlbuilder.Update("users").
+		Set("phone_verified_at", verifiedAt).
+		Where(squirrel.Eq{"tg_user_id": tgID, "phone_number": phoneNumber, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := tx.ExecContext(ctx, updateQuery, updateArgs...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrConfirmPhone, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrConfirmPhone, err)
+	}
+
+	if rowsAffected == 0 {
+		return phone.ErrVerificationNotFound
+	}
+
+	deleteQuery, deleteArgs, err := psqlbuilder.Delete("phone_verifications").
+		Where(squirrel.Eq{"tg_user_id": tgID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, deleteQuery, deleteArgs...); err != nil {
+		return fmt.Errorf("%w: %v", ErrConfirmPhone, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%w: failed to commit transaction: %v", ErrConfirmPhone, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package plateclaim
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/plateclaim"
+	"github.com/m04kA/SMC-UserService/pkg/dbtx"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrCreateClaim = errors.New("failed to create plate claim in database")
+	ErrGetClaim    = errors.New("failed to get plate claim from database")
+	ErrUpdateClaim = errors.New("failed to update plate claim in database")
+	ErrBuildQuery  = errors.New("failed to build SQL query")
+)
+
+var claimColumns = []string{
+	"id",
+	"car_id",
+	"claimant_id",
+	"holder_car_id",
+	"holder_id",
+	"license_plate_normalized",
+	"status",
+	"resolved_by",
+	"comment",
+	"created_at",
+	"resolved_at",
+}
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db: executor,
+	}
+}
+
+// conn возвращает транзакцию из ctx (dbtx.Manager.WithTx) или соединение с БД
+func (r *Repository) conn(ctx context.Context) dbtx.Executor {
+	return dbtx.From(ctx, r.db)
+}
+
+// Create сохраняет спор и присваивает ему ID
+func (r *Repository) Create(ctx context.Context, claim *domain.PlateClaim) error {
+	query, args, err := psqlbuilder.Insert("plate_claims").
+		Columns("car_id", "claimant_id", "holder_car_id", "holder_id", "license_plate_normalized", "status", "created_at").
+		Values(claim.CarID, claim.ClaimantID, claim.HolderCarID, claim.HolderID, claim.LicensePlate, claim.Status, claim.CreatedAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if err := r.conn(ctx).GetContext(ctx, &claim.ID, query, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrCreateClaim, err)
+	}
+
+	return nil
+}
+
+// WithdrawByCarID закрывает нерешенный спор автомобиля carID как отозванный; отсутствие спора не ошибка
+func (r *Repository) WithdrawByCarID(ctx context.Context, carID int64, at time.Time) error {
+	query, args, err := psqlbuilder.Update("plate_claims").
+		Set("status", domain.PlateClaimWithdrawn).
+		Set("resolved_at", at).
+		Where(squirrel.Eq{"car_id": carID, "status": domain.PlateClaimPending}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := r.conn(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateClaim, err)
+	}
+
+	return nil
+}
+
+// GetForUpdate получает спор и блокирует его до конца транзакции.
+// Вызывается только внутри транзакции (dbtx.Manager.WithTx).
+func (r *Repository) GetForUpdate(ctx context.Context, id int64) (*domain.PlateClaim, error) {
+	query, args, err := psqlbuilder.Select(claimColumns...).
+		From("plate_claims").
+		Where(squirrel.Eq{"id": id}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var claim domain.PlateClaim
+	if err := r.conn(ctx).GetContext(ctx, &claim, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, plateclaim.ErrPlateClaimNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrGetClaim, err)
+	}
+
+	return &claim, nil
+}
+
+// Resolve сохраняет решение по спору
+func (r *Repository) Resolve(ctx context.Context, claim *domain.PlateClaim) error {
+	query, args, err := psqlbuilder.Update("plate_claims").
+		Set("status", claim.Status).
+		Set("resolved_by", claim.ResolvedBy).
+		Set("comment", claim.Comment).
+		Set("resolved_at", claim.ResolvedAt).
+		Where(squirrel.Eq{"id": claim.ID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateClaim, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrUpdateClaim, err)
+	}
+
+	if rowsAffected == 0 {
+		return plateclaim.ErrPlateClaimNotFound
+	}
+
+	return nil
+}
+
+// List возвращает споры по фильтру в порядке подачи
+func (r *Repository) List(ctx context.Context, filter plateclaim.ClaimFilter) ([]*domain.PlateClaim, error) {
+	builder := psqlbuilder.Select(claimColumns...).
+		From("plate_claims").
+		OrderBy("id").
+		Limit(filter.Limit)
+	if filter.Status != "" {
+		builder = builder.Where(squirrel.Eq{"status": filter.Status})
+	}
+	if filter.UserID != 0 {
+		builder = builder.Where(squirrel.Or{
+			squirrel.Eq{"claimant_id": filter.UserID},
+			squirrel.Eq{"holder_id": filter.UserID},
+		})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var claims []*domain.PlateClaim
+	if err := r.conn(ctx).SelectContext(ctx, &claims, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetClaim, err)
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,75 @@
+package platelookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/platelookup"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrCreateEvent = errors.New("failed to create plate lookup audit event in database")
+	ErrGetEvents   = errors.New("failed to get plate lookup audit events from database")
+	ErrBuildQuery  = errors.New("failed to build SQL query")
+)
+
+var eventColumns = []string{"id", "actor_id", "company_id", "plate", "result_count", "created_at"}
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db: executor,
+	}
+}
+
+// Create сохраняет запись журнала и присваивает ей ID
+func (r *Repository) Create(ctx context.Context, event *domain.PlateLookupEvent) error {
+	query, args, err := psqlbuilder.Insert("plate_lookup_audit").
+		Columns("actor_id", "company_id", "plate", "result_count", "created_at").
+		Values(event.ActorID, event.CompanyID, event.Plate, event.ResultCount, event.CreatedAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&event.ID); err != nil {
+		return fmt.Errorf("%w: %v", ErrCreateEvent, err)
+	}
+
+	return nil
+}
+
+// List возвращает записи журнала по фильтру, новые первыми
+func (r *Repository) List(ctx context.Context, filter platelookup.AuditFilter) ([]*domain.PlateLookupEvent, error) {
+	builder := psqlbuilder.Select(eventColumns...).
+		From("plate_lookup_audit").
+		OrderBy("created_at DESC", "id DESC").
+		Limit(filter.Limit)
+	if filter.ActorID != 0 {
+		builder = builder.Where(squirrel.Eq{"actor_id": filter.ActorID})
+	}
+	if filter.CompanyID != 0 {
+		builder = builder.Where(squirrel.Eq{"company_id": filter.CompanyID})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var events []*domain.PlateLookupEvent
+	if err := r.db.SelectContext(ctx, &events, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetEvents, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,138 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+	"github.com/m04kA/SMC-UserService/pkg/ratelimit"
+)
+
+var (
+	ErrTakeToken     = errors.New("failed to take rate limit token in database")
+	ErrDeleteExpired = errors.New("failed to delete expired rate limit buckets")
+	ErrBuildQuery    = errors.New("failed to build SQL query")
+)
+
+// sweepInterval период удаления заполнившихся корзин
+const sweepInterval = time.Minute
+
+// Repository хранит корзины токенов в PostgreSQL, чтобы лимиты были общими для всех инстансов.
+// Время берется из БД, поэтому расхождение часов инстансов не влияет на пополнение.
+type Repository struct {
+	db *sqlx.DB
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db:        executor,
+		lastSweep: time.Now(),
+	}
+}
+
+type bucketRow struct {
+	Tokens    float64   `db:"tokens"`
+	UpdatedAt time.Time `db:"updated_at"`
+	Now       time.Time `db:"now"`
+}
+
+// Take пытается взять токен из корзины key в одной транзакции (корзина блокируется FOR UPDATE)
+func (r *Repository) Take(ctx context.Context, key string, limit ratelimit.Limit) (result ratelimit.Result, err error) {
+	r.sweep(ctx)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return result, fmt.Errorf("%w: failed to begin transaction: %v", ErrTakeToken, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	insertQuery, insertArgs, err := psqlbuilder.Insert("rate_limit_buckets").
+		Columns("key", "tokens", "updated_at", "expires_at").
+		Values(key, limit.Burst, squirrel.Expr("NOW()"), squirrel.Expr("NOW()")).
+		Suffix("ON CONFLICT (key) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return result, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, insertQuery, insertArgs...); err != nil {
+		return result, fmt.Errorf("%w: %v", ErrTakeToken, err)
+	}
+
+	selectQuery, selectArgs, err := psqlbuilder.Select("tokens", "updated_at", "NOW() AS now").
+		From("rate_limit_buckets").
+		Where(squirrel.Eq{"key": key}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return result, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var row bucketRow
+	if err = tx.GetContext(ctx, &row, selectQuery, selectArgs...); err != nil {
+		return result, fmt.Errorf("%w: %v", ErrTakeToken, err)
+	}
+
+	tokens, result := ratelimit.Take(limit, row.Tokens, row.Now.Sub(row.UpdatedAt))
+
+	updateQuery, updateArgs, err := psqlbuilder.Update("rate_limit_buckets").
+		Set("tokens", tokens).
+		Set("updated_at", row.Now).
+		Set("expires_at", row.Now.Add(limit.FullAfter())).
+		Where(squirrel.Eq{"key": key}).
+		ToSql()
+	if err != nil {
+		return result, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, updateQuery, updateArgs...); err != nil {
+		return result, fmt.Errorf("%w: %v", ErrTakeToken, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result, fmt.Errorf("%w: failed to commit transaction: %v", ErrTakeToken, err)
+	}
+
+	return result, nil
+}
+
+// DeleteExpired удаляет корзины, которые успели заполниться полностью
+func (r *Repository) DeleteExpired(ctx context.Context) error {
+	query, args, err := psqlbuilder.Delete("rate_limit_buckets").
+		Where("expires_at < NOW()").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrDeleteExpired, err)
+	}
+
+	return nil
+}
+
+// sweep не чаще раза в sweepInterval удаляет заполнившиеся корзины; ошибка очистки не влияет на запрос
+func (r *Repository) sweep(ctx context.Context) {
+	r.mu.Lock()
+	if time.Since(r.lastSweep) < sweepInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastSweep = time.Now()
+	r.mu.Unlock()
+
+	_ = r.DeleteExpired(ctx)
+}
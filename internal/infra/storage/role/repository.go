@@ -0,0 +1,219 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrGetRoles       = errors.New("failed to get roles from database")
+	ErrCreateRole     = errors.New("failed to create role in database")
+	ErrSetPermissions = errors.New("failed to set role permissions in database")
+	ErrBuildQuery     = errors.New("failed to build SQL query")
+)
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db: executor,
+	}
+}
+
+// rolePermissionRow строка связи роли и права
+type rolePermissionRow struct {
+	RoleID int               `db:"role_id"`
+	Code   domain.Permission `db:"code"`
+}
+
+// ListRoles возвращает все роли вместе с их правами
+func (r *Repository) ListRoles(ctx context.Context) ([]domain.RoleDefinition, error) {
+	rolesQuery, rolesArgs, err := psqlbuilder.Select("id", "name", "description").
+		From("roles").
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var roles []domain.RoleDefinition
+	if err := r.db.SelectContext(ctx, &roles, rolesQuery, rolesArgs...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetRoles, err)
+	}
+
+	permsQuery, permsArgs, err := psqlbuilder.Select("rp.role_id", "p.code").
+		From("role_permissions rp").
+		Join("permissions p ON rp.permission_id = p.id").
+		OrderBy("rp.role_id", "p.code").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var rows []rolePermissionRow
+	if err := r.db.SelectContext(ctx, &rows, permsQuery, permsArgs...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetRoles, err)
+	}
+
+	index := make(map[int]int, len(roles))
+	for i := range roles {
+		index[roles[i].ID] = i
+		roles[i].Permissions = []domain.Permission{}
+	}
+	for _, row := range rows {
+		if i, ok := index[row.RoleID]; ok {
+			roles[i].Permissions = append(roles[i].Permissions, row.Code)
+		}
+	}
+
+	return roles, nil
+}
+
+// ListPermissions возвращает справочник прав
+func (r *Repository) ListPermissions(ctx context.Context) ([]domain.PermissionDefinition, error) {
+	query, args, err := psqlbuilder.Select("id", "code", "description").
+		From("permissions").
+		OrderBy("code").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var permissions []domain.PermissionDefinition
+	if err := r.db.SelectContext(ctx, &permissions, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetRoles, err)
+	}
+
+	return permissions, nil
+}
+
+// CreateRole создает роль и назначает ей права в одной транзакции
+func (r *Repository) CreateRole(ctx context.Context, role *domain.RoleDefinition) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to begin transaction: %v", ErrCreateRole, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query, args, err := psqlbuilder.Insert("roles").
+		Columns("name", "description").
+		Values(role.Name, role.Description).
+		Suffix("ON CONFLICT (name) DO NOTHING RETURNING id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&role.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return rbac.ErrRoleAlreadyExists
+		}
+		return fmt.Errorf("%w: %v", ErrCreateRole, err)
+	}
+
+	if err = insertRolePermissions(ctx, tx, role.ID, role.Permissions); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%w: failed to commit transaction: %v", ErrCreateRole, err)
+	}
+
+	return nil
+}
+
+// SetRolePermissions заменяет набор прав роли в одной транзакции
+func (r *Repository) SetRolePermissions(ctx context.Context, name domain.Role, permissions []domain.Permission) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to begin transaction: %v", ErrSetPermissions, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	roleQuery, roleArgs, err := psqlbuilder.Select("id").
+		From("roles").
+		Where(squirrel.Eq{"name": name}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var roleID int
+	if err = tx.GetContext(ctx, &roleID, roleQuery, roleArgs...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return rbac.ErrRoleNotFound
+		}
+		return fmt.Errorf("%w: %v", ErrSetPermissions, err)
+	}
+
+	deleteQuery, deleteArgs, err := psqlbuilder.Delete("role_permissions").
+		Where(squirrel.Eq{"role_id": roleID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, deleteQuery, deleteArgs...); err != nil {
+		return fmt.Errorf("%w: %v", ErrSetPermissions, err)
+	}
+
+	if err = insertRolePermissions(ctx, tx, roleID, permissions); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%w: failed to commit transaction: %v", ErrSetPermissions, err)
+	}
+
+	return nil
+}
+
+// insertRolePermissions назначает роли права по их кодам
+func insertRolePermissions(ctx context.Context, tx *sqlx.Tx, roleID int, permissions []domain.Permission) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	codes := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		codes = append(codes, string(p))
+	}
+
+	selectQuery := psqlbuilder.Select(fmt.Sprintf("%d", roleID), "id").
+		From("permissions").
+		Where(squirrel.Eq{"code": codes})
+
+	query, args, err := psqlbuilder.Insert("role_permissions").
+		Columns("role_id", "permission_id").
+		Select(selectQuery).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrSetPermissions, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package servicecredential
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/serviceauth"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrCreateCredential = errors.New("failed to create service credential in database")
+	ErrGetCredential    = errors.New("failed to get service credential from database")
+	ErrRevokeCredential = errors.New("failed to revoke service credential in database")
+	ErrUpdateCredential = errors.New("failed to update service credential in database")
+	ErrBuildQuery       = errors.New("failed to build SQL query")
+)
+
+var credentialColumns = []string{"id", "service_name", "key_id", "signing_key_encrypted", "secret_hash", "created_by", "created_at", "revoked_at"}
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db: executor,
+	}
+}
+
+// Create сохраняет учетные данные сервиса и возвращает их с присвоенным ID
+func (r *Repository) Create(ctx context.Context, credential *domain.ServiceCredential) (*domain.ServiceCredential, error) {
+	query, args, err := psqlbuilder.Insert("service_credentials").
+		Columns("service_name", "key_id", "signing_key_encrypted", "created_by", "created_at").
+		Values(credential.ServiceName, credential.KeyID, credential.SigningKeyEncrypted, credential.CreatedBy, credential.CreatedAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&credential.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCreateCredential, err)
+	}
+
+	return credential, nil
+}
+
+// GetByKeyID находит учетные данные по идентификатору ключа
+func (r *Repository) GetByKeyID(ctx context.Context, keyID string) (*domain.ServiceCredential, error) {
+	query, args, err := psqlbuilder.Select(credentialColumns...).
+		From("service_credentials").
+		Where(squirrel.Eq{"key_id": keyID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var credential domain.ServiceCredential
+	err = r.db.GetContext(ctx, &credential, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceauth.ErrCredentialNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrGetCredential, err)
+	}
+
+	return &credential, nil
+}
+
+// List возвращает все учетные данные сервисов
+func (r *Repository) List(ctx context.Context) ([]*domain.ServiceCredential, error) {
+	query, args, err := psqlbuilder.Select(credentialColumns...).
+		From("service_credentials").
+		OrderBy("service_name", "id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var credentials []*domain.ServiceCredential
+	err = r.db.SelectContext(ctx, &credentials, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetCredential, err)
+	}
+
+	return credentials, nil
+}
+
+// ListLegacy возвращает ключи, ключ подписи которых еще хранится в открытом виде
+func (r *Repository) ListLegacy(ctx context.Context) ([]*domain.ServiceCredential, error) {
+	query, args, err := psqlbuilder.Select(credentialColumns...).
+		From("service_credentials").
+		Where(squirrel.Eq{"signing_key_encrypted": nil}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var credentials []*domain.ServiceCredential
+	err = r.db.SelectContext(ctx, &credentials, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetCredential, err)
+	}
+
+	return credentials, nil
+}
+
+// SetSigningKey сохраняет зашифрованный ключ подписи и удаляет открытый
+func (r *Repository) SetSigningKey(ctx context.Context, id int64, encrypted []byte) error {
+	query, args, err := psqlbuilder.Update("service_credentials").
+		Set("signing_key_encrypted", encrypted).
+		Set("secret_hash", nil).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateCredential, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrUpdateCredential, err)
+	}
+
+	if rowsAffected == 0 {
+		return serviceauth.ErrCredentialNotFound
+	}
+
+	return nil
+}
+
+// Revoke помечает учетные данные отозванными (повторный отзыв не меняет дату)
+func (r *Repository) Revoke(ctx context.Context, id int64) error {
+	query, args, err := psqlbuilder.Update("service_credentials").
+		Set("revoked_at", squirrel.Expr("COALESCE(revoked_at, NOW())")).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRevokeCredential, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrRevokeCredential, err)
+	}
+
+	if rowsAffected == 0 {
+		return serviceauth.ErrCredentialNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package servicenonce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrUseNonce      = errors.New("failed to store service request nonce in database")
+	ErrDeleteExpired = errors.New("failed to delete expired service request nonces")
+	ErrBuildQuery    = errors.New("failed to build SQL query")
+)
+
+// sweepInterval период удаления истекших nonce
+const sweepInterval = time.Minute
+
+// Repository хранит использованные nonce подписанных запросов в PostgreSQL,
+// чтобы запрос нельзя было повторить на другом инстансе. Время берется из БД.
+type Repository struct {
+	db *sqlx.DB
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db:        executor,
+		lastSweep: time.Now(),
+	}
+}
+
+// Use запоминает key на ttl одним запросом: новая запись или перезапись истекшей - nonce свободен,
+// действующая запись не меняется - повтор
+func (r *Repository) Use(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	r.sweep(ctx)
+
+	expiresAt := squirrel.Expr("NOW() + make_interval(secs => ?)", ttl.Seconds())
+	query, args, err := psqlbuilder.Insert("service_request_nonces").
+		Columns("key", "expires_at").
+		Values(key, expiresAt).
+		Suffix("ON CONFLICT (key) DO UPDATE SET expires_at = EXCLUDED.expires_at WHERE service_request_nonces.expires_at < NOW()").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrUseNonce, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: failed to get rows affected: %v", ErrUseNonce, err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// DeleteExpired удаляет nonce, окно повтора которых истекло
+func (r *Repository) DeleteExpired(ctx context.Context) error {
+	query, args, err := psqlbuilder.Delete("service_request_nonces").
+		Where("expires_at < NOW()").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrDeleteExpired, err)
+	}
+
+	return nil
+}
+
+// sweep не чаще раза в sweepInterval удаляет истекшие nonce; ошибка очистки не влияет на запрос
+func (r *Repository) sweep(ctx context.Context) {
+	r.mu.Lock()
+	if time.Since(r.lastSweep) < sweepInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastSweep = time.Now()
+	r.mu.Unlock()
+
+	_ = r.DeleteExpired(ctx)
+}
@@ -5,23 +5,54 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/m04kA/SMC-UserService/internal/domain"
 	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/pkg/dbtx"
 	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
 )
 
 var (
-	ErrCreateUser      = errors.New("failed to create user in database")
-	ErrGetUser         = errors.New("failed to get user from database")
-	ErrUpdateUser      = errors.New("failed to update user in database")
-	ErrDeleteUser      = errors.New("failed to delete user from database")
-	ErrGetSuperUsers   = errors.New("failed to get super users from database")
-	ErrBuildQuery      = errors.New("failed to build SQL query")
+	ErrCreateUser    = errors.New("failed to create user in database")
+	ErrGetUser       = errors.New("failed to get user from database")
+	ErrUpdateUser    = errors.New("failed to update user in database")
+	ErrDeleteUser    = errors.New("failed to delete user from database")
+	ErrRestoreUser   = errors.New("failed to restore user in database")
+	ErrPurgeUsers    = errors.New("failed to purge deleted users from database")
+	ErrGetSuperUsers = errors.New("failed to get super users from database")
+	ErrChangeRole    = errors.New("failed to change user role in database")
+	ErrAddConsents   = errors.New("failed to add user consents in database")
+	ErrLockPhone     = errors.New("failed to lock phone number in database")
+	ErrBuildQuery    = errors.New("failed to build SQL query")
 )
 
+var userColumns = []string{
+	"u.tg_user_id",
+	"u.name",
+	"u.phone_number",
+	"u.phone_verified_at",
+	"u.phone_duplicate",
+	"u.tg_link",
+	"u.language_code",
+	"u.role_id",
+	"r.name as role_name",
+	"u.status",
+	"u.suspended_until",
+	"u.status_reason",
+	"u.status_changed_by",
+	"u.status_changed_at",
+	"u.created_at",
+	"u.deleted_at",
+}
+
+// likeEscaper экранирует спецсимволы шаблона LIKE
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type Repository struct {
 	db *sqlx.DB
 }
@@ -32,17 +63,22 @@ func NewRepository(executor *sqlx.DB) *Repository {
 	}
 }
 
+// conn возвращает транзакцию из ctx (dbtx.Manager.WithTx) или соединение с БД
+func (r *Repository) conn(ctx context.Context) dbtx.Executor {
+	return dbtx.From(ctx, r.db)
+}
+
 // Create сохраняет нового пользователя в базу данных
 func (r *Repository) Create(ctx context.Context, user *domain.User) error {
 	query, args, err := psqlbuilder.Insert("users").
-		Columns("tg_user_id", "name", "phone_number", "tg_link", "role_id", "created_at").
-		Values(user.TGUserID, user.Name, user.PhoneNumber, user.TGLink, user.RoleID, user.CreatedAt).
+		Columns("tg_user_id", "name", "phone_number", "phone_duplicate", "tg_link", "language_code", "role_id", "created_at").
+		Values(user.TGUserID, user.Name, user.PhoneNumber, user.PhoneDuplicate, user.TGLink, user.LanguageCode, user.RoleID, user.CreatedAt).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
-	_, err = r.db.ExecContext(ctx, query, args...)
+	_, err = r.conn(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrCreateUser, err)
 	}
@@ -50,27 +86,43 @@ func (r *Repository) Create(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// AddConsents сохраняет согласия пользователя, принятые в момент grantedAt
+func (r *Repository) AddConsents(ctx context.Context, tgID int64, consents []domain.ConsentType, grantedAt time.Time) error {
+	if len(consents) == 0 {
+		return nil
+	}
+
+	builder := psqlbuilder.Insert("user_consents").
+		Columns("tg_user_id", "consent_type", "granted_at")
+	for _, consent := range consents {
+		builder = builder.Values(tgID, consent, grantedAt)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err = r.conn(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrAddConsents, err)
+	}
+
+	return nil
+}
+
 // GetByTGID находит пользователя по Telegram ID
 func (r *Repository) GetByTGID(ctx context.Context, tgID int64) (*domain.User, error) {
-	query, args, err := psqlbuilder.Select(
-		"u.tg_user_id",
-		"u.name",
-		"u.phone_number",
-		"u.tg_link",
-		"u.role_id",
-		"r.name as role_name",
-		"u.created_at",
-	).
+	query, args, err := psqlbuilder.Select(userColumns...).
 		From("users u").
 		LeftJoin("roles r ON u.role_id = r.id").
-		Where(squirrel.Eq{"u.tg_user_id": tgID}).
+		Where(squirrel.Eq{"u.tg_user_id": tgID, "u.deleted_at": nil}).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
 	var user domain.User
-	err = r.db.GetContext(ctx, &user, query, args...)
+	err = r.conn(ctx).GetContext(ctx, &user, query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, userservice.ErrUserNotFound
@@ -81,19 +133,170 @@ func (r *Repository) GetByTGID(ctx context.Context, tgID int64) (*domain.User, e
 	return &user, nil
 }
 
+// GetByTGIDs находит пользователей по списку Telegram ID одним запросом
+func (r *Repository) GetByTGIDs(ctx context.Context, tgIDs []int64) ([]*domain.User, error) {
+	query, args, err := psqlbuilder.Select(userColumns...).
+		From("users u").
+		LeftJoin("roles r ON u.role_id = r.id").
+		Where("u.tg_user_id = ANY(?)", pq.Array(tgIDs)).
+		Where(squirrel.Eq{"u.deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var users []*domain.User
+	if err := r.conn(ctx).SelectContext(ctx, &users, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetUser, err)
+	}
+
+	return users, nil
+}
+
+// GetByPhoneNumber находит пользователей с номером телефона в формате E.164; подтвердившие номер идут первыми
+func (r *Repository) GetByPhoneNumber(ctx context.Context, phoneNumber string) ([]*domain.User, error) {
+	query, args, err := psqlbuilder.Select(userColumns...).
+		From("users u").
+		LeftJoin("roles r ON u.role_id = r.id").
+		Where(squirrel.Eq{"u.phone_number": phoneNumber, "u.deleted_at": nil}).
+		OrderBy("u.phone_verified_at DESC NULLS LAST", "u.tg_user_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var users []*domain.User
+	if err := r.conn(ctx).SelectContext(ctx, &users, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetUser, err)
+	}
+
+	return users, nil
+}
+
+// GetPhoneOwners возвращает tg_user_id всех владельцев номера, включая удаленных, но еще не очищенных:
+// удаленный аккаунт может быть восстановлен вместе с номером
+func (r *Repository) GetPhoneOwners(ctx context.Context, phoneNumber string) ([]int64, error) {
+	query, args, err := psqlbuilder.Select("tg_user_id").
+		From("users").
+		Where(squirrel.Eq{"phone_number": phoneNumber}).
+		OrderBy("tg_user_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var userIDs []int64
+	if err := r.conn(ctx).SelectContext(ctx, &userIDs, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetUser, err)
+	}
+
+	return userIDs, nil
+}
+
+// LockPhoneNumber блокирует номер в формате E.164 до конца транзакции (advisory lock), чтобы два пользователя
+// не сохранили один номер параллельно. Вызывается только внутри транзакции (dbtx.Manager.WithTx).
+func (r *Repository) LockPhoneNumber(ctx context.Context, phoneNumber string) error {
+	_, err := r.conn(ctx).ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "phone_number:"+phoneNumber)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrLockPhone, err)
+	}
+	return nil
+}
+
+// List возвращает пользователей по фильтру, упорядоченных по полю сортировки и tg_user_id
+func (r *Repository) List(ctx context.Context, filter userservice.UserListFilter) ([]*domain.User, error) {
+	builder := psqlbuilder.Select(userColumns...).
+		From("users u").
+		LeftJoin("roles r ON u.role_id = r.id").
+		Where(squirrel.Eq{"u.deleted_at": nil})
+
+	if filter.Role != nil {
+		builder = builder.Where(squirrel.Eq{"r.name": *filter.Role})
+	}
+	if filter.CreatedFrom != nil {
+		builder = builder.Where(squirrel.GtOrEq{"u.created_at": *filter.CreatedFrom})
+	}
+	if filter.CreatedTo != nil {
+		builder = builder.Where(squirrel.LtOrEq{"u.created_at": *filter.CreatedTo})
+	}
+	if filter.HasCar != nil {
+		hasCar := "EXISTS (SELECT 1 FROM cars c WHERE c.user_id = u.tg_user_id)"
+		if !*filter.HasCar {
+			hasCar = "NOT " + hasCar
+		}
+		builder = builder.Where(hasCar)
+	}
+	if filter.Search != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Search) + "%"
+		builder = builder.Where(squirrel.Or{
+			squirrel.ILike{"u.name": pattern},
+			squirrel.ILike{"u.phone_number": pattern},
+			squirrel.ILike{"u.tg_link": pattern},
+		})
+	}
+
+	column := "u.created_at"
+	var cursorValue interface{}
+	if filter.After != nil {
+		cursorValue = filter.After.CreatedAt
+	}
+	switch filter.SortField {
+	case userservice.UserSortName:
+		column = "u.name"
+		if filter.After != nil {
+			cursorValue = filter.After.Name
+		}
+	case userservice.UserSortTGUserID:
+		column = ""
+	}
+
+	direction, op := "ASC", ">"
+	if filter.SortDesc {
+		direction, op = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		if column == "" {
+			builder = builder.Where("u.tg_user_id "+op+" ?", filter.After.TGUserID)
+		} else {
+			builder = builder.Where(fmt.Sprintf("(%s, u.tg_user_id) %s (?, ?)", column, op), cursorValue, filter.After.TGUserID)
+		}
+	}
+
+	if column != "" {
+		builder = builder.OrderBy(column + " " + direction)
+	}
+	builder = builder.OrderBy("u.tg_user_id " + direction).Limit(filter.Limit)
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var users []*domain.User
+	if err := r.conn(ctx).SelectContext(ctx, &users, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetUser, err)
+	}
+
+	return users, nil
+}
+
 // Update обновляет данные пользователя
 func (r *Repository) Update(ctx context.Context, user *domain.User) error {
 	query, args, err := psqlbuilder.Update("users").
 		Set("name", user.Name).
 		Set("phone_number", user.PhoneNumber).
+		Set("phone_verified_at", user.PhoneVerifiedAt).
+		Set("phone_duplicate", user.PhoneDuplicate).
 		Set("tg_link", user.TGLink).
-		Where(squirrel.Eq{"tg_user_id": user.TGUserID}).
+		Set("language_code", user.LanguageCode).
+		Where(squirrel.Eq{"tg_user_id": user.TGUserID, "deleted_at": nil}).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
+	result, err := r.conn(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrUpdateUser, err)
 	}
@@ -110,16 +313,48 @@ func (r *Repository) Update(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
-// Delete удаляет пользователя
-func (r *Repository) Delete(ctx context.Context, tgID int64) error {
-	query, args, err := psqlbuilder.Delete("users").
-		Where(squirrel.Eq{"tg_user_id": tgID}).
+// UpdateStatus обновляет статус аккаунта пользователя
+func (r *Repository) UpdateStatus(ctx context.Context, user *domain.User) error {
+	query, args, err := psqlbuilder.Update("users").
+		Set("status", user.Status).
+		Set("suspended_until", user.SuspendedUntil).
+		Set("status_reason", user.StatusReason).
+		Set("status_changed_by", user.StatusChangedBy).
+		Set("status_changed_at", user.StatusChangedAt).
+		Where(squirrel.Eq{"tg_user_id": user.TGUserID, "deleted_at": nil}).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
+	result, err := r.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateUser, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrUpdateUser, err)
+	}
+
+	if rowsAffected == 0 {
+		return userservice.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// SoftDelete помечает пользователя удаленным; строка и автомобили сохраняются до очистки
+func (r *Repository) SoftDelete(ctx context.Context, tgID int64, deletedAt time.Time) error {
+	query, args, err := psqlbuilder.Update("users").
+		Set("deleted_at", deletedAt).
+		Where(squirrel.Eq{"tg_user_id": tgID, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrDeleteUser, err)
 	}
@@ -136,21 +371,175 @@ func (r *Repository) Delete(ctx context.Context, tgID int64) error {
 	return nil
 }
 
+// GetDeletedByTGID находит удаленного, но еще не очищенного пользователя по Telegram ID
+func (r *Repository) GetDeletedByTGID(ctx context.Context, tgID int64) (*domain.User, error) {
+	query, args, err := psqlbuilder.Select(userColumns...).
+		From("users u").
+		LeftJoin("roles r ON u.role_id = r.id").
+		Where(squirrel.Eq{"u.tg_user_id": tgID}).
+		Where(squirrel.NotEq{"u.deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var user domain.User
+	err = r.conn(ctx).GetContext(ctx, &user, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, userservice.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrGetUser, err)
+	}
+
+	return &user, nil
+}
+
+// Restore снимает пометку удаления, если пользователь удален не раньше deletedAfter
+func (r *Repository) Restore(ctx context.Context, tgID int64, deletedAfter time.Time) error {
+	query, args, err := psqlbuilder.Update("users").
+		Set("deleted_at", nil).
+		Where(squirrel.Eq{"tg_user_id": tgID}).
+		Where(squirrel.Gt{"deleted_at": deletedAfter}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRestoreUser, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrRestoreUser, err)
+	}
+
+	if rowsAffected == 0 {
+		return userservice.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// PurgeDeleted окончательно удаляет пользователей, удаленных не позже deletedBefore.
+// Автомобили и история ролей удаляются каскадно.
+func (r *Repository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query, args, err := psqlbuilder.Delete("users").
+		Where(squirrel.LtOrEq{"deleted_at": deletedBefore}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrPurgeUsers, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to get rows affected: %v", ErrPurgeUsers, err)
+	}
+
+	return rowsAffected, nil
+}
+
+// LockSuperUsers блокирует строки активных суперпользователей до конца транзакции и возвращает их tg_user_id.
+// Вызывается внутри TxManager.WithTx перед снятием роли или удалением суперпользователя.
+func (r *Repository) LockSuperUsers(ctx context.Context) ([]int64, error) {
+	query, args, err := psqlbuilder.Select("tg_user_id").
+		From("users").
+		Where(squirrel.Eq{"role_id": domain.RoleIDSuperUser, "deleted_at": nil}).
+		OrderBy("tg_user_id").
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var userIDs []int64
+	err = r.conn(ctx).SelectContext(ctx, &userIDs, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetSuperUsers, err)
+	}
+
+	return userIDs, nil
+}
+
 // GetSuperUsers возвращает список tg_user_id всех суперпользователей
 func (r *Repository) GetSuperUsers(ctx context.Context) ([]int64, error) {
 	query, args, err := psqlbuilder.Select("u.tg_user_id").
 		From("users u").
-		Where(squirrel.Eq{"u.role_id": domain.RoleIDSuperUser}).
+		Where(squirrel.Eq{"u.role_id": domain.RoleIDSuperUser, "u.deleted_at": nil}).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
 	var userIDs []int64
-	err = r.db.SelectContext(ctx, &userIDs, query, args...)
+	err = r.conn(ctx).SelectContext(ctx, &userIDs, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrGetSuperUsers, err)
 	}
 
 	return userIDs, nil
 }
+
+// ChangeRole меняет роль пользователя и записывает изменение в историю.
+// Вызывается внутри TxManager.WithTx: суперпользователи блокируются до конца транзакции,
+// чтобы параллельные запросы не разжаловали последнего из них.
+func (r *Repository) ChangeRole(ctx context.Context, change *domain.RoleChange) error {
+	superUserIDs, err := r.LockSuperUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrChangeRole, err)
+	}
+
+	userQuery, userArgs, err := psqlbuilder.Select("role_id").
+		From("users").
+		Where(squirrel.Eq{"tg_user_id": change.TGUserID, "deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if err = r.conn(ctx).GetContext(ctx, &change.OldRoleID, userQuery, userArgs...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return userservice.ErrUserNotFound
+		}
+		return fmt.Errorf("%w: %v", ErrChangeRole, err)
+	}
+
+	if change.OldRoleID == domain.RoleIDSuperUser && change.NewRoleID != domain.RoleIDSuperUser && len(superUserIDs) <= 1 {
+		return userservice.ErrLastSuperUser
+	}
+
+	updateQuery, updateArgs, err := psqlbuilder.Update("users").
+		Set("role_id", change.NewRoleID).
+		Where(squirrel.Eq{"tg_user_id": change.TGUserID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err = r.conn(ctx).ExecContext(ctx, updateQuery, updateArgs...); err != nil {
+		return fmt.Errorf("%w: %v", ErrChangeRole, err)
+	}
+
+	historyQuery, historyArgs, err := psqlbuilder.Insert("role_changes").
+		Columns("tg_user_id", "old_role_id", "new_role_id", "changed_by", "reason", "changed_at").
+		Values(change.TGUserID, change.OldRoleID, change.NewRoleID, change.ChangedBy, change.Reason, change.ChangedAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if err = r.conn(ctx).GetContext(ctx, &change.ID, historyQuery, historyArgs...); err != nil {
+		return fmt.Errorf("%w: %v", ErrChangeRole, err)
+	}
+
+	return nil
+}
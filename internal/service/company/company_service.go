@@ -0,0 +1,162 @@
+package company
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/company/models"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+var (
+	ErrServiceCreateCompany = errors.New("service: failed to create company")
+	ErrServiceGetCompany    = errors.New("service: failed to get company")
+	ErrServiceUpdateMember  = errors.New("service: failed to update company member")
+	ErrServiceGetMember     = errors.New("service: failed to get company members")
+)
+
+type Service struct {
+	repo  CompanyRepository
+	users UserProvider
+}
+
+func NewService(repo CompanyRepository, users UserProvider) *Service {
+	return &Service{
+		repo:  repo,
+		users: users,
+	}
+}
+
+// CreateCompany создает компанию
+func (s *Service) CreateCompany(ctx context.Context, input models.CreateCompanyInputDTO, actorID int64) (*models.CompanyDTO, error) {
+	company := &domain.Company{
+		Name:      strings.TrimSpace(input.Name),
+		CreatedBy: &actorID,
+		CreatedAt: time.Now(),
+	}
+	if err := validator.Struct(company); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, company)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceCreateCompany, err)
+	}
+
+	response := toCompanyDTO(created)
+	return &response, nil
+}
+
+// GetCompany возвращает компанию по ID
+func (s *Service) GetCompany(ctx context.Context, id int64) (*models.CompanyDTO, error) {
+	company, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrCompanyNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCompany, err)
+	}
+
+	response := toCompanyDTO(company)
+	return &response, nil
+}
+
+// ListCompanies возвращает все компании
+func (s *Service) ListCompanies(ctx context.Context) (*models.CompaniesDTO, error) {
+	companies, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCompany, err)
+	}
+
+	response := &models.CompaniesDTO{Companies: make([]models.CompanyDTO, 0, len(companies))}
+	for _, c := range companies {
+		response.Companies = append(response.Companies, toCompanyDTO(c))
+	}
+	return response, nil
+}
+
+// SetMember назначает менеджера в компанию с ролью owner или staff.
+// Повторное назначение меняет роль. Назначить можно только пользователя с ролью manager.
+func (s *Service) SetMember(ctx context.Context, companyID, tgID int64, input models.SetMemberInputDTO) (*models.MembershipDTO, error) {
+	if !input.Role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	user, err := s.users.GetUserByID(ctx, tgID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceUpdateMember, err)
+	}
+	if user.Role != domain.RoleManager {
+		return nil, ErrUserNotManager
+	}
+
+	member, err := s.repo.SetMember(ctx, &domain.CompanyMember{
+		CompanyID: companyID,
+		TGUserID:  tgID,
+		Role:      input.Role,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, ErrCompanyNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceUpdateMember, err)
+	}
+
+	response := toMembershipDTO(member)
+	return &response, nil
+}
+
+// RemoveMember исключает менеджера из компании
+func (s *Service) RemoveMember(ctx context.Context, companyID, tgID int64) error {
+	if err := s.repo.RemoveMember(ctx, companyID, tgID); err != nil {
+		if errors.Is(err, ErrMemberNotFound) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrServiceUpdateMember, err)
+	}
+	return nil
+}
+
+// GetUserCompanies возвращает компании пользователя с его ролью в каждой из них.
+// Членство не зависит от глобальной роли: после снятия роли manager оно сохраняется,
+// поэтому права менеджера проверяются по обоим полям.
+func (s *Service) GetUserCompanies(ctx context.Context, tgID int64) (*models.MembershipsDTO, error) {
+	members, err := s.repo.GetMembershipsByUserID(ctx, tgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetMember, err)
+	}
+
+	response := &models.MembershipsDTO{Companies: make([]models.MembershipDTO, 0, len(members))}
+	for _, m := range members {
+		response.Companies = append(response.Companies, toMembershipDTO(m))
+	}
+	return response, nil
+}
+
+func toCompanyDTO(c *domain.Company) models.CompanyDTO {
+	return models.CompanyDTO{
+		ID:        c.ID,
+		Name:      c.Name,
+		CreatedBy: c.CreatedBy,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+func toMembershipDTO(m *domain.CompanyMember) models.MembershipDTO {
+	return models.MembershipDTO{
+		CompanyID:   m.CompanyID,
+		CompanyName: m.CompanyName,
+		TGUserID:    m.TGUserID,
+		Role:        m.Role,
+		CreatedAt:   m.CreatedAt,
+	}
+}
@@ -0,0 +1,33 @@
+package company
+
+import (
+	"context"
+	"errors"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	usermodels "github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+var (
+	ErrCompanyNotFound = errors.New("company not found")
+	ErrMemberNotFound  = errors.New("user is not a member of the company")
+	ErrInvalidRole     = errors.New("invalid company role")
+	ErrUserNotManager  = errors.New("only users with the manager role can be assigned to a company")
+)
+
+// UserProvider предоставляет профиль пользователя.
+type UserProvider interface {
+	GetUserByID(ctx context.Context, tgID int64) (*usermodels.UserDTO, error)
+}
+
+// CompanyRepository определяет контракт для работы с хранилищем компаний и их менеджеров.
+type CompanyRepository interface {
+	Create(ctx context.Context, company *domain.Company) (*domain.Company, error)
+	GetByID(ctx context.Context, id int64) (*domain.Company, error)
+	List(ctx context.Context) ([]*domain.Company, error)
+	// SetMember добавляет менеджера в компанию или меняет его роль; ErrCompanyNotFound, если компании нет
+	SetMember(ctx context.Context, member *domain.CompanyMember) (*domain.CompanyMember, error)
+	RemoveMember(ctx context.Context, companyID, tgID int64) error
+	// GetMembershipsByUserID возвращает членства пользователя с названиями компаний
+	GetMembershipsByUserID(ctx context.Context, tgID int64) ([]*domain.CompanyMember, error)
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+)
+
+type CreateCompanyInputDTO struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+type CompanyDTO struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedBy *int64    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CompaniesDTO struct {
+	Companies []CompanyDTO `json:"companies"`
+}
+
+type SetMemberInputDTO struct {
+	Role domain.CompanyRole `json:"role" validate:"required,oneof=owner staff"`
+}
+
+// MembershipDTO членство менеджера в компании
+type MembershipDTO struct {
+	CompanyID   int64              `json:"company_id"`
+	CompanyName string             `json:"company_name"`
+	TGUserID    int64              `json:"tg_user_id"`
+	Role        domain.CompanyRole `json:"role"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+type MembershipsDTO struct {
+	Companies []MembershipDTO `json:"companies"`
+}
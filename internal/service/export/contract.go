@@ -0,0 +1,36 @@
+package export
+
+import (
+	"context"
+	"errors"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	usermodels "github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported export format")
+)
+
+// UserProvider предоставляет профиль пользователя с автомобилями.
+type UserProvider interface {
+	GetUserWithCars(ctx context.Context, tgID int64) (*usermodels.UserWithCarsDTO, error)
+}
+
+// HistoryRepository построчно читает историю, связанную с пользователем, не загружая ее в память целиком.
+// Обход прекращается при первой ошибке fn.
+type HistoryRepository interface {
+	StreamRoleChanges(ctx context.Context, tgID int64, fn func(RoleChangeRecord) error) error
+	StreamImpersonationEvents(ctx context.Context, tgID int64, fn func(domain.ImpersonationEvent) error) error
+	StreamConsents(ctx context.Context, tgID int64, fn func(domain.UserConsent) error) error
+	StreamCompanyMemberships(ctx context.Context, tgID int64, fn func(domain.CompanyMember) error) error
+	StreamInvitations(ctx context.Context, tgID int64, fn func(domain.Invitation) error) error
+	StreamPlateClaims(ctx context.Context, tgID int64, fn func(domain.PlateClaim) error) error
+}
+
+// RoleChangeRecord запись истории ролей с названиями ролей
+type RoleChangeRecord struct {
+	domain.RoleChange
+	OldRole domain.Role `db:"old_role_name"`
+	NewRole domain.Role `db:"new_role_name"`
+}
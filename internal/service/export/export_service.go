@@ -0,0 +1,430 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/export/models"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	usermodels "github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+var (
+	ErrServiceGetProfile = errors.New("service: failed to get user profile for export")
+	ErrServiceWrite      = errors.New("service: failed to write export")
+)
+
+type Service struct {
+	users UserProvider
+	repo  HistoryRepository
+}
+
+func NewService(users UserProvider, repo HistoryRepository) *Service {
+	return &Service{users: users, repo: repo}
+}
+
+// GetProfile возвращает профиль для выгрузки. Вызывается до записи ответа,
+// чтобы отсутствие пользователя можно было вернуть кодом 404.
+func (s *Service) GetProfile(ctx context.Context, tgID int64) (*usermodels.UserWithCarsDTO, error) {
+	profile, err := s.users.GetUserWithCars(ctx, tgID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetProfile, err)
+	}
+	return profile, nil
+}
+
+// Write записывает выгрузку профиля и связанной с ним истории в w.
+// История читается из БД построчно, поэтому объем выгрузки не ограничен памятью.
+func (s *Service) Write(ctx context.Context, w io.Writer, format models.Format, profile *usermodels.UserWithCarsDTO) error {
+	var err error
+	switch format {
+	case models.FormatJSON:
+		err = s.writeJSON(ctx, w, profile)
+	case models.FormatZip:
+		err = s.writeZip(ctx, w, profile)
+	default:
+		return ErrUnsupportedFormat
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrServiceWrite, err)
+	}
+	return nil
+}
+
+// writeJSON пишет документ вида {"exported_at", "profile", "consents", "role_changes", "company_memberships",
+// "invitations", "plate_claims", "impersonation_audit"}
+func (s *Service) writeJSON(ctx context.Context, w io.Writer, profile *usermodels.UserWithCarsDTO) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	tgID := profile.TGUserID
+
+	// Ошибки записи в bufio.Writer сохраняются и возвращаются из Encode и Flush
+	bw.WriteString(`{"exported_at":`)
+	if err := enc.Encode(time.Now().UTC()); err != nil {
+		return err
+	}
+	bw.WriteString(`,"profile":`)
+	if err := enc.Encode(profile); err != nil {
+		return err
+	}
+
+	sections := []struct {
+		name   string
+		stream func(emit func(v any) error) error
+	}{
+		{"consents", func(emit func(v any) error) error {
+			return s.repo.StreamConsents(ctx, tgID, func(consent domain.UserConsent) error {
+				return emit(toConsentDTO(consent))
+			})
+		}},
+		{"role_changes", func(emit func(v any) error) error {
+			return s.repo.StreamRoleChanges(ctx, tgID, func(record RoleChangeRecord) error {
+				return emit(toRoleChangeDTO(record))
+			})
+		}},
+		{"company_memberships", func(emit func(v any) error) error {
+			return s.repo.StreamCompanyMemberships(ctx, tgID, func(member domain.CompanyMember) error {
+				return emit(toCompanyMembershipDTO(member))
+			})
+		}},
+		{"invitations", func(emit func(v any) error) error {
+			return s.repo.StreamInvitations(ctx, tgID, func(invitation domain.Invitation) error {
+				return emit(toInvitationDTO(invitation))
+			})
+		}},
+		{"plate_claims", func(emit func(v any) error) error {
+			return s.repo.StreamPlateClaims(ctx, tgID, func(claim domain.PlateClaim) error {
+				return emit(toPlateClaimDTO(claim))
+			})
+		}},
+		{"impersonation_audit", func(emit func(v any) error) error {
+			return s.repo.StreamImpersonationEvents(ctx, tgID, func(event domain.ImpersonationEvent) error {
+				return emit(toImpersonationEventDTO(event))
+			})
+		}},
+	}
+
+	for _, section := range sections {
+		bw.WriteString(`,"` + section.name + `":[`)
+		first := true
+		err := section.stream(func(v any) error {
+			if !first {
+				bw.WriteString(",")
+			}
+			first = false
+			return enc.Encode(v)
+		})
+		if err != nil {
+			return err
+		}
+		bw.WriteString("]")
+	}
+
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// writeZip пишет ZIP архив с CSV файлом на каждую таблицу
+func (s *Service) writeZip(ctx context.Context, w io.Writer, profile *usermodels.UserWithCarsDTO) error {
+	zw := zip.NewWriter(w)
+
+	err := writeCSV(zw, "profile.csv", func(cw *csv.Writer) error {
+		if err := cw.Write([]string{"tg_user_id", "name", "phone_number", "phone_verified_at", "tg_link", "language_code", "role",
+			"status", "suspended_until", "status_reason", "created_at"}); err != nil {
+			return err
+		}
+		return cw.Write([]string{
+			strconv.FormatInt(profile.TGUserID, 10),
+			profile.Name,
+			optionalString(profile.PhoneNumber),
+			optionalTime(profile.PhoneVerifiedAt),
+			optionalString(profile.TGLink),
+			optionalString(profile.LanguageCode),
+			string(profile.Role),
+			string(profile.Status),
+			optionalTime(profile.SuspendedUntil),
+			optionalString(profile.StatusReason),
+			formatTime(profile.CreatedAt),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeCSV(zw, "cars.csv", func(cw *csv.Writer) error {
+		if err := cw.Write([]string{"id", "brand", "model", "license_plate", "color", "size", "is_selected"}); err != nil {
+			return err
+		}
+		for _, car := range profile.Cars {
+			if err := cw.Write([]string{
+				strconv.FormatInt(car.ID, 10),
+				car.Brand,
+				car.Model,
+				car.LicensePlate,
+				optionalString(car.Color),
+				optionalString(car.Size),
+				strconv.FormatBool(car.IsSelected),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeCSV(zw, "consents.csv", func(cw *csv.Writer) error {
+		if err := cw.Write([]string{"consent_type", "granted_at", "revoked_at"}); err != nil {
+			return err
+		}
+		return s.repo.StreamConsents(ctx, profile.TGUserID, func(consent domain.UserConsent) error {
+			return cw.Write([]string{
+				string(consent.Type),
+				formatTime(consent.GrantedAt),
+				optionalTime(consent.RevokedAt),
+			})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeCSV(zw, "role_changes.csv", func(cw *csv.Writer) error {
+		if err := cw.Write([]string{"id", "old_role", "new_role", "changed_by", "reason", "changed_at"}); err != nil {
+			return err
+		}
+		return s.repo.StreamRoleChanges(ctx, profile.TGUserID, func(record RoleChangeRecord) error {
+			return cw.Write([]string{
+				strconv.FormatInt(record.ID, 10),
+				string(record.OldRole),
+				string(record.NewRole),
+				strconv.FormatInt(record.ChangedBy, 10),
+				optionalString(record.Reason),
+				formatTime(record.ChangedAt),
+			})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeCSV(zw, "company_memberships.csv", func(cw *csv.Writer) error {
+		if err := cw.Write([]string{"company_id", "company_name", "role", "created_at"}); err != nil {
+			return err
+		}
+		return s.repo.StreamCompanyMemberships(ctx, profile.TGUserID, func(member domain.CompanyMember) error {
+			return cw.Write([]string{
+				strconv.FormatInt(member.CompanyID, 10),
+				member.CompanyName,
+				string(member.Role),
+				formatTime(member.CreatedAt),
+			})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeCSV(zw, "invitations.csv", func(cw *csv.Writer) error {
+		if err := cw.Write([]string{"id", "role", "company_id", "company_role", "created_by", "created_at", "expires_at",
+			"accepted_by", "accepted_at", "revoked_at"}); err != nil {
+			return err
+		}
+		return s.repo.StreamInvitations(ctx, profile.TGUserID, func(invitation domain.Invitation) error {
+			companyRole := ""
+			if invitation.CompanyRole != nil {
+				companyRole = string(*invitation.CompanyRole)
+			}
+			return cw.Write([]string{
+				strconv.FormatInt(invitation.ID, 10),
+				string(invitation.Role),
+				optionalInt(invitation.CompanyID),
+				companyRole,
+				strconv.FormatInt(invitation.CreatedBy, 10),
+				formatTime(invitation.CreatedAt),
+				formatTime(invitation.ExpiresAt),
+				optionalInt(invitation.AcceptedBy),
+				optionalTime(invitation.AcceptedAt),
+				optionalTime(invitation.RevokedAt),
+			})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeCSV(zw, "plate_claims.csv", func(cw *csv.Writer) error {
+		if err := cw.Write([]string{"id", "car_id", "claimant_id", "holder_car_id", "holder_id", "license_plate_normalized",
+			"status", "resolved_by", "comment", "created_at", "resolved_at"}); err != nil {
+			return err
+		}
+		return s.repo.StreamPlateClaims(ctx, profile.TGUserID, func(claim domain.PlateClaim) error {
+			return cw.Write([]string{
+				strconv.FormatInt(claim.ID, 10),
+				optionalInt(claim.CarID),
+				strconv.FormatInt(claim.ClaimantID, 10),
+				optionalInt(claim.HolderCarID),
+				strconv.FormatInt(claim.HolderID, 10),
+				claim.LicensePlate,
+				string(claim.Status),
+				optionalInt(claim.ResolvedBy),
+				optionalString(claim.Comment),
+				formatTime(claim.CreatedAt),
+				optionalTime(claim.ResolvedAt),
+			})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeCSV(zw, "impersonation_audit.csv", func(cw *csv.Writer) error {
+		if err := cw.Write([]string{"id", "actor_id", "target_id", "method", "path", "status_code", "created_at"}); err != nil {
+			return err
+		}
+		return s.repo.StreamImpersonationEvents(ctx, profile.TGUserID, func(event domain.ImpersonationEvent) error {
+			statusCode := ""
+			if event.StatusCode != nil {
+				statusCode = strconv.Itoa(*event.StatusCode)
+			}
+			return cw.Write([]string{
+				strconv.FormatInt(event.ID, 10),
+				strconv.FormatInt(event.ActorID, 10),
+				strconv.FormatInt(event.TargetID, 10),
+				event.Method,
+				event.Path,
+				statusCode,
+				formatTime(event.CreatedAt),
+			})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// writeCSV добавляет в архив CSV файл name, содержимое которого пишет fill
+func writeCSV(zw *zip.Writer, name string, fill func(cw *csv.Writer) error) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(f)
+	if err := fill(cw); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func toRoleChangeDTO(record RoleChangeRecord) models.RoleChangeDTO {
+	return models.RoleChangeDTO{
+		ID:        record.ID,
+		OldRole:   record.OldRole,
+		NewRole:   record.NewRole,
+		ChangedBy: record.ChangedBy,
+		Reason:    record.Reason,
+		ChangedAt: record.ChangedAt,
+	}
+}
+
+func toImpersonationEventDTO(event domain.ImpersonationEvent) models.ImpersonationEventDTO {
+	return models.ImpersonationEventDTO{
+		ID:         event.ID,
+		ActorID:    event.ActorID,
+		TargetID:   event.TargetID,
+		Method:     event.Method,
+		Path:       event.Path,
+		StatusCode: event.StatusCode,
+		CreatedAt:  event.CreatedAt,
+	}
+}
+
+func toConsentDTO(consent domain.UserConsent) models.ConsentDTO {
+	return models.ConsentDTO{
+		Type:      consent.Type,
+		GrantedAt: consent.GrantedAt,
+		RevokedAt: consent.RevokedAt,
+	}
+}
+
+func toCompanyMembershipDTO(member domain.CompanyMember) models.CompanyMembershipDTO {
+	return models.CompanyMembershipDTO{
+		CompanyID:   member.CompanyID,
+		CompanyName: member.CompanyName,
+		Role:        member.Role,
+		CreatedAt:   member.CreatedAt,
+	}
+}
+
+func toInvitationDTO(invitation domain.Invitation) models.InvitationDTO {
+	return models.InvitationDTO{
+		ID:          invitation.ID,
+		Role:        invitation.Role,
+		CompanyID:   invitation.CompanyID,
+		CompanyRole: invitation.CompanyRole,
+		CreatedBy:   invitation.CreatedBy,
+		CreatedAt:   invitation.CreatedAt,
+		ExpiresAt:   invitation.ExpiresAt,
+		AcceptedBy:  invitation.AcceptedBy,
+		AcceptedAt:  invitation.AcceptedAt,
+		RevokedAt:   invitation.RevokedAt,
+	}
+}
+
+func toPlateClaimDTO(claim domain.PlateClaim) models.PlateClaimDTO {
+	return models.PlateClaimDTO{
+		ID:           claim.ID,
+		CarID:        claim.CarID,
+		ClaimantID:   claim.ClaimantID,
+		HolderCarID:  claim.HolderCarID,
+		HolderID:     claim.HolderID,
+		LicensePlate: claim.LicensePlate,
+		Status:       claim.Status,
+		ResolvedBy:   claim.ResolvedBy,
+		Comment:      claim.Comment,
+		CreatedAt:    claim.CreatedAt,
+		ResolvedAt:   claim.ResolvedAt,
+	}
+}
+
+func optionalString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func optionalInt(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+func optionalTime(v *time.Time) string {
+	if v == nil {
+		return ""
+	}
+	return formatTime(*v)
+}
+
+func formatTime(v time.Time) string {
+	return v.UTC().Format(time.RFC3339)
+}
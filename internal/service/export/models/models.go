@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+)
+
+// Format формат выгрузки персональных данных
+type Format string
+
+const (
+	FormatJSON Format = "json" // Один JSON документ
+	FormatZip  Format = "zip"  // ZIP архив с CSV файлом на каждую таблицу
+)
+
+type RoleChangeDTO struct {
+	ID        int64       `json:"id"`
+	OldRole   domain.Role `json:"old_role"`
+	NewRole   domain.Role `json:"new_role"`
+	ChangedBy int64       `json:"changed_by"`
+	Reason    *string     `json:"reason,omitempty"`
+	ChangedAt time.Time   `json:"changed_at"`
+}
+
+// ImpersonationEventDTO запрос, выполненный пользователем или от его имени
+type ImpersonationEventDTO struct {
+	ID         int64     `json:"id"`
+	ActorID    int64     `json:"actor_id"`
+	TargetID   int64     `json:"target_id"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	StatusCode *int      `json:"status_code,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ConsentDTO согласие, принятое пользователем
+type ConsentDTO struct {
+	Type      domain.ConsentType `json:"consent_type"`
+	GrantedAt time.Time          `json:"granted_at"`
+	RevokedAt *time.Time         `json:"revoked_at,omitempty"`
+}
+
+// CompanyMembershipDTO членство пользователя в компании
+type CompanyMembershipDTO struct {
+	CompanyID   int64              `json:"company_id"`
+	CompanyName string             `json:"company_name"`
+	Role        domain.CompanyRole `json:"role"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+// InvitationDTO приглашение, выпущенное или принятое пользователем
+type InvitationDTO struct {
+	ID          int64               `json:"id"`
+	Role        domain.Role         `json:"role"`
+	CompanyID   *int64              `json:"company_id,omitempty"`
+	CompanyRole *domain.CompanyRole `json:"company_role,omitempty"`
+	CreatedBy   int64               `json:"created_by"`
+	CreatedAt   time.Time           `json:"created_at"`
+	ExpiresAt   time.Time           `json:"expires_at"`
+	AcceptedBy  *int64              `json:"accepted_by,omitempty"`
+	AcceptedAt  *time.Time          `json:"accepted_at,omitempty"`
+	RevokedAt   *time.Time          `json:"revoked_at,omitempty"`
+}
+
+// PlateClaimDTO спор о госномере, где пользователь заявитель или владелец номера
+type PlateClaimDTO struct {
+	ID           int64                   `json:"id"`
+	CarID        *int64                  `json:"car_id,omitempty"`
+	ClaimantID   int64                   `json:"claimant_id"`
+	HolderCarID  *int64                  `json:"holder_car_id,omitempty"`
+	HolderID     int64                   `json:"holder_id"`
+	LicensePlate string                  `json:"license_plate_normalized"`
+	Status       domain.PlateClaimStatus `json:"status"`
+	ResolvedBy   *int64                  `json:"resolved_by,omitempty"`
+	Comment      *string                 `json:"comment,omitempty"`
+	CreatedAt    time.Time               `json:"created_at"`
+	ResolvedAt   *time.Time              `json:"resolved_at,omitempty"`
+}
+
+// IsValid проверяет, поддерживается ли формат
+func (f Format) IsValid() bool {
+	return f == FormatJSON || f == FormatZip
+}
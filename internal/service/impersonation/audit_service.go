@@ -0,0 +1,82 @@
+package impersonation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/impersonation/models"
+)
+
+var (
+	ErrServiceRecordAudit = errors.New("service: failed to record impersonation audit")
+	ErrServiceGetAudit    = errors.New("service: failed to get impersonation audit")
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type Service struct {
+	repo AuditRepository
+}
+
+func NewService(repo AuditRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// StartImpersonation записывает запрос actorID от имени targetID до его выполнения и возвращает ID записи
+func (s *Service) StartImpersonation(ctx context.Context, actorID, targetID int64, method, path string) (int64, error) {
+	event := &domain.ImpersonationEvent{
+		ActorID:   actorID,
+		TargetID:  targetID,
+		Method:    method,
+		Path:      path,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, event); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrServiceRecordAudit, err)
+	}
+	return event.ID, nil
+}
+
+// FinishImpersonation сохраняет HTTP статус выполненного запроса
+func (s *Service) FinishImpersonation(ctx context.Context, eventID int64, statusCode int) error {
+	if err := s.repo.SetStatus(ctx, eventID, statusCode); err != nil {
+		return fmt.Errorf("%w: %v", ErrServiceRecordAudit, err)
+	}
+	return nil
+}
+
+// ListEvents возвращает записи журнала, новые первыми
+func (s *Service) ListEvents(ctx context.Context, filter AuditFilter) ([]models.AuditEventDTO, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	if filter.Limit > maxAuditLimit {
+		filter.Limit = maxAuditLimit
+	}
+
+	events, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetAudit, err)
+	}
+
+	response := make([]models.AuditEventDTO, 0, len(events))
+	for _, e := range events {
+		response = append(response, models.AuditEventDTO{
+			ID:         e.ID,
+			ActorID:    e.ActorID,
+			TargetID:   e.TargetID,
+			Method:     e.Method,
+			Path:       e.Path,
+			StatusCode: e.StatusCode,
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,21 @@
+package impersonation
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+)
+
+// AuditRepository определяет контракт для работы с журналом имперсонации.
+type AuditRepository interface {
+	Create(ctx context.Context, event *domain.ImpersonationEvent) error
+	SetStatus(ctx context.Context, id int64, statusCode int) error
+	List(ctx context.Context, filter AuditFilter) ([]*domain.ImpersonationEvent, error)
+}
+
+// AuditFilter условия выборки журнала; нулевые поля не ограничивают выборку
+type AuditFilter struct {
+	ActorID  int64
+	TargetID int64
+	Limit    uint64
+}
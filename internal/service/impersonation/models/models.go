@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type AuditEventDTO struct {
+	ID         int64     `json:"id"`
+	ActorID    int64     `json:"actor_id"`
+	TargetID   int64     `json:"target_id"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	StatusCode *int      `json:"status_code,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
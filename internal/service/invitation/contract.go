@@ -0,0 +1,66 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	companymodels "github.com/m04kA/SMC-UserService/internal/service/company/models"
+	usermodels "github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+var (
+	ErrInvitationNotFound  = errors.New("invitation not found")
+	ErrInvitationAccepted  = errors.New("invitation has already been accepted")
+	ErrInvitationRevoked   = errors.New("invitation is revoked")
+	ErrInvitationExpired   = errors.New("invitation has expired")
+	ErrInvitationForbidden = errors.New("not allowed to manage invitations for this role or company")
+	ErrInvalidCompanyGrant = errors.New("company membership requires company_id, company_role and the manager role")
+	ErrInvalidTTL          = errors.New("invitation ttl exceeds the maximum")
+)
+
+// UserManager читает профиль пользователя и меняет его роль с записью в историю role_changes.
+type UserManager interface {
+	GetUserByID(ctx context.Context, tgID int64) (*usermodels.UserDTO, error)
+	ChangeUserRole(ctx context.Context, actorID, tgID int64, input usermodels.ChangeRoleInputDTO) (*usermodels.UserDTO, error)
+}
+
+// CompanyManager предоставляет компании и назначает в них менеджеров.
+type CompanyManager interface {
+	GetCompany(ctx context.Context, id int64) (*companymodels.CompanyDTO, error)
+	GetUserCompanies(ctx context.Context, tgID int64) (*companymodels.MembershipsDTO, error)
+	SetMember(ctx context.Context, companyID, tgID int64, input companymodels.SetMemberInputDTO) (*companymodels.MembershipDTO, error)
+}
+
+// AccessPolicy предоставляет актуальный справочник ролей и прав.
+type AccessPolicy interface {
+	Authorizer(ctx context.Context) (*domain.Authorizer, error)
+}
+
+// InvitationRepository определяет контракт для работы с хранилищем приглашений.
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *domain.Invitation) (*domain.Invitation, error)
+	GetByID(ctx context.Context, id int64) (*domain.Invitation, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error)
+	List(ctx context.Context) ([]*domain.Invitation, error)
+	ListByCompanyIDs(ctx context.Context, companyIDs []int64) ([]*domain.Invitation, error)
+	// Claim атомарно отмечает действующее приглашение принятым пользователем tgID;
+	// ErrInvitationNotFound, если приглашения нет или оно уже не действует
+	Claim(ctx context.Context, tokenHash string, tgID int64, acceptedAt time.Time) (*domain.Invitation, error)
+	// Revoke отзывает непринятое приглашение (повторный отзыв не меняет дату)
+	Revoke(ctx context.Context, id int64) error
+}
+
+// TxManager выполняет fn в транзакции; при конфликте транзакций fn может быть выполнена повторно.
+type TxManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Config параметры приглашений
+type Config struct {
+	BotUsername string // Бот для ссылки https://t.me/<bot>; пусто - ссылка не формируется
+	AppName     string // Короткое имя Mini App бота; пусто - основное Mini App
+	DefaultTTL  time.Duration
+	MaxTTL      time.Duration
+}
@@ -0,0 +1,358 @@
+package invitation
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/company"
+	companymodels "github.com/m04kA/SMC-UserService/internal/service/company/models"
+	"github.com/m04kA/SMC-UserService/internal/service/invitation/models"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	usermodels "github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+var (
+	ErrServiceCreateInvitation = errors.New("service: failed to create invitation")
+	ErrServiceGetInvitation    = errors.New("service: failed to get invitation")
+	ErrServiceRevokeInvitation = errors.New("service: failed to revoke invitation")
+	ErrServiceAcceptInvitation = errors.New("service: failed to accept invitation")
+)
+
+type Service struct {
+	repo      InvitationRepository
+	users     UserManager
+	companies CompanyManager
+	policy    AccessPolicy
+	tx        TxManager
+	cfg       Config
+}
+
+func NewService(repo InvitationRepository, users UserManager, companies CompanyManager, policy AccessPolicy, tx TxManager, cfg Config) *Service {
+	return &Service{
+		repo:      repo,
+		users:     users,
+		companies: companies,
+		policy:    policy,
+		tx:        tx,
+		cfg:       cfg,
+	}
+}
+
+// CreateInvitation создает приглашение от имени actorID. Открытый токен и ссылка возвращаются только здесь.
+// Пользователь с правом users:role:assign приглашает на любую роль, владелец компании - менеджеров в свою компанию.
+func (s *Service) CreateInvitation(ctx context.Context, actorID int64, actorRole domain.Role, input models.CreateInvitationInputDTO) (*models.CreatedInvitationDTO, error) {
+	authz, err := s.policy.Authorizer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceCreateInvitation, err)
+	}
+	if !authz.IsKnownRole(input.Role) {
+		return nil, userservice.ErrInvalidRole
+	}
+	if (input.CompanyID == nil) != (input.CompanyRole == nil) ||
+		(input.CompanyID != nil && input.Role != domain.RoleManager) {
+		return nil, ErrInvalidCompanyGrant
+	}
+	if input.CompanyRole != nil && !input.CompanyRole.IsValid() {
+		return nil, company.ErrInvalidRole
+	}
+
+	ttl := s.cfg.DefaultTTL
+	if input.TTL > 0 {
+		ttl = time.Duration(input.TTL) * time.Second
+	}
+	if ttl > s.cfg.MaxTTL {
+		return nil, ErrInvalidTTL
+	}
+
+	allowed, err := s.canManage(ctx, authz, actorID, actorRole, input.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceCreateInvitation, err)
+	}
+	if !allowed {
+		return nil, ErrInvitationForbidden
+	}
+
+	if input.CompanyID != nil {
+		if _, err := s.companies.GetCompany(ctx, *input.CompanyID); err != nil {
+			if errors.Is(err, company.ErrCompanyNotFound) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %v", ErrServiceCreateInvitation, err)
+		}
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceCreateInvitation, err)
+	}
+
+	now := time.Now()
+	created, err := s.repo.Create(ctx, &domain.Invitation{
+		TokenHash:   hashToken(token),
+		Role:        input.Role,
+		CompanyID:   input.CompanyID,
+		CompanyRole: input.CompanyRole,
+		CreatedBy:   actorID,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceCreateInvitation, err)
+	}
+
+	return &models.CreatedInvitationDTO{
+		InvitationDTO: toInvitationDTO(created, now),
+		Token:         token,
+		Link:          s.deepLink(token),
+	}, nil
+}
+
+// ListInvitations возвращает приглашения, доступные actorID: все - при праве users:role:assign,
+// иначе приглашения компаний, владельцем которых он является
+func (s *Service) ListInvitations(ctx context.Context, actorID int64, actorRole domain.Role) (*models.InvitationsDTO, error) {
+	authz, err := s.policy.Authorizer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetInvitation, err)
+	}
+
+	var invitations []*domain.Invitation
+	if authz.Has(actorRole, domain.PermUsersRoleAssign) {
+		invitations, err = s.repo.List(ctx)
+	} else {
+		var owned []int64
+		owned, err = s.ownedCompanies(ctx, actorID, actorRole)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrServiceGetInvitation, err)
+		}
+		if len(owned) == 0 {
+			return nil, ErrInvitationForbidden
+		}
+		invitations, err = s.repo.ListByCompanyIDs(ctx, owned)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetInvitation, err)
+	}
+
+	now := time.Now()
+	response := &models.InvitationsDTO{Invitations: make([]models.InvitationDTO, 0, len(invitations))}
+	for _, inv := range invitations {
+		response.Invitations = append(response.Invitations, toInvitationDTO(inv, now))
+	}
+	return response, nil
+}
+
+// RevokeInvitation отзывает непринятое приглашение
+func (s *Service) RevokeInvitation(ctx context.Context, actorID int64, actorRole domain.Role, id int64) error {
+	inv, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrInvitationNotFound) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrServiceRevokeInvitation, err)
+	}
+
+	authz, err := s.policy.Authorizer(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrServiceRevokeInvitation, err)
+	}
+	allowed, err := s.canManage(ctx, authz, actorID, actorRole, inv.CompanyID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrServiceRevokeInvitation, err)
+	}
+	if !allowed {
+		return ErrInvitationForbidden
+	}
+	if inv.AcceptedAt != nil {
+		return ErrInvitationAccepted
+	}
+
+	if err := s.repo.Revoke(ctx, id); err != nil {
+		if errors.Is(err, ErrInvitationNotFound) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrServiceRevokeInvitation, err)
+	}
+	return nil
+}
+
+// AcceptInvitation принимает приглашение по токену: пользователь tgID получает роль (смена роли
+// записывается в role_changes от имени пригласившего) и членство в компании, если оно указано
+func (s *Service) AcceptInvitation(ctx context.Context, tgID int64, token string) (*models.AcceptedInvitationDTO, error) {
+	user, err := s.users.GetUserByID(ctx, tgID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceAcceptInvitation, err)
+	}
+
+	// Отметка о принятии, смена роли и членство в компании сохраняются в одной транзакции:
+	// при ошибке приглашение остается действующим
+	tokenHash := hashToken(token)
+	var response *models.AcceptedInvitationDTO
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		inv, err := s.repo.Claim(ctx, tokenHash, tgID, time.Now())
+		if err != nil {
+			if errors.Is(err, ErrInvitationNotFound) {
+				return s.unavailableReason(ctx, tokenHash)
+			}
+			return fmt.Errorf("%w: %v", ErrServiceAcceptInvitation, err)
+		}
+
+		response, err = s.grant(ctx, inv, user)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// grant выдает роль и членство в компании по принятому приглашению
+func (s *Service) grant(ctx context.Context, inv *domain.Invitation, user *usermodels.UserDTO) (*models.AcceptedInvitationDTO, error) {
+	response := &models.AcceptedInvitationDTO{
+		Invitation: toInvitationDTO(inv, time.Now()),
+		User:       user,
+	}
+
+	if user.Role != inv.Role {
+		reason := fmt.Sprintf("invitation #%d", inv.ID)
+		updated, err := s.users.ChangeUserRole(ctx, inv.CreatedBy, user.TGUserID, usermodels.ChangeRoleInputDTO{
+			Role:   inv.Role,
+			Reason: &reason,
+		})
+		if err != nil {
+			if errors.Is(err, userservice.ErrInvalidRole) || errors.Is(err, userservice.ErrLastSuperUser) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %v", ErrServiceAcceptInvitation, err)
+		}
+		response.User = updated
+	}
+
+	if inv.CompanyID != nil && inv.CompanyRole != nil {
+		membership, err := s.companies.SetMember(ctx, *inv.CompanyID, user.TGUserID, companymodels.SetMemberInputDTO{Role: *inv.CompanyRole})
+		if err != nil {
+			if errors.Is(err, company.ErrCompanyNotFound) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %v", ErrServiceAcceptInvitation, err)
+		}
+		response.Membership = membership
+	}
+
+	return response, nil
+}
+
+// unavailableReason объясняет, почему приглашение нельзя принять
+func (s *Service) unavailableReason(ctx context.Context, tokenHash string) error {
+	inv, err := s.repo.GetByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, ErrInvitationNotFound) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrServiceAcceptInvitation, err)
+	}
+
+	switch inv.Status(time.Now()) {
+	case domain.InvitationStatusAccepted:
+		return ErrInvitationAccepted
+	case domain.InvitationStatusRevoked:
+		return ErrInvitationRevoked
+	default:
+		return ErrInvitationExpired
+	}
+}
+
+// canManage проверяет, может ли actorID управлять приглашениями компании companyID
+// (nil - приглашение без компании, только для права users:role:assign)
+func (s *Service) canManage(ctx context.Context, authz *domain.Authorizer, actorID int64, actorRole domain.Role, companyID *int64) (bool, error) {
+	if authz.Has(actorRole, domain.PermUsersRoleAssign) {
+		return true, nil
+	}
+	if companyID == nil {
+		return false, nil
+	}
+
+	owned, err := s.ownedCompanies(ctx, actorID, actorRole)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range owned {
+		if id == *companyID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ownedCompanies возвращает компании, владельцем которых является менеджер actorID
+func (s *Service) ownedCompanies(ctx context.Context, actorID int64, actorRole domain.Role) ([]int64, error) {
+	if actorRole != domain.RoleManager {
+		return nil, nil
+	}
+
+	memberships, err := s.companies.GetUserCompanies(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []int64
+	for _, m := range memberships.Companies {
+		if m.Role == domain.CompanyRoleOwner {
+			owned = append(owned, m.CompanyID)
+		}
+	}
+	return owned, nil
+}
+
+// deepLink формирует ссылку, открывающую Mini App с токеном в параметре startapp
+func (s *Service) deepLink(token string) string {
+	if s.cfg.BotUsername == "" {
+		return ""
+	}
+	path := url.PathEscape(s.cfg.BotUsername)
+	if s.cfg.AppName != "" {
+		path += "/" + url.PathEscape(s.cfg.AppName)
+	}
+	return "https://t.me/" + path + "?startapp=" + token
+}
+
+func toInvitationDTO(inv *domain.Invitation, now time.Time) models.InvitationDTO {
+	return models.InvitationDTO{
+		ID:          inv.ID,
+		Role:        inv.Role,
+		CompanyID:   inv.CompanyID,
+		CompanyRole: inv.CompanyRole,
+		Status:      inv.Status(now),
+		CreatedBy:   inv.CreatedBy,
+		CreatedAt:   inv.CreatedAt,
+		ExpiresAt:   inv.ExpiresAt,
+		AcceptedBy:  inv.AcceptedBy,
+		AcceptedAt:  inv.AcceptedAt,
+		RevokedAt:   inv.RevokedAt,
+	}
+}
+
+// hashToken возвращает hex(SHA-256(token)), по нему приглашение ищется в БД
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomToken возвращает n случайных байт в base64url без паддинга (допустимо в startapp)
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	companymodels "github.com/m04kA/SMC-UserService/internal/service/company/models"
+	usermodels "github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+type CreateInvitationInputDTO struct {
+	Role        domain.Role         `json:"role" validate:"required,max=20"`
+	CompanyID   *int64              `json:"company_id"`
+	CompanyRole *domain.CompanyRole `json:"company_role" validate:"omitempty,oneof=owner staff"`
+	TTL         int                 `json:"ttl" validate:"omitempty,min=60"` // Срок действия (секунды), по умолчанию из конфигурации
+}
+
+type InvitationDTO struct {
+	ID          int64                   `json:"id"`
+	Role        domain.Role             `json:"role"`
+	CompanyID   *int64                  `json:"company_id,omitempty"`
+	CompanyRole *domain.CompanyRole     `json:"company_role,omitempty"`
+	Status      domain.InvitationStatus `json:"status"`
+	CreatedBy   int64                   `json:"created_by"`
+	CreatedAt   time.Time               `json:"created_at"`
+	ExpiresAt   time.Time               `json:"expires_at"`
+	AcceptedBy  *int64                  `json:"accepted_by,omitempty"`
+	AcceptedAt  *time.Time              `json:"accepted_at,omitempty"`
+	RevokedAt   *time.Time              `json:"revoked_at,omitempty"`
+}
+
+// CreatedInvitationDTO содержит открытый токен, он возвращается только один раз при создании
+type CreatedInvitationDTO struct {
+	InvitationDTO
+	Token string `json:"token"`
+	Link  string `json:"link,omitempty"` // Deep link Telegram Mini App с токеном в startapp
+}
+
+type InvitationsDTO struct {
+	Invitations []InvitationDTO `json:"invitations"`
+}
+
+// AcceptedInvitationDTO профиль пользователя после принятия приглашения
+type AcceptedInvitationDTO struct {
+	Invitation InvitationDTO                `json:"invitation"`
+	User       *usermodels.UserDTO          `json:"user"`
+	Membership *companymodels.MembershipDTO `json:"membership,omitempty"`
+}
@@ -0,0 +1,51 @@
+package phone
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	usermodels "github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+var (
+	ErrPhoneNotSet          = errors.New("phone number is not set")
+	ErrInvalidPhoneNumber   = errors.New("phone number must be in E.164 format")
+	ErrPhoneAlreadyVerified = errors.New("phone number is already verified")
+	ErrResendTooSoon        = errors.New("verification code was sent recently")
+	ErrVerificationNotFound = errors.New("no pending phone verification")
+	ErrCodeExpired          = errors.New("verification code has expired")
+	ErrInvalidCode          = errors.New("invalid verification code")
+	ErrTooManyAttempts      = errors.New("too many verification attempts")
+)
+
+// UserProvider предоставляет профиль пользователя.
+type UserProvider interface {
+	GetUserByID(ctx context.Context, tgID int64) (*usermodels.UserDTO, error)
+}
+
+// VerificationRepository определяет контракт для хранения кодов подтверждения.
+type VerificationRepository interface {
+	// Save сохраняет код, заменяя предыдущий код пользователя
+	Save(ctx context.Context, verification *domain.PhoneVerification) error
+	Get(ctx context.Context, tgID int64) (*domain.PhoneVerification, error)
+	Delete(ctx context.Context, tgID int64) error
+	// IncrementAttempts учитывает попытку ввода и возвращает ErrTooManyAttempts, если лимит уже исчерпан
+	IncrementAttempts(ctx context.Context, tgID int64, maxAttempts int) error
+	// Confirm отмечает номер подтвержденным и удаляет код; ErrVerificationNotFound, если номер в профиле изменился
+	Confirm(ctx context.Context, tgID int64, phoneNumber string, verifiedAt time.Time) error
+}
+
+// Sender доставляет сообщение с кодом на номер телефона.
+type Sender interface {
+	Send(ctx context.Context, phoneNumber, message string) error
+}
+
+// Config параметры одноразовых кодов
+type Config struct {
+	CodeLength     int
+	CodeTTL        time.Duration
+	MaxAttempts    int
+	ResendInterval time.Duration
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// VerificationDTO информация об отправленном коде
+type VerificationDTO struct {
+	PhoneNumber       string    `json:"phone_number"`
+	ExpiresAt         time.Time `json:"expires_at"`
+	ResendAvailableAt time.Time `json:"resend_available_at"`
+}
+
+type VerifyPhoneInputDTO struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// PhoneStatusDTO подтвержденный номер телефона
+type PhoneStatusDTO struct {
+	PhoneNumber     string    `json:"phone_number"`
+	PhoneVerifiedAt time.Time `json:"phone_verified_at"`
+}
@@ -0,0 +1,161 @@
+package phone
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/phone/models"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+var (
+	ErrServiceGetUser         = errors.New("service: failed to get user")
+	ErrServiceGetVerification = errors.New("service: failed to get phone verification")
+	ErrServiceSaveCode        = errors.New("service: failed to save verification code")
+	ErrServiceSendCode        = errors.New("service: failed to send verification code")
+	ErrServiceVerifyCode      = errors.New("service: failed to verify code")
+)
+
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+type Service struct {
+	users  UserProvider
+	repo   VerificationRepository
+	sender Sender
+	cfg    Config
+}
+
+func NewService(users UserProvider, repo VerificationRepository, sender Sender, cfg Config) *Service {
+	return &Service{users: users, repo: repo, sender: sender, cfg: cfg}
+}
+
+// StartVerification отправляет одноразовый код на номер из профиля пользователя
+func (s *Service) StartVerification(ctx context.Context, tgID int64) (*models.VerificationDTO, error) {
+	user, err := s.users.GetUserByID(ctx, tgID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+
+	if user.PhoneNumber == nil || *user.PhoneNumber == "" {
+		return nil, ErrPhoneNotSet
+	}
+	phoneNumber := *user.PhoneNumber
+	if !e164Pattern.MatchString(phoneNumber) {
+		return nil, ErrInvalidPhoneNumber
+	}
+	if user.PhoneVerifiedAt != nil {
+		return nil, ErrPhoneAlreadyVerified
+	}
+
+	now := time.Now()
+	previous, err := s.repo.Get(ctx, tgID)
+	if err != nil && !errors.Is(err, ErrVerificationNotFound) {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetVerification, err)
+	}
+	if previous != nil && previous.PhoneNumber == phoneNumber && now.Before(previous.CreatedAt.Add(s.cfg.ResendInterval)) {
+		return nil, ErrResendTooSoon
+	}
+
+	code, err := generateCode(s.cfg.CodeLength)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceSaveCode, err)
+	}
+
+	verification := &domain.PhoneVerification{
+		TGUserID:    tgID,
+		PhoneNumber: phoneNumber,
+		CodeHash:    hashCode(tgID, phoneNumber, code),
+		ExpiresAt:   now.Add(s.cfg.CodeTTL),
+		CreatedAt:   now,
+	}
+	if err = s.repo.Save(ctx, verification); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceSaveCode, err)
+	}
+
+	message := fmt.Sprintf("Код подтверждения SMC: %s. Действует %d мин.", code, int(s.cfg.CodeTTL.Minutes()))
+	if err = s.sender.Send(ctx, phoneNumber, message); err != nil {
+		// Код не доставлен: удаляем его, чтобы повторный запрос не ждал окончания интервала
+		_ = s.repo.Delete(ctx, tgID)
+		return nil, fmt.Errorf("%w: %v", ErrServiceSendCode, err)
+	}
+
+	response := &models.VerificationDTO{
+		PhoneNumber:       phoneNumber,
+		ExpiresAt:         verification.ExpiresAt,
+		ResendAvailableAt: now.Add(s.cfg.ResendInterval),
+	}
+
+	return response, nil
+}
+
+// VerifyCode проверяет код и отмечает номер подтвержденным.
+// Попытка учитывается до сравнения кода, поэтому параллельные запросы не обходят лимит.
+func (s *Service) VerifyCode(ctx context.Context, tgID int64, input models.VerifyPhoneInputDTO) (*models.PhoneStatusDTO, error) {
+	verification, err := s.repo.Get(ctx, tgID)
+	if err != nil {
+		if errors.Is(err, ErrVerificationNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetVerification, err)
+	}
+
+	now := time.Now()
+	if !now.Before(verification.ExpiresAt) {
+		return nil, ErrCodeExpired
+	}
+
+	if err = s.repo.IncrementAttempts(ctx, tgID, s.cfg.MaxAttempts); err != nil {
+		if errors.Is(err, ErrTooManyAttempts) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceVerifyCode, err)
+	}
+
+	expected := []byte(verification.CodeHash)
+	actual := []byte(hashCode(tgID, verification.PhoneNumber, input.Code))
+	if subtle.ConstantTimeCompare(expected, actual) != 1 {
+		return nil, ErrInvalidCode
+	}
+
+	if err = s.repo.Confirm(ctx, tgID, verification.PhoneNumber, now); err != nil {
+		if errors.Is(err, ErrVerificationNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceVerifyCode, err)
+	}
+
+	response := &models.PhoneStatusDTO{
+		PhoneNumber:     verification.PhoneNumber,
+		PhoneVerifiedAt: now,
+	}
+
+	return response, nil
+}
+
+// generateCode возвращает случайный цифровой код длины length
+func generateCode(length int) (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", length, n), nil
+}
+
+// hashCode привязывает код к пользователю и номеру, в БД хранится только хеш
+func hashCode(tgID int64, phoneNumber, code string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s:%s", tgID, phoneNumber, code)))
+	return hex.EncodeToString(sum[:])
+}
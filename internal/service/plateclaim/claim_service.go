@@ -0,0 +1,277 @@
+package plateclaim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/plateclaim/models"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+var (
+	ErrServiceGetClaims    = errors.New("service: failed to get plate claims")
+	ErrServiceResolveClaim = errors.New("service: failed to resolve plate claim")
+	ErrServiceNotify       = errors.New("service: failed to notify users about plate claim resolution")
+)
+
+const (
+	defaultClaimListLimit = 100
+	maxClaimListLimit     = 1000
+)
+
+type Service struct {
+	claims    ClaimRepository
+	cars      CarRepository
+	companies CompanyProvider
+	policy    AccessPolicy
+	tx        TxManager
+	notifier  Notifier
+}
+
+func NewService(claims ClaimRepository, cars CarRepository, companies CompanyProvider, policy AccessPolicy, tx TxManager, notifier Notifier) *Service {
+	return &Service{claims: claims, cars: cars, companies: companies, policy: policy, tx: tx, notifier: notifier}
+}
+
+// ListClaims возвращает споры в порядке подачи
+func (s *Service) ListClaims(ctx context.Context, filter ClaimFilter) ([]models.PlateClaimDTO, error) {
+	if filter.Status != "" && filter.Status != domain.PlateClaimPending &&
+		filter.Status != domain.PlateClaimWithdrawn && !slices.Contains(domain.PlateClaimResolutions, filter.Status) {
+		return nil, fmt.Errorf("%w: unsupported status %q", ErrInvalidFilter, filter.Status)
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultClaimListLimit
+	}
+	if filter.Limit > maxClaimListLimit {
+		filter.Limit = maxClaimListLimit
+	}
+
+	claims, err := s.claims.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetClaims, err)
+	}
+
+	response := make([]models.PlateClaimDTO, 0, len(claims))
+	for _, claim := range claims {
+		response = append(response, toClaimDTO(claim))
+	}
+	return response, nil
+}
+
+// ResolveClaim принимает решение по спору от имени actorID и сообщает его заявителю и владельцу номера:
+//   - transferred: номер закрепляется за заявителем, автомобиль владельца с этим номером удаляется
+//   - rejected: автомобиль заявителя удаляется
+//   - shared: номер закрепляется за обоими
+//
+// Роль с правом cars:delete:any решает любые споры, остальные (менеджеры) - только споры, где заявитель
+// или владелец номера состоит в одной из компаний actorID (иначе ErrClaimForbidden).
+//
+// Решение сохраняется до отправки уведомлений. Если уведомить не удалось, возвращается решение
+// вместе с ошибкой ErrServiceNotify.
+func (s *Service) ResolveClaim(ctx context.Context, actorID int64, actorRole domain.Role, claimID int64, input models.ResolveClaimInputDTO) (*models.PlateClaimDTO, error) {
+	if !slices.Contains(domain.PlateClaimResolutions, input.Resolution) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidResolution, input.Resolution)
+	}
+
+	authz, err := s.policy.Authorizer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceResolveClaim, err)
+	}
+	global := authz.Has(actorRole, domain.PermCarsDeleteAny)
+
+	var claim *domain.PlateClaim
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		claim, err = s.claims.GetForUpdate(ctx, claimID)
+		if err != nil {
+			if errors.Is(err, ErrPlateClaimNotFound) {
+				return err
+			}
+			return fmt.Errorf("%w: %v", ErrServiceResolveClaim, err)
+		}
+		if claim.Status != domain.PlateClaimPending {
+			return ErrPlateClaimResolved
+		}
+		if actorID == claim.ClaimantID || actorID == claim.HolderID {
+			return ErrOwnPlateClaim
+		}
+		if !global {
+			allowed, err := s.sharesCompany(ctx, actorID, claim.ClaimantID, claim.HolderID)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return ErrClaimForbidden
+			}
+		}
+		if claim.CarID == nil {
+			return userservice.ErrCarNotFound
+		}
+
+		if err = s.applyResolution(ctx, claim, input.Resolution); err != nil {
+			return err
+		}
+
+		resolvedAt := time.Now()
+		claim.Status = input.Resolution
+		claim.ResolvedBy = &actorID
+		claim.Comment = input.Comment
+		claim.ResolvedAt = &resolvedAt
+		if err = s.claims.Resolve(ctx, claim); err != nil {
+			return fmt.Errorf("%w: %v", ErrServiceResolveClaim, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toClaimDTO(claim)
+
+	var notifyErrs []error
+	for _, recipient := range []int64{claim.ClaimantID, claim.HolderID} {
+		if err := s.notifier.NotifyPlateClaimResolved(ctx, recipient, response); err != nil {
+			notifyErrs = append(notifyErrs, fmt.Errorf("tg_user_id=%d: %v", recipient, err))
+		}
+	}
+	if len(notifyErrs) > 0 {
+		return &response, fmt.Errorf("%w: %v", ErrServiceNotify, errors.Join(notifyErrs...))
+	}
+
+	return &response, nil
+}
+
+// sharesCompany проверяет, состоит ли хотя бы один из участников спора в одной из компаний actorID
+func (s *Service) sharesCompany(ctx context.Context, actorID int64, participantIDs ...int64) (bool, error) {
+	actorCompanies, err := s.companies.GetUserCompanies(ctx, actorID)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrServiceResolveClaim, err)
+	}
+	companyIDs := make(map[int64]struct{}, len(actorCompanies.Companies))
+	for _, m := range actorCompanies.Companies {
+		companyIDs[m.CompanyID] = struct{}{}
+	}
+	if len(companyIDs) == 0 {
+		return false, nil
+	}
+
+	for _, participantID := range participantIDs {
+		memberships, err := s.companies.GetUserCompanies(ctx, participantID)
+		if err != nil {
+			return false, fmt.Errorf("%w: %v", ErrServiceResolveClaim, err)
+		}
+		for _, m := range memberships.Companies {
+			if _, ok := companyIDs[m.CompanyID]; ok {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// applyResolution меняет автомобили участников спора.
+// Автомобили блокируются в порядке ID пользователей, чтобы параллельные решения не блокировали друг друга.
+// Автомобили удаленного пользователя не меняются.
+func (s *Service) applyResolution(ctx context.Context, claim *domain.PlateClaim, resolution domain.PlateClaimStatus) error {
+	userIDs := []int64{claim.ClaimantID, claim.HolderID}
+	slices.Sort(userIDs)
+
+	carsByUser := make(map[int64][]*domain.Car, len(userIDs))
+	for _, userID := range userIDs {
+		cars, err := s.cars.LockByUserID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, userservice.ErrUserNotFound) {
+				continue
+			}
+			return fmt.Errorf("%w: %v", ErrServiceResolveClaim, err)
+		}
+		carsByUser[userID] = cars
+	}
+
+	claimantCars := carsByUser[claim.ClaimantID]
+	claimantCar := findCar(claimantCars, *claim.CarID)
+	if claimantCar == nil {
+		return userservice.ErrCarNotFound
+	}
+
+	switch resolution {
+	case domain.PlateClaimRejected:
+		// Автомобиль со спорным номером не бывает выбранным, выбор заявителя не меняется
+		if err := s.cars.Delete(ctx, claimantCar.ID); err != nil {
+			return fmt.Errorf("%w: %v", ErrServiceResolveClaim, err)
+		}
+		return nil
+	case domain.PlateClaimTransferred:
+		holderCars := carsByUser[claim.HolderID]
+		if claim.HolderCarID != nil {
+			// Владелец мог сменить номер автомобиля после подачи заявки
+			holderCar := findCar(holderCars, *claim.HolderCarID)
+			if holderCar != nil && holderCar.LicensePlateNormalized == claim.LicensePlate {
+				if err := s.removeCar(ctx, holderCars, holderCar); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return s.confirmCar(ctx, claimantCars, claimantCar)
+}
+
+// confirmCar закрепляет номер за автомобилем; если у владельца нет выбранного автомобиля, выбирает его
+func (s *Service) confirmCar(ctx context.Context, cars []*domain.Car, car *domain.Car) error {
+	car.LicensePlateStatus = domain.PlateStatusConfirmed
+	car.IsSelected = !slices.ContainsFunc(cars, func(c *domain.Car) bool { return c.IsSelected })
+	if err := s.cars.Update(ctx, car); err != nil {
+		return fmt.Errorf("%w: %v", ErrServiceResolveClaim, err)
+	}
+	return nil
+}
+
+// removeCar удаляет автомобиль; если он был выбранным, выбирает первый из оставшихся с закрепленным номером
+func (s *Service) removeCar(ctx context.Context, cars []*domain.Car, car *domain.Car) error {
+	if err := s.cars.Delete(ctx, car.ID); err != nil {
+		return fmt.Errorf("%w: %v", ErrServiceResolveClaim, err)
+	}
+	if !car.IsSelected {
+		return nil
+	}
+	for _, c := range cars {
+		if c.ID == car.ID || c.LicensePlateStatus != domain.PlateStatusConfirmed {
+			continue
+		}
+		c.IsSelected = true
+		if err := s.cars.Update(ctx, c); err != nil {
+			return fmt.Errorf("%w: %v", ErrServiceResolveClaim, err)
+		}
+		break
+	}
+	return nil
+}
+
+func findCar(cars []*domain.Car, carID int64) *domain.Car {
+	for _, car := range cars {
+		if car.ID == carID {
+			return car
+		}
+	}
+	return nil
+}
+
+func toClaimDTO(claim *domain.PlateClaim) models.PlateClaimDTO {
+	return models.PlateClaimDTO{
+		ID:           claim.ID,
+		CarID:        claim.CarID,
+		ClaimantID:   claim.ClaimantID,
+		HolderCarID:  claim.HolderCarID,
+		HolderID:     claim.HolderID,
+		LicensePlate: claim.LicensePlate,
+		Status:       claim.Status,
+		ResolvedBy:   claim.ResolvedBy,
+		Comment:      claim.Comment,
+		CreatedAt:    claim.CreatedAt,
+		ResolvedAt:   claim.ResolvedAt,
+	}
+}
@@ -0,0 +1,62 @@
+package plateclaim
+
+import (
+	"context"
+	"errors"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	companymodels "github.com/m04kA/SMC-UserService/internal/service/company/models"
+	"github.com/m04kA/SMC-UserService/internal/service/plateclaim/models"
+)
+
+var (
+	ErrPlateClaimNotFound = errors.New("plate claim not found")
+	ErrPlateClaimResolved = errors.New("plate claim is already resolved")
+	ErrOwnPlateClaim      = errors.New("cannot resolve a plate claim involving own car")
+	ErrInvalidFilter      = errors.New("invalid plate claim filter")
+	ErrInvalidResolution  = errors.New("unsupported plate claim resolution")
+	ErrClaimForbidden     = errors.New("plate claim does not involve members of the actor's companies")
+)
+
+// ClaimRepository определяет контракт для работы с хранилищем споров о госномерах.
+type ClaimRepository interface {
+	// GetForUpdate блокирует спор до конца транзакции, вызывается внутри TxManager.WithTx
+	GetForUpdate(ctx context.Context, id int64) (*domain.PlateClaim, error)
+	Resolve(ctx context.Context, claim *domain.PlateClaim) error
+	List(ctx context.Context, filter ClaimFilter) ([]*domain.PlateClaim, error)
+}
+
+// CarRepository определяет операции с автомобилями участников спора.
+type CarRepository interface {
+	// LockByUserID блокирует автомобили пользователя до конца транзакции, вызывается внутри TxManager.WithTx
+	LockByUserID(ctx context.Context, userID int64) ([]*domain.Car, error)
+	Update(ctx context.Context, car *domain.Car) error
+	Delete(ctx context.Context, carID int64) error
+}
+
+// CompanyProvider предоставляет членство пользователей в компаниях.
+type CompanyProvider interface {
+	GetUserCompanies(ctx context.Context, tgID int64) (*companymodels.MembershipsDTO, error)
+}
+
+// AccessPolicy предоставляет актуальный справочник ролей и прав.
+type AccessPolicy interface {
+	Authorizer(ctx context.Context) (*domain.Authorizer, error)
+}
+
+// TxManager выполняет fn в транзакции; при конфликте транзакций fn может быть выполнена повторно.
+type TxManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Notifier сообщает пользователю о решении по спору о госномере.
+type Notifier interface {
+	NotifyPlateClaimResolved(ctx context.Context, tgUserID int64, claim models.PlateClaimDTO) error
+}
+
+// ClaimFilter условия выборки споров; нулевые поля не ограничивают выборку
+type ClaimFilter struct {
+	Status domain.PlateClaimStatus
+	UserID int64 // Заявитель или владелец номера
+	Limit  uint64
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+)
+
+type PlateClaimDTO struct {
+	ID           int64                   `json:"id"`
+	CarID        *int64                  `json:"car_id"`
+	ClaimantID   int64                   `json:"claimant_id"`
+	HolderCarID  *int64                  `json:"holder_car_id"`
+	HolderID     int64                   `json:"holder_id"`
+	LicensePlate string                  `json:"license_plate_normalized"`
+	Status       domain.PlateClaimStatus `json:"status"`
+	ResolvedBy   *int64                  `json:"resolved_by,omitempty"`
+	Comment      *string                 `json:"comment,omitempty"`
+	CreatedAt    time.Time               `json:"created_at"`
+	ResolvedAt   *time.Time              `json:"resolved_at,omitempty"`
+}
+
+// ResolveClaimInputDTO решение по спору о госномере
+type ResolveClaimInputDTO struct {
+	Resolution domain.PlateClaimStatus `json:"resolution" validate:"required,oneof=transferred rejected shared"`
+	Comment    *string                 `json:"comment" validate:"omitempty,max=500"`
+}
@@ -0,0 +1,43 @@
+package platelookup
+
+import (
+	"context"
+	"errors"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	companymodels "github.com/m04kA/SMC-UserService/internal/service/company/models"
+	usermodels "github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+var (
+	ErrNotCompanyMember = errors.New("user is not a member of the company")
+)
+
+// CarFinder ищет автомобили с владельцами по госномеру.
+type CarFinder interface {
+	FindCarsByPlate(ctx context.Context, plate string) (*usermodels.CarsByPlateDTO, error)
+}
+
+// CompanyProvider предоставляет компании и членство в них.
+type CompanyProvider interface {
+	GetCompany(ctx context.Context, id int64) (*companymodels.CompanyDTO, error)
+	GetUserCompanies(ctx context.Context, tgID int64) (*companymodels.MembershipsDTO, error)
+}
+
+// AccessPolicy предоставляет актуальный справочник ролей и прав.
+type AccessPolicy interface {
+	Authorizer(ctx context.Context) (*domain.Authorizer, error)
+}
+
+// AuditRepository определяет контракт для работы с журналом поиска по госномеру.
+type AuditRepository interface {
+	Create(ctx context.Context, event *domain.PlateLookupEvent) error
+	List(ctx context.Context, filter AuditFilter) ([]*domain.PlateLookupEvent, error)
+}
+
+// AuditFilter условия выборки журнала; нулевые поля не ограничивают выборку
+type AuditFilter struct {
+	ActorID   int64
+	CompanyID int64
+	Limit     uint64
+}
@@ -0,0 +1,120 @@
+package platelookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/company"
+	"github.com/m04kA/SMC-UserService/internal/service/platelookup/models"
+	usermodels "github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+var (
+	ErrServiceLookup      = errors.New("service: failed to look up cars by plate")
+	ErrServiceRecordAudit = errors.New("service: failed to record plate lookup audit")
+	ErrServiceGetAudit    = errors.New("service: failed to get plate lookup audit")
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type Service struct {
+	cars      CarFinder
+	companies CompanyProvider
+	policy    AccessPolicy
+	audit     AuditRepository
+}
+
+func NewService(cars CarFinder, companies CompanyProvider, policy AccessPolicy, audit AuditRepository) *Service {
+	return &Service{cars: cars, companies: companies, policy: policy, audit: audit}
+}
+
+// LookupForCompany ищет автомобили по госномеру от имени компании companyID.
+// Искать может менеджер компании, роль с cars:read:any - от имени любой компании.
+// Каждый поиск записывается в журнал; если записать не удалось, результат не возвращается.
+func (s *Service) LookupForCompany(ctx context.Context, actorID int64, role domain.Role, companyID int64, plate string) (*usermodels.CarsByPlateDTO, error) {
+	if _, err := s.companies.GetCompany(ctx, companyID); err != nil {
+		if errors.Is(err, company.ErrCompanyNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceLookup, err)
+	}
+
+	authz, err := s.policy.Authorizer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceLookup, err)
+	}
+	if !authz.Has(role, domain.PermCarsReadAny) {
+		member, err := s.isMember(ctx, actorID, companyID)
+		if err != nil {
+			return nil, err
+		}
+		if !member {
+			return nil, ErrNotCompanyMember
+		}
+	}
+
+	result, err := s.cars.FindCarsByPlate(ctx, plate)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &domain.PlateLookupEvent{
+		ActorID:     actorID,
+		CompanyID:   companyID,
+		Plate:       result.LicensePlate,
+		ResultCount: len(result.Cars),
+		CreatedAt:   time.Now(),
+	}
+	if err := s.audit.Create(ctx, event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceRecordAudit, err)
+	}
+
+	return result, nil
+}
+
+// ListEvents возвращает записи журнала, новые первыми
+func (s *Service) ListEvents(ctx context.Context, filter AuditFilter) ([]models.AuditEventDTO, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	if filter.Limit > maxAuditLimit {
+		filter.Limit = maxAuditLimit
+	}
+
+	events, err := s.audit.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetAudit, err)
+	}
+
+	response := make([]models.AuditEventDTO, 0, len(events))
+	for _, e := range events {
+		response = append(response, models.AuditEventDTO{
+			ID:          e.ID,
+			ActorID:     e.ActorID,
+			CompanyID:   e.CompanyID,
+			Plate:       e.Plate,
+			ResultCount: e.ResultCount,
+			CreatedAt:   e.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+func (s *Service) isMember(ctx context.Context, tgID, companyID int64) (bool, error) {
+	memberships, err := s.companies.GetUserCompanies(ctx, tgID)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrServiceLookup, err)
+	}
+	for _, m := range memberships.Companies {
+		if m.CompanyID == companyID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type AuditEventDTO struct {
+	ID          int64     `json:"id"`
+	ActorID     int64     `json:"actor_id"`
+	CompanyID   int64     `json:"company_id"`
+	Plate       string    `json:"plate"`
+	ResultCount int       `json:"result_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,27 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+)
+
+var (
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrRoleAlreadyExists = errors.New("role already exists")
+	ErrInvalidRoleName   = errors.New("invalid role name")
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrProtectedRole     = errors.New("permissions of this role cannot be changed")
+)
+
+// RoleRepository определяет контракт для работы со справочником ролей и прав.
+type RoleRepository interface {
+	// ListRoles возвращает все роли вместе с их правами
+	ListRoles(ctx context.Context) ([]domain.RoleDefinition, error)
+	ListPermissions(ctx context.Context) ([]domain.PermissionDefinition, error)
+	// CreateRole создает роль и назначает ей права в одной транзакции
+	CreateRole(ctx context.Context, role *domain.RoleDefinition) error
+	// SetRolePermissions заменяет набор прав роли
+	SetRolePermissions(ctx context.Context, role domain.Role, permissions []domain.Permission) error
+}
@@ -0,0 +1,30 @@
+package models
+
+type RoleDTO struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	BuiltIn     bool     `json:"built_in"`
+	Permissions []string `json:"permissions"`
+}
+
+type PermissionDTO struct {
+	Code        string  `json:"code"`
+	Description *string `json:"description,omitempty"`
+}
+
+type CreateRoleInputDTO struct {
+	Name        string   `json:"name" validate:"required,max=20"`
+	Description *string  `json:"description,omitempty"`
+	Permissions []string `json:"permissions" validate:"dive,required,max=64"`
+}
+
+type SetRolePermissionsInputDTO struct {
+	Permissions []string `json:"permissions" validate:"dive,required,max=64"`
+}
+
+// MyPermissionsDTO права текущего пользователя
+type MyPermissionsDTO struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
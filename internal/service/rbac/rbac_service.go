@@ -0,0 +1,211 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/rbac/models"
+)
+
+var (
+	ErrServiceGetRoles   = errors.New("service: failed to get roles")
+	ErrServiceCreateRole = errors.New("service: failed to create role")
+	ErrServiceUpdateRole = errors.New("service: failed to update role")
+)
+
+// Service управляет ролями и правами и кеширует справочник в виде domain.Authorizer.
+// Кеш обновляется по истечении ttl и сбрасывается при изменениях через этот сервис.
+type Service struct {
+	repo RoleRepository
+	ttl  time.Duration
+
+	mu       sync.Mutex
+	authz    *domain.Authorizer
+	loadedAt time.Time
+}
+
+func NewService(repo RoleRepository, cacheTTL time.Duration) *Service {
+	return &Service{repo: repo, ttl: cacheTTL}
+}
+
+// Authorizer возвращает закешированный справочник ролей и прав
+func (s *Service) Authorizer(ctx context.Context) (*domain.Authorizer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.authz != nil && time.Since(s.loadedAt) < s.ttl {
+		return s.authz, nil
+	}
+
+	roles, err := s.repo.ListRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetRoles, err)
+	}
+
+	s.authz = domain.NewAuthorizer(roles)
+	s.loadedAt = time.Now()
+	return s.authz, nil
+}
+
+// GetRolePermissions возвращает права роли текущего пользователя
+func (s *Service) GetRolePermissions(ctx context.Context, role domain.Role) (*models.MyPermissionsDTO, error) {
+	authz, err := s.Authorizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MyPermissionsDTO{
+		Role:        string(role),
+		Permissions: permissionsToStrings(authz.Permissions(role)),
+	}, nil
+}
+
+// ListRoles возвращает все роли с правами
+func (s *Service) ListRoles(ctx context.Context) ([]models.RoleDTO, error) {
+	authz, err := s.Authorizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := authz.Roles()
+	response := make([]models.RoleDTO, 0, len(roles))
+	for _, role := range roles {
+		response = append(response, toRoleDTO(role))
+	}
+	return response, nil
+}
+
+// ListPermissions возвращает справочник прав
+func (s *Service) ListPermissions(ctx context.Context) ([]models.PermissionDTO, error) {
+	permissions, err := s.repo.ListPermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetRoles, err)
+	}
+
+	response := make([]models.PermissionDTO, 0, len(permissions))
+	for _, p := range permissions {
+		response = append(response, models.PermissionDTO{
+			Code:        string(p.Code),
+			Description: p.Description,
+		})
+	}
+	return response, nil
+}
+
+// CreateRole создает собственную роль (например, support) с набором прав
+func (s *Service) CreateRole(ctx context.Context, input models.CreateRoleInputDTO) (*models.RoleDTO, error) {
+	name := domain.Role(input.Name)
+	if !name.IsValid() {
+		return nil, ErrInvalidRoleName
+	}
+
+	permissions, err := s.resolvePermissions(ctx, input.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &domain.RoleDefinition{
+		Name:        name,
+		Description: input.Description,
+		Permissions: permissions,
+	}
+	if err := s.repo.CreateRole(ctx, role); err != nil {
+		if errors.Is(err, ErrRoleAlreadyExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceCreateRole, err)
+	}
+	s.invalidate()
+
+	response := toRoleDTO(*role)
+	return &response, nil
+}
+
+// SetRolePermissions заменяет набор прав роли.
+// Права superuser не изменяются, чтобы нельзя было потерять доступ к управлению ролями.
+func (s *Service) SetRolePermissions(ctx context.Context, name domain.Role, input models.SetRolePermissionsInputDTO) (*models.RoleDTO, error) {
+	if name == domain.RoleSuperUser {
+		return nil, ErrProtectedRole
+	}
+
+	permissions, err := s.resolvePermissions(ctx, input.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetRolePermissions(ctx, name, permissions); err != nil {
+		if errors.Is(err, ErrRoleNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceUpdateRole, err)
+	}
+	s.invalidate()
+
+	authz, err := s.Authorizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range authz.Roles() {
+		if role.Name == name {
+			response := toRoleDTO(role)
+			return &response, nil
+		}
+	}
+	return nil, ErrRoleNotFound
+}
+
+// resolvePermissions проверяет, что все коды прав есть в справочнике, и убирает дубликаты
+func (s *Service) resolvePermissions(ctx context.Context, codes []string) ([]domain.Permission, error) {
+	known, err := s.repo.ListPermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetRoles, err)
+	}
+
+	knownSet := make(map[domain.Permission]bool, len(known))
+	for _, p := range known {
+		knownSet[p.Code] = true
+	}
+
+	seen := make(map[domain.Permission]bool, len(codes))
+	permissions := make([]domain.Permission, 0, len(codes))
+	for _, code := range codes {
+		p := domain.Permission(code)
+		if !knownSet[p] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPermission, code)
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		permissions = append(permissions, p)
+	}
+	return permissions, nil
+}
+
+func (s *Service) invalidate() {
+	s.mu.Lock()
+	s.authz = nil
+	s.mu.Unlock()
+}
+
+func toRoleDTO(role domain.RoleDefinition) models.RoleDTO {
+	return models.RoleDTO{
+		ID:          role.ID,
+		Name:        string(role.Name),
+		Description: role.Description,
+		BuiltIn:     role.Name.IsBuiltIn(),
+		Permissions: permissionsToStrings(role.Permissions),
+	}
+}
+
+func permissionsToStrings(permissions []domain.Permission) []string {
+	result := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		result = append(result, string(p))
+	}
+	return result
+}
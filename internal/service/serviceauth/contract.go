@@ -0,0 +1,32 @@
+package serviceauth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+)
+
+var (
+	ErrCredentialNotFound = errors.New("service credential not found")
+	ErrCredentialRevoked  = errors.New("service credential is revoked")
+	ErrInvalidServiceName = errors.New("invalid service name")
+	ErrKeyEncryptionOff   = errors.New("service key encryption key is not configured")
+)
+
+// CredentialRepository определяет контракт для работы с хранилищем учетных данных сервисов.
+type CredentialRepository interface {
+	Create(ctx context.Context, credential *domain.ServiceCredential) (*domain.ServiceCredential, error)
+	GetByKeyID(ctx context.Context, keyID string) (*domain.ServiceCredential, error)
+	List(ctx context.Context) ([]*domain.ServiceCredential, error)
+	ListLegacy(ctx context.Context) ([]*domain.ServiceCredential, error)
+	SetSigningKey(ctx context.Context, id int64, encrypted []byte) error
+	Revoke(ctx context.Context, id int64) error
+}
+
+// KeyCipher шифрует ключи подписи ключом шифрования сервера (KEK), см. pkg/secretbox.
+// associatedData - key_id, чтобы зашифрованный ключ нельзя было перенести в другую запись.
+type KeyCipher interface {
+	Seal(plaintext, associatedData []byte) ([]byte, error)
+	Open(sealed, associatedData []byte) ([]byte, error)
+}
@@ -0,0 +1,191 @@
+package serviceauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/serviceauth/models"
+	"github.com/m04kA/SMC-UserService/pkg/servicesign"
+)
+
+var (
+	ErrServiceCreateCredential = errors.New("service: failed to create service credential")
+	ErrServiceGetCredential    = errors.New("service: failed to get service credential")
+	ErrServiceRevokeCredential = errors.New("service: failed to revoke service credential")
+	ErrServiceEncryptKeys      = errors.New("service: failed to encrypt legacy service signing keys")
+)
+
+var serviceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,99}$`)
+
+type Service struct {
+	repo   CredentialRepository
+	cipher KeyCipher
+}
+
+// NewService создает сервис ключей. cipher может быть nil, если KEK не задан:
+// тогда выпуск ключей и проверка подписи возвращают ErrKeyEncryptionOff.
+func NewService(repo CredentialRepository, cipher KeyCipher) *Service {
+	return &Service{repo: repo, cipher: cipher}
+}
+
+// CreateCredential выпускает новый ключ для сервиса. Открытый секрет возвращается только здесь,
+// в БД сохраняется ключ подписи SHA-256(secret), зашифрованный KEK.
+func (s *Service) CreateCredential(ctx context.Context, input models.CreateCredentialInputDTO, actorID int64) (*models.CreatedCredentialDTO, error) {
+	if !serviceNamePattern.MatchString(input.ServiceName) {
+		return nil, ErrInvalidServiceName
+	}
+	if s.cipher == nil {
+		return nil, ErrKeyEncryptionOff
+	}
+
+	keyID, err := randomToken(9)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceCreateCredential, err)
+	}
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceCreateCredential, err)
+	}
+
+	keyID = "sk_" + keyID
+	encrypted, err := s.cipher.Seal(servicesign.SigningKey(secret), []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceCreateCredential, err)
+	}
+
+	credential := &domain.ServiceCredential{
+		ServiceName:         input.ServiceName,
+		KeyID:               keyID,
+		SigningKeyEncrypted: encrypted,
+		CreatedBy:           &actorID,
+		CreatedAt:           time.Now(),
+	}
+
+	created, err := s.repo.Create(ctx, credential)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceCreateCredential, err)
+	}
+
+	return &models.CreatedCredentialDTO{
+		CredentialDTO: toCredentialDTO(created),
+		Secret:        secret,
+	}, nil
+}
+
+// ListCredentials возвращает все ключи сервисов (без секретов)
+func (s *Service) ListCredentials(ctx context.Context) ([]models.CredentialDTO, error) {
+	credentials, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCredential, err)
+	}
+
+	response := make([]models.CredentialDTO, 0, len(credentials))
+	for _, c := range credentials {
+		response = append(response, toCredentialDTO(c))
+	}
+	return response, nil
+}
+
+// RevokeCredential отзывает ключ сервиса
+func (s *Service) RevokeCredential(ctx context.Context, id int64) error {
+	if err := s.repo.Revoke(ctx, id); err != nil {
+		if errors.Is(err, ErrCredentialNotFound) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrServiceRevokeCredential, err)
+	}
+	return nil
+}
+
+// GetCaller возвращает сервис и ключ подписи по идентификатору ключа
+func (s *Service) GetCaller(ctx context.Context, keyID string) (*models.CallerDTO, error) {
+	credential, err := s.repo.GetByKeyID(ctx, keyID)
+	if err != nil {
+		if errors.Is(err, ErrCredentialNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCredential, err)
+	}
+
+	if !credential.IsActive() {
+		return nil, ErrCredentialRevoked
+	}
+	if s.cipher == nil {
+		return nil, ErrKeyEncryptionOff
+	}
+	if credential.SigningKeyEncrypted == nil {
+		return nil, fmt.Errorf("%w: signing key of %s is not encrypted", ErrServiceGetCredential, credential.KeyID)
+	}
+
+	signingKey, err := s.cipher.Open(credential.SigningKeyEncrypted, []byte(credential.KeyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decrypt signing key of %s: %v", ErrServiceGetCredential, credential.KeyID, err)
+	}
+
+	return &models.CallerDTO{
+		ServiceName: credential.ServiceName,
+		KeyID:       credential.KeyID,
+		SigningKey:  signingKey,
+	}, nil
+}
+
+// EncryptLegacyKeys шифрует ключи подписи, выпущенные до появления KEK, и удаляет их открытую копию.
+// Вызывается при запуске; возвращает количество зашифрованных ключей.
+func (s *Service) EncryptLegacyKeys(ctx context.Context) (int, error) {
+	if s.cipher == nil {
+		return 0, ErrKeyEncryptionOff
+	}
+
+	credentials, err := s.repo.ListLegacy(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrServiceEncryptKeys, err)
+	}
+
+	encryptedCount := 0
+	for _, c := range credentials {
+		if c.LegacySigningKey == nil {
+			continue
+		}
+		signingKey, err := hex.DecodeString(*c.LegacySigningKey)
+		if err != nil {
+			return encryptedCount, fmt.Errorf("%w: corrupted signing key of %s: %v", ErrServiceEncryptKeys, c.KeyID, err)
+		}
+		encrypted, err := s.cipher.Seal(signingKey, []byte(c.KeyID))
+		if err != nil {
+			return encryptedCount, fmt.Errorf("%w: %v", ErrServiceEncryptKeys, err)
+		}
+		if err := s.repo.SetSigningKey(ctx, c.ID, encrypted); err != nil {
+			return encryptedCount, fmt.Errorf("%w: %v", ErrServiceEncryptKeys, err)
+		}
+		encryptedCount++
+	}
+
+	return encryptedCount, nil
+}
+
+func toCredentialDTO(c *domain.ServiceCredential) models.CredentialDTO {
+	return models.CredentialDTO{
+		ID:          c.ID,
+		ServiceName: c.ServiceName,
+		KeyID:       c.KeyID,
+		CreatedBy:   c.CreatedBy,
+		CreatedAt:   c.CreatedAt,
+		RevokedAt:   c.RevokedAt,
+	}
+}
+
+// randomToken возвращает n случайных байт в base64url без паддинга
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
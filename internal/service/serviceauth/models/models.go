@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type CreateCredentialInputDTO struct {
+	ServiceName string `json:"service_name" validate:"required,max=100"`
+}
+
+type CredentialDTO struct {
+	ID          int64      `json:"id"`
+	ServiceName string     `json:"service_name"`
+	KeyID       string     `json:"key_id"`
+	CreatedBy   *int64     `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreatedCredentialDTO содержит открытый секрет, он возвращается только один раз при создании
+type CreatedCredentialDTO struct {
+	CredentialDTO
+	Secret string `json:"secret"`
+}
+
+// CallerDTO проверенный вызывающий сервис
+type CallerDTO struct {
+	ServiceName string
+	KeyID       string
+	SigningKey  []byte
+}
@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/m04kA/SMC-UserService/internal/domain"
 )
@@ -12,15 +13,75 @@ var (
 	ErrUserAlreadyExists = errors.New("user with this telegram id already exists")
 	ErrCarNotFound       = errors.New("car not found")
 	ErrCarAccessDenied   = errors.New("access denied to this car")
+	ErrInvalidRole       = errors.New("invalid role")
+	ErrLastSuperUser     = errors.New("cannot demote or delete the last superuser")
+	ErrInvalidStatus     = errors.New("invalid account status")
+	ErrSuspensionExpired = errors.New("suspended_until must be in the future")
+	ErrOwnStatusChange   = errors.New("cannot change own account status")
+	ErrInvalidFilter     = errors.New("invalid user list filter")
+	ErrInvalidCursor     = errors.New("invalid pagination cursor")
+	ErrUserDeleted       = errors.New("user account is pending deletion")
+	ErrUserNotDeleted    = errors.New("user account is not deleted")
+	ErrRestoreExpired    = errors.New("restore period has expired")
+	ErrInvalidPhone      = errors.New("invalid phone number")
+	ErrPhoneNumberTaken  = errors.New("phone number is used by another user")
+	ErrInvalidPlate      = errors.New("invalid license plate")
+	ErrPlateTaken        = errors.New("license plate is registered by another user")
+	ErrPlateClaimPending = errors.New("license plate claim is pending")
 )
 
 // UserRepository определяет контракт для работы с хранилищем пользователей.
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
+	AddConsents(ctx context.Context, tgID int64, consents []domain.ConsentType, grantedAt time.Time) error
 	GetByTGID(ctx context.Context, tgID int64) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
-	Delete(ctx context.Context, tgID int64) error
+	SoftDelete(ctx context.Context, tgID int64, deletedAt time.Time) error
+	GetDeletedByTGID(ctx context.Context, tgID int64) (*domain.User, error)
+	Restore(ctx context.Context, tgID int64, deletedAfter time.Time) error
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
 	GetSuperUsers(ctx context.Context) ([]int64, error)
+	// LockSuperUsers блокирует активных суперпользователей до конца транзакции, вызывается внутри TxManager.WithTx
+	LockSuperUsers(ctx context.Context) ([]int64, error)
+	ChangeRole(ctx context.Context, change *domain.RoleChange) error
+	UpdateStatus(ctx context.Context, user *domain.User) error
+	List(ctx context.Context, filter UserListFilter) ([]*domain.User, error)
+	GetByTGIDs(ctx context.Context, tgIDs []int64) ([]*domain.User, error)
+	GetByPhoneNumber(ctx context.Context, phoneNumber string) ([]*domain.User, error)
+	// GetPhoneOwners возвращает владельцев номера, включая удаленных, но еще не очищенных
+	GetPhoneOwners(ctx context.Context, phoneNumber string) ([]int64, error)
+	// LockPhoneNumber блокирует номер до конца транзакции, вызывается внутри TxManager.WithTx
+	LockPhoneNumber(ctx context.Context, phoneNumber string) error
+}
+
+// UserSortField поле сортировки списка пользователей
+type UserSortField string
+
+const (
+	UserSortCreatedAt UserSortField = "created_at"
+	UserSortName      UserSortField = "name"
+	UserSortTGUserID  UserSortField = "tg_user_id"
+)
+
+// UserCursor позиция последнего пользователя предыдущей страницы.
+// Используется значение поля сортировки и tg_user_id для однозначного порядка.
+type UserCursor struct {
+	CreatedAt time.Time
+	Name      string
+	TGUserID  int64
+}
+
+// UserListFilter условия выборки списка пользователей; nil и пустые поля не ограничивают выборку
+type UserListFilter struct {
+	Role        *domain.Role
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	HasCar      *bool
+	Search      string // Подстрока имени, телефона или tg_link без учета регистра
+	SortField   UserSortField
+	SortDesc    bool
+	After       *UserCursor
+	Limit       uint64
 }
 
 // CarRepository определяет контракт для работы с хранилищем автомобилей.
@@ -28,8 +89,80 @@ type CarRepository interface {
 	Create(ctx context.Context, car *domain.Car) (*domain.Car, error)
 	GetByID(ctx context.Context, carID int64) (*domain.Car, error)
 	GetByUserID(ctx context.Context, userID int64) ([]*domain.Car, error)
+	ListByUserID(ctx context.Context, filter CarListFilter) ([]*domain.Car, error)
+	GetByUserIDs(ctx context.Context, userIDs []int64) ([]*domain.Car, error)
+	GetByNormalizedPlate(ctx context.Context, plate string) ([]*domain.Car, error)
 	GetSelectedByUserID(ctx context.Context, userID int64) (*domain.Car, error)
+	GetSelectedByUserIDs(ctx context.Context, userIDs []int64) ([]*domain.Car, error)
 	Update(ctx context.Context, car *domain.Car) error
 	Delete(ctx context.Context, carID int64) error
 	UnselectAllByUserID(ctx context.Context, userID int64) error
+	// LockByUserID блокирует автомобили пользователя до конца транзакции, вызывается внутри TxManager.WithTx
+	LockByUserID(ctx context.Context, userID int64) ([]*domain.Car, error)
+	// LockPlate блокирует нормализованный номер до конца транзакции, вызывается внутри TxManager.WithTx
+	LockPlate(ctx context.Context, plate string) error
+}
+
+// PlateClaimRepository определяет контракт для подачи и отзыва споров о госномерах.
+type PlateClaimRepository interface {
+	Create(ctx context.Context, claim *domain.PlateClaim) error
+	// WithdrawByCarID закрывает нерешенный спор автомобиля, если он есть
+	WithdrawByCarID(ctx context.Context, carID int64, at time.Time) error
+}
+
+// TxManager выполняет fn в транзакции; репозитории, вызванные с переданным ctx, работают внутри нее.
+// При конфликте транзакций fn может быть выполнена повторно.
+type TxManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// CarSortField порядок списка автомобилей пользователя
+type CarSortField string
+
+const (
+	CarSortCreated  CarSortField = "created"  // В порядке добавления
+	CarSortBrand    CarSortField = "brand"    // По марке и модели без учета регистра
+	CarSortSelected CarSortField = "selected" // Сначала выбранный, затем в порядке добавления
+)
+
+// CarListFilter условия выборки автомобилей пользователя.
+// При равенстве значений поля сортировки автомобили упорядочиваются по id.
+type CarListFilter struct {
+	UserID    int64
+	SortField CarSortField
+	SortDesc  bool
+}
+
+// AccessPolicy предоставляет справочник ролей и прав для проверки доступа.
+type AccessPolicy interface {
+	Authorizer(ctx context.Context) (*domain.Authorizer, error)
+}
+
+// DuplicatePhonePolicy поведение при совпадении номера телефона с номером другого пользователя
+type DuplicatePhonePolicy string
+
+const (
+	DuplicatePhoneReject DuplicatePhonePolicy = "reject" // Отклонить сохранение
+	DuplicatePhoneAllow  DuplicatePhonePolicy = "allow"  // Сохранить без отметки
+	DuplicatePhoneFlag   DuplicatePhonePolicy = "flag"   // Сохранить и отметить пользователя phone_duplicate
+)
+
+// DuplicatePlatePolicy поведение при совпадении госномера с номером автомобиля другого пользователя
+type DuplicatePlatePolicy string
+
+const (
+	DuplicatePlateReject DuplicatePlatePolicy = "reject" // Отклонить сохранение
+	DuplicatePlateAllow  DuplicatePlatePolicy = "allow"  // Сохранить, номер закрепляется за обоими
+	DuplicatePlateClaim  DuplicatePlatePolicy = "claim"  // Сохранить со спорным номером до решения менеджера
+)
+
+// PlateConfig правила закрепления госномеров
+type PlateConfig struct {
+	DuplicatePolicy DuplicatePlatePolicy
+}
+
+// PhoneConfig правила нормализации номеров телефонов
+type PhoneConfig struct {
+	DefaultRegion   string // Регион номеров без кода страны (ISO 3166-1 alpha-2)
+	DuplicatePolicy DuplicatePhonePolicy
 }
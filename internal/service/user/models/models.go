@@ -4,68 +4,190 @@ import (
 	"time"
 
 	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/pkg/mergepatch"
 )
 
 // User DTOs
 
 type CreateUserInputDTO struct {
-	TGUserID    int64       `json:"tg_user_id" validate:"required"`
-	Name        string      `json:"name" validate:"required"`
-	PhoneNumber *string     `json:"phone_number" validate:"omitempty,e164"`
-	TGLink      *string     `json:"tg_link"`
-	Role        domain.Role `json:"role" validate:"required,oneof=client manager superuser"`
+	TGUserID int64 `json:"tg_user_id" validate:"required"`
+	// Имя можно не передавать, если оно есть в профиле Telegram
+	Name string `json:"name" validate:"omitempty,max=255"`
+	// Номер в произвольном оформлении, к E.164 его приводит сервис
+	PhoneNumber  *string `json:"phone_number" validate:"omitempty,max=32"`
+	TGLink       *string `json:"tg_link" validate:"omitempty,max=255"`
+	LanguageCode *string `json:"language_code" validate:"omitempty,max=10"`
+	// Согласия, принятые пользователем в Mini App перед регистрацией
+	Consents []domain.ConsentType `json:"consents" validate:"omitempty,max=10,dive,oneof=personal_data marketing"`
+	// Telegram профиль из проверенного initData, заполняется хендлером
+	Telegram *TelegramProfileDTO `json:"-"`
 }
 
+// TelegramProfileDTO профиль пользователя из проверенного initData Telegram Mini App
+type TelegramProfileDTO struct {
+	Username     string
+	FirstName    string
+	LastName     string
+	LanguageCode string
+}
+
+type ChangeRoleInputDTO struct {
+	Role   domain.Role `json:"role" validate:"required,max=20"`
+	Reason *string     `json:"reason"`
+}
+
+type ChangeStatusInputDTO struct {
+	Status         domain.UserStatus `json:"status" validate:"required,oneof=active suspended banned"`
+	SuspendedUntil *time.Time        `json:"suspended_until"` // Обязательно для suspended
+	Reason         *string           `json:"reason"`
+}
+
+// UserStatusDTO статус аккаунта с учетом истечения приостановки
+type UserStatusDTO struct {
+	Status         domain.UserStatus `json:"status"`
+	SuspendedUntil *time.Time        `json:"suspended_until,omitempty"`
+	StatusReason   *string           `json:"status_reason,omitempty"`
+}
+
+// ListUsersInputDTO параметры списка пользователей
+type ListUsersInputDTO struct {
+	Role        *domain.Role
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	HasCar      *bool
+	Search      string
+	Sort        string // created_at, name, tg_user_id; префикс "-" - по убыванию
+	Cursor      string
+	Limit       int
+}
+
+type UsersPageDTO struct {
+	Users      []UserDTO `json:"users"`
+	NextCursor *string   `json:"next_cursor,omitempty"`
+}
+
+// BatchGetInputDTO список Telegram ID для пакетного запроса
+type BatchGetInputDTO struct {
+	TGUserIDs []int64 `json:"tg_user_ids" validate:"required"`
+}
+
+// UsersByPhoneDTO пользователи с номером телефона; при политике allow/flag их может быть несколько
+type UsersByPhoneDTO struct {
+	PhoneNumber string            `json:"phone_number"`
+	Users       []UserWithCarsDTO `json:"users"`
+}
+
+type UsersBatchDTO struct {
+	Users      []UserWithCarsDTO `json:"users"`
+	MissingIDs []int64           `json:"missing_ids"`
+}
+
+type SelectedCarsBatchDTO struct {
+	Cars []CarDTO `json:"cars"`
+	// MissingIDs пользователи без выбранного автомобиля (в том числе несуществующие)
+	MissingIDs []int64 `json:"missing_ids"`
+}
+
+// UpdateUserInputDTO частичное обновление профиля в семантике JSON Merge Patch:
+// отсутствующий ключ не меняет поле, null очищает необязательное поле
 type UpdateUserInputDTO struct {
-	Name        *string `json:"name" validate:"omitempty"`
-	PhoneNumber *string `json:"phone_number" validate:"omitempty,e164"`
-	TGLink      *string `json:"tg_link"`
+	Name         mergepatch.Field[string] `json:"name" validate:"required,max=255"`
+	PhoneNumber  mergepatch.Field[string] `json:"phone_number" validate:"omitempty,max=32"`
+	TGLink       mergepatch.Field[string] `json:"tg_link" validate:"omitempty,max=255"`
+	LanguageCode mergepatch.Field[string] `json:"language_code" validate:"omitempty,max=10"`
+}
+
+// IgnoreNulls трактует null как отсутствующий ключ (обычный application/json)
+func (in *UpdateUserInputDTO) IgnoreNulls() {
+	in.Name.IgnoreNull()
+	in.PhoneNumber.IgnoreNull()
+	in.TGLink.IgnoreNull()
+	in.LanguageCode.IgnoreNull()
 }
 
 type UserDTO struct {
-	TGUserID    int64       `json:"tg_user_id"`
-	Name        string      `json:"name"`
-	PhoneNumber *string     `json:"phone_number,omitempty"`
-	TGLink      *string     `json:"tg_link,omitempty"`
-	Role        domain.Role `json:"role"`
-	CreatedAt   time.Time   `json:"created_at"`
+	TGUserID        int64       `json:"tg_user_id"`
+	Name            string      `json:"name"`
+	PhoneNumber     *string     `json:"phone_number,omitempty"`
+	PhoneVerifiedAt *time.Time  `json:"phone_verified_at,omitempty"`
+	PhoneDuplicate  bool        `json:"phone_duplicate,omitempty"`
+	TGLink          *string     `json:"tg_link,omitempty"`
+	LanguageCode    *string     `json:"language_code,omitempty"`
+	Role            domain.Role `json:"role"`
+	UserStatusDTO
+	CreatedAt time.Time `json:"created_at"`
 }
 
+// UserWithCarsDTO профиль пользователя вместе с его автомобилями
 type UserWithCarsDTO struct {
-	TGUserID    int64       `json:"tg_user_id"`
-	Name        string      `json:"name"`
-	PhoneNumber *string     `json:"phone_number,omitempty"`
-	TGLink      *string     `json:"tg_link,omitempty"`
-	Role        domain.Role `json:"role"`
-	CreatedAt   time.Time   `json:"created_at"`
-	Cars        []CarDTO    `json:"cars"`
+	UserDTO
+	Cars []CarDTO `json:"cars"`
 }
 
 // Car DTOs
 
 type CreateCarInputDTO struct {
-	Brand        string  `json:"brand" validate:"required"`
-	Model        string  `json:"model" validate:"required"`
-	LicensePlate string  `json:"license_plate" validate:"required"`
-	Color        *string `json:"color"`
-	Size         *string `json:"size"`
+	Brand              string  `json:"brand" validate:"required,max=100"`
+	Model              string  `json:"model" validate:"required,max=100"`
+	LicensePlate       string  `json:"license_plate" validate:"required,max=20"`
+	LicensePlateFormat *string `json:"license_plate_format" validate:"omitempty,oneof=private taxi trailer motorcycle diplomatic foreign"`
+	Color              *string `json:"color" validate:"omitempty,max=50"`
+	Size               *string `json:"size" validate:"omitempty,max=50"`
 }
 
+// UpdateCarInputDTO частичное обновление автомобиля в семантике JSON Merge Patch
 type UpdateCarInputDTO struct {
-	Brand        *string `json:"brand"`
-	Model        *string `json:"model"`
-	LicensePlate *string `json:"license_plate"`
-	Color        *string `json:"color"`
-	Size         *string `json:"size"`
+	Brand              mergepatch.Field[string] `json:"brand" validate:"required,max=100"`
+	Model              mergepatch.Field[string] `json:"model" validate:"required,max=100"`
+	LicensePlate       mergepatch.Field[string] `json:"license_plate" validate:"required,max=20"`
+	LicensePlateFormat mergepatch.Field[string] `json:"license_plate_format" validate:"omitempty,oneof=private taxi trailer motorcycle diplomatic foreign"`
+	Color              mergepatch.Field[string] `json:"color" validate:"omitempty,max=50"`
+	Size               mergepatch.Field[string] `json:"size" validate:"omitempty,max=50"`
+}
+
+// IgnoreNulls трактует null как отсутствующий ключ (обычный application/json)
+func (in *UpdateCarInputDTO) IgnoreNulls() {
+	in.Brand.IgnoreNull()
+	in.Model.IgnoreNull()
+	in.LicensePlate.IgnoreNull()
+	in.LicensePlateFormat.IgnoreNull()
+	in.Color.IgnoreNull()
+	in.Size.IgnoreNull()
+}
+
+// CarsByPlateDTO автомобили с нормализованным номером; у разных владельцев номер может совпадать
+type CarsByPlateDTO struct {
+	LicensePlate string            `json:"license_plate_normalized"`
+	Cars         []CarWithOwnerDTO `json:"cars"`
+}
+
+type CarWithOwnerDTO struct {
+	CarDTO
+	Owner CarOwnerDTO `json:"owner"`
+}
+
+// CarOwnerDTO краткие данные владельца автомобиля
+type CarOwnerDTO struct {
+	TGUserID    int64   `json:"tg_user_id"`
+	Name        string  `json:"name"`
+	PhoneNumber *string `json:"phone_number,omitempty"`
+	TGLink      *string `json:"tg_link,omitempty"`
+}
+
+type CarsDTO struct {
+	Cars []CarDTO `json:"cars"`
 }
 
 type CarDTO struct {
-	ID           int64   `json:"id"`
-	UserID       int64   `json:"user_id"`
-	Brand        string  `json:"brand"`
-	Model        string  `json:"model"`
-	LicensePlate string  `json:"license_plate"`
-	Color        *string `json:"color,omitempty"`
-	Size         *string `json:"size,omitempty"`
-	IsSelected   bool    `json:"is_selected"`
+	ID                     int64   `json:"id"`
+	UserID                 int64   `json:"user_id"`
+	Brand                  string  `json:"brand"`
+	Model                  string  `json:"model"`
+	LicensePlate           string  `json:"license_plate"`
+	LicensePlateNormalized string  `json:"license_plate_normalized"`
+	LicensePlateFormat     string  `json:"license_plate_format"`
+	Color                  *string `json:"color,omitempty"`
+	Size                   *string `json:"size,omitempty"`
+	IsSelected             bool    `json:"is_selected"`
+	LicensePlateStatus     string  `json:"license_plate_status"`
 }
@@ -2,32 +2,54 @@ package user
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/m04kA/SMC-UserService/internal/domain"
 	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+	"github.com/m04kA/SMC-UserService/pkg/licenseplate"
+	"github.com/m04kA/SMC-UserService/pkg/phonenumber"
+	"github.com/m04kA/SMC-UserService/pkg/validator"
+)
+
+const (
+	defaultUserListLimit = 50
+	maxUserListLimit     = 200
 )
 
 var (
-	ErrServiceCreateUser = errors.New("service: failed to create user")
-	ErrServiceGetUser    = errors.New("service: failed to get user")
-	ErrServiceUpdateUser = errors.New("service: failed to update user")
-	ErrServiceDeleteUser = errors.New("service: failed to delete user")
-	ErrServiceCreateCar  = errors.New("service: failed to create car")
-	ErrServiceGetCar     = errors.New("service: failed to get car")
-	ErrServiceUpdateCar  = errors.New("service: failed to update car")
-	ErrServiceDeleteCar  = errors.New("service: failed to delete car")
+	ErrServiceCreateUser  = errors.New("service: failed to create user")
+	ErrServiceGetUser     = errors.New("service: failed to get user")
+	ErrServiceUpdateUser  = errors.New("service: failed to update user")
+	ErrServiceDeleteUser  = errors.New("service: failed to delete user")
+	ErrServiceRestoreUser = errors.New("service: failed to restore user")
+	ErrServicePurgeUsers  = errors.New("service: failed to purge deleted users")
+	ErrServiceCreateCar   = errors.New("service: failed to create car")
+	ErrServiceGetCar      = errors.New("service: failed to get car")
+	ErrServiceUpdateCar   = errors.New("service: failed to update car")
+	ErrServiceDeleteCar   = errors.New("service: failed to delete car")
+	ErrServiceFileClaim   = errors.New("service: failed to file plate claim")
 )
 
 type Service struct {
-	userRepo UserRepository
-	carRepo  CarRepository
+	userRepo  UserRepository
+	carRepo   CarRepository
+	claimRepo PlateClaimRepository
+	policy    AccessPolicy
+	tx        TxManager
+
+	// deletionRetention срок, в течение которого удаленный аккаунт можно восстановить
+	deletionRetention time.Duration
+	phones            PhoneConfig
+	plates            PlateConfig
 }
 
-func NewUserService(ur UserRepository, cr CarRepository) *Service {
-	return &Service{userRepo: ur, carRepo: cr}
+func NewUserService(ur UserRepository, cr CarRepository, claims PlateClaimRepository, policy AccessPolicy, tx TxManager, deletionRetention time.Duration, phones PhoneConfig, plates PlateConfig) *Service {
+	return &Service{userRepo: ur, carRepo: cr, claimRepo: claims, policy: policy, tx: tx, deletionRetention: deletionRetention, phones: phones, plates: plates}
 }
 
 // CreateUser создает нового пользователя
@@ -40,82 +62,185 @@ func (s *Service) CreateUser(ctx context.Context, input models.CreateUserInputDT
 		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
 	}
 
-	// Маппинг роли в role_id
-	roleID := roleToID(input.Role)
-
-	user := &domain.User{
-		TGUserID:    input.TGUserID,
-		Name:        input.Name,
-		PhoneNumber: input.PhoneNumber,
-		TGLink:      input.TGLink,
-		RoleID:      roleID,
-		Role:        input.Role,
-		CreatedAt:   time.Now(),
+	// Удаленный аккаунт занимает Telegram ID до очистки, его можно только восстановить
+	_, err = s.userRepo.GetDeletedByTGID(ctx, input.TGUserID)
+	if err == nil {
+		return nil, ErrUserDeleted
+	}
+	if !errors.Is(err, ErrUserNotFound) {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
 	}
 
-	if err = s.userRepo.Create(ctx, user); err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrServiceCreateUser, err)
+	// Незаполненные поля берем из профиля Telegram
+	if input.Telegram != nil {
+		applyTelegramProfile(&input, *input.Telegram)
 	}
 
-	response := &models.UserDTO{
-		TGUserID:    user.TGUserID,
-		Name:        user.Name,
-		PhoneNumber: user.PhoneNumber,
-		TGLink:      user.TGLink,
-		Role:        user.Role,
-		CreatedAt:   user.CreatedAt,
+	// Номер проверяется и сохраняется в одной транзакции под блокировкой номера
+	var user *domain.User
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		// Публичная регистрация всегда создает клиента, роль меняет только суперпользователь
+		user = &domain.User{
+			TGUserID:     input.TGUserID,
+			Name:         input.Name,
+			TGLink:       input.TGLink,
+			LanguageCode: input.LanguageCode,
+			RoleID:       domain.RoleIDClient,
+			Role:         domain.RoleClient,
+			Status:       domain.UserStatusActive,
+			CreatedAt:    time.Now(),
+		}
+
+		if err := s.applyPhoneNumber(ctx, user, input.PhoneNumber); err != nil {
+			return err
+		}
+
+		// Итоговая сущность проверяется после подстановки данных Telegram и нормализации номера
+		if err := validator.Struct(user); err != nil {
+			return err
+		}
+
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return fmt.Errorf("%w: %v", ErrServiceCreateUser, err)
+		}
+		if err := s.userRepo.AddConsents(ctx, user.TGUserID, uniqueConsents(input.Consents), user.CreatedAt); err != nil {
+			return fmt.Errorf("%w: %v", ErrServiceCreateUser, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	response := toUserDTO(user, time.Now())
+	return &response, nil
 }
 
-// UpdateUser обновляет данные пользователя (частичное обновление)
+// UpdateUser обновляет данные пользователя (частичное обновление).
+// Изменение номера проверяется и сохраняется в одной транзакции под блокировкой номера.
 func (s *Service) UpdateUser(ctx context.Context, tgID int64, input models.UpdateUserInputDTO) (*models.UserDTO, error) {
-	user, err := s.userRepo.GetByTGID(ctx, tgID)
+	var user *domain.User
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.userRepo.GetByTGID(ctx, tgID)
+		if err != nil {
+			if errors.Is(err, ErrUserNotFound) {
+				return err
+			}
+			return fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+		}
+
+		// Обновляем только те поля, которые переданы; null очищает необязательные поля
+		// null для имени отклоняет правило required при проверке пользователя (422 с errors[].field)
+		if input.Name.Present() {
+			user.Name, _ = input.Name.Value()
+		}
+		if input.PhoneNumber.Present() {
+			previous := user.PhoneNumber
+			if err = s.applyPhoneNumber(ctx, user, input.PhoneNumber.Ptr()); err != nil {
+				return err
+			}
+			// Подтверждение относится к номеру, новый номер нужно подтвердить заново
+			if previous == nil || user.PhoneNumber == nil || *previous != *user.PhoneNumber {
+				user.PhoneVerifiedAt = nil
+			}
+		}
+		if input.TGLink.Present() {
+			user.TGLink = input.TGLink.Ptr()
+		}
+		if input.LanguageCode.Present() {
+			user.LanguageCode = input.LanguageCode.Ptr()
+		}
+
+		if err = validator.Struct(user); err != nil {
+			return err
+		}
+
+		if err = s.userRepo.Update(ctx, user); err != nil {
+			return fmt.Errorf("%w: %v", ErrServiceUpdateUser, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toUserDTO(user, time.Now())
+	return &response, nil
+}
+
+// DeleteUser помечает пользователя удаленным; до окончания срока хранения аккаунт можно восстановить.
+// Последний суперпользователь удалить себя не может, как и снять с себя роль (ErrLastSuperUser).
+func (s *Service) DeleteUser(ctx context.Context, tgID int64) error {
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		user, err := s.userRepo.GetByTGID(ctx, tgID)
+		if err != nil {
+			if errors.Is(err, ErrUserNotFound) {
+				return err
+			}
+			return fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+		}
+
+		if user.RoleID == domain.RoleIDSuperUser {
+			// Блокировка списка суперпользователей упорядочивает удаление с одновременным снятием роли
+			superUsers, err := s.userRepo.LockSuperUsers(ctx)
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrServiceDeleteUser, err)
+			}
+			if len(superUsers) <= 1 {
+				return ErrLastSuperUser
+			}
+		}
+
+		if err = s.userRepo.SoftDelete(ctx, tgID, time.Now()); err != nil {
+			if errors.Is(err, ErrUserNotFound) {
+				return err
+			}
+			return fmt.Errorf("%w: %v", ErrServiceDeleteUser, err)
+		}
+		return nil
+	})
+}
+
+// RestoreUser восстанавливает удаленного пользователя вместе с его автомобилями
+func (s *Service) RestoreUser(ctx context.Context, tgID int64) (*models.UserDTO, error) {
+	user, err := s.userRepo.GetDeletedByTGID(ctx, tgID)
 	if err != nil {
+		if !errors.Is(err, ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+		}
+		if _, err = s.userRepo.GetByTGID(ctx, tgID); err == nil {
+			return nil, ErrUserNotDeleted
+		}
 		if errors.Is(err, ErrUserNotFound) {
 			return nil, err
 		}
 		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
 	}
 
-	// Обновляем только те поля, которые переданы
-	if input.Name != nil {
-		user.Name = *input.Name
-	}
-	if input.PhoneNumber != nil {
-		user.PhoneNumber = input.PhoneNumber
-	}
-	if input.TGLink != nil {
-		user.TGLink = input.TGLink
+	deletedAfter := time.Now().Add(-s.deletionRetention)
+	if !user.DeletedAt.After(deletedAfter) {
+		return nil, ErrRestoreExpired
 	}
 
-	if err = s.userRepo.Update(ctx, user); err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrServiceUpdateUser, err)
-	}
-
-	response := &models.UserDTO{
-		TGUserID:    user.TGUserID,
-		Name:        user.Name,
-		PhoneNumber: user.PhoneNumber,
-		TGLink:      user.TGLink,
-		Role:        user.Role,
-		CreatedAt:   user.CreatedAt,
+	if err = s.userRepo.Restore(ctx, tgID, deletedAfter); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceRestoreUser, err)
 	}
 
-	return response, nil
+	response := toUserDTO(user, time.Now())
+	return &response, nil
 }
 
-// DeleteUser удаляет пользователя
-func (s *Service) DeleteUser(ctx context.Context, tgID int64) error {
-	err := s.userRepo.Delete(ctx, tgID)
+// PurgeDeletedUsers окончательно удаляет пользователей, срок восстановления которых истек
+func (s *Service) PurgeDeletedUsers(ctx context.Context) (int64, error) {
+	purged, err := s.userRepo.PurgeDeleted(ctx, time.Now().Add(-s.deletionRetention))
 	if err != nil {
-		if errors.Is(err, ErrUserNotFound) {
-			return err
-		}
-		return fmt.Errorf("%w: %v", ErrServiceDeleteUser, err)
+		return 0, fmt.Errorf("%w: %v", ErrServicePurgeUsers, err)
 	}
-	return nil
+	return purged, nil
 }
 
 // GetUserByID получает пользователя по ID
@@ -128,16 +253,8 @@ func (s *Service) GetUserByID(ctx context.Context, tgID int64) (*models.UserDTO,
 		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
 	}
 
-	response := &models.UserDTO{
-		TGUserID:    user.TGUserID,
-		Name:        user.Name,
-		PhoneNumber: user.PhoneNumber,
-		TGLink:      user.TGLink,
-		Role:        user.Role,
-		CreatedAt:   user.CreatedAt,
-	}
-
-	return response, nil
+	response := toUserDTO(user, time.Now())
+	return &response, nil
 }
 
 // GetUserWithCars получает пользователя со всеми его автомобилями
@@ -157,31 +274,273 @@ func (s *Service) GetUserWithCars(ctx context.Context, tgID int64) (*models.User
 
 	carDTOs := make([]models.CarDTO, 0, len(cars))
 	for _, car := range cars {
-		carDTOs = append(carDTOs, models.CarDTO{
-			ID:           car.ID,
-			UserID:       car.UserID,
-			Brand:        car.Brand,
-			Model:        car.Model,
-			LicensePlate: car.LicensePlate,
-			Color:        car.Color,
-			Size:         car.Size,
-			IsSelected:   car.IsSelected,
-		})
+		carDTOs = append(carDTOs, toCarDTO(car))
 	}
 
 	response := &models.UserWithCarsDTO{
-		TGUserID:    user.TGUserID,
-		Name:        user.Name,
-		PhoneNumber: user.PhoneNumber,
-		TGLink:      user.TGLink,
-		Role:        user.Role,
-		CreatedAt:   user.CreatedAt,
-		Cars:        carDTOs,
+		UserDTO: toUserDTO(user, time.Now()),
+		Cars:    carDTOs,
+	}
+
+	return response, nil
+}
+
+// GetUsersWithCars получает пользователей с автомобилями по списку ID двумя запросами.
+// Порядок соответствует запросу (без повторов), ненайденные ID возвращаются отдельно.
+func (s *Service) GetUsersWithCars(ctx context.Context, tgIDs []int64) (*models.UsersBatchDTO, error) {
+	tgIDs = uniqueIDs(tgIDs)
+
+	users, err := s.userRepo.GetByTGIDs(ctx, tgIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+
+	cars, err := s.carRepo.GetByUserIDs(ctx, tgIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+	}
+
+	carsByUser := make(map[int64][]models.CarDTO, len(users))
+	for _, car := range cars {
+		carsByUser[car.UserID] = append(carsByUser[car.UserID], toCarDTO(car))
+	}
+
+	usersByID := make(map[int64]*domain.User, len(users))
+	for _, user := range users {
+		usersByID[user.TGUserID] = user
+	}
+
+	now := time.Now()
+	response := &models.UsersBatchDTO{
+		Users:      make([]models.UserWithCarsDTO, 0, len(users)),
+		MissingIDs: []int64{},
+	}
+	for _, id := range tgIDs {
+		user, ok := usersByID[id]
+		if !ok {
+			response.MissingIDs = append(response.MissingIDs, id)
+			continue
+		}
+
+		userCars := carsByUser[id]
+		if userCars == nil {
+			userCars = []models.CarDTO{}
+		}
+		response.Users = append(response.Users, models.UserWithCarsDTO{
+			UserDTO: toUserDTO(user, now),
+			Cars:    userCars,
+		})
+	}
+
+	return response, nil
+}
+
+// GetUsersByPhone находит пользователей с автомобилями по номеру телефона в любом допустимом формате
+func (s *Service) GetUsersByPhone(ctx context.Context, phoneNumber string) (*models.UsersByPhoneDTO, error) {
+	normalized, err := phonenumber.Normalize(phoneNumber, s.phones.DefaultRegion)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPhone, err)
+	}
+
+	users, err := s.userRepo.GetByPhoneNumber(ctx, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+	if len(users) == 0 {
+		return nil, ErrUserNotFound
+	}
+
+	tgIDs := make([]int64, 0, len(users))
+	for _, user := range users {
+		tgIDs = append(tgIDs, user.TGUserID)
+	}
+
+	batch, err := s.GetUsersWithCars(ctx, tgIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.UsersByPhoneDTO{
+		PhoneNumber: normalized,
+		Users:       batch.Users,
+	}
+
+	return response, nil
+}
+
+// FindCarsByPlate находит автомобили по номеру в любом написании (licenseplate.Normalize)
+// вместе с владельцами. Пустой список - номер не найден.
+func (s *Service) FindCarsByPlate(ctx context.Context, plate string) (*models.CarsByPlateDTO, error) {
+	normalized := licenseplate.Normalize(plate)
+	if normalized == "" {
+		return nil, fmt.Errorf("%w: empty plate", ErrInvalidPlate)
+	}
+
+	cars, err := s.carRepo.GetByNormalizedPlate(ctx, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+	}
+
+	response := &models.CarsByPlateDTO{
+		LicensePlate: normalized,
+		Cars:         make([]models.CarWithOwnerDTO, 0, len(cars)),
+	}
+	if len(cars) == 0 {
+		return response, nil
+	}
+
+	ownerIDs := make([]int64, 0, len(cars))
+	for _, car := range cars {
+		ownerIDs = append(ownerIDs, car.UserID)
+	}
+	owners, err := s.userRepo.GetByTGIDs(ctx, uniqueIDs(ownerIDs))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+	ownersByID := make(map[int64]*domain.User, len(owners))
+	for _, owner := range owners {
+		ownersByID[owner.TGUserID] = owner
+	}
+
+	for _, car := range cars {
+		owner, ok := ownersByID[car.UserID]
+		if !ok {
+			// Владелец удален между запросами
+			continue
+		}
+		response.Cars = append(response.Cars, models.CarWithOwnerDTO{
+			CarDTO: toCarDTO(car),
+			Owner: models.CarOwnerDTO{
+				TGUserID:    owner.TGUserID,
+				Name:        owner.Name,
+				PhoneNumber: owner.PhoneNumber,
+				TGLink:      owner.TGLink,
+			},
+		})
 	}
 
 	return response, nil
 }
 
+// ChangeUserRole меняет роль пользователя от имени суперпользователя actorID и записывает изменение в историю
+func (s *Service) ChangeUserRole(ctx context.Context, actorID, tgID int64, input models.ChangeRoleInputDTO) (*models.UserDTO, error) {
+	authz, err := s.policy.Authorizer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceUpdateUser, err)
+	}
+
+	roleID, ok := authz.RoleID(input.Role)
+	if !ok {
+		return nil, ErrInvalidRole
+	}
+
+	change := &domain.RoleChange{
+		TGUserID:  tgID,
+		NewRoleID: roleID,
+		ChangedBy: actorID,
+		Reason:    input.Reason,
+		ChangedAt: time.Now(),
+	}
+
+	// Внутри транзакции вызывающего (например, принятия приглашения) смена роли выполняется в ней же
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.ChangeRole(ctx, change); err != nil {
+			if errors.Is(err, ErrUserNotFound) || errors.Is(err, ErrLastSuperUser) {
+				return err
+			}
+			return fmt.Errorf("%w: %v", ErrServiceUpdateUser, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetUserByID(ctx, tgID)
+}
+
+// ChangeUserStatus блокирует, приостанавливает или разблокирует пользователя от имени actorID
+func (s *Service) ChangeUserStatus(ctx context.Context, actorID, tgID int64, input models.ChangeStatusInputDTO) (*models.UserDTO, error) {
+	if !input.Status.IsValid() {
+		return nil, ErrInvalidStatus
+	}
+	if actorID == tgID {
+		return nil, ErrOwnStatusChange
+	}
+
+	now := time.Now()
+	if input.Status == domain.UserStatusSuspended && (input.SuspendedUntil == nil || !input.SuspendedUntil.After(now)) {
+		return nil, ErrSuspensionExpired
+	}
+
+	user, err := s.userRepo.GetByTGID(ctx, tgID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+
+	user.Status = input.Status
+	user.SuspendedUntil = nil
+	if input.Status == domain.UserStatusSuspended {
+		user.SuspendedUntil = input.SuspendedUntil
+	}
+	user.StatusReason = input.Reason
+	user.StatusChangedBy = &actorID
+	user.StatusChangedAt = &now
+
+	if err = s.userRepo.UpdateStatus(ctx, user); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceUpdateUser, err)
+	}
+
+	return s.GetUserByID(ctx, tgID)
+}
+
+// GetUserStatus возвращает статус аккаунта; незарегистрированный пользователь считается активным
+func (s *Service) GetUserStatus(ctx context.Context, tgID int64) (*models.UserStatusDTO, error) {
+	user, err := s.userRepo.GetByTGID(ctx, tgID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return &models.UserStatusDTO{Status: domain.UserStatusActive}, nil
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+
+	status := toUserStatusDTO(user, time.Now())
+	return &status, nil
+}
+
+// GetUserLanguage возвращает язык из профиля пользователя; для незарегистрированного или без языка - пустую строку
+func (s *Service) GetUserLanguage(ctx context.Context, tgID int64) (string, error) {
+	user, err := s.userRepo.GetByTGID(ctx, tgID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+	if user.LanguageCode == nil {
+		return "", nil
+	}
+	return *user.LanguageCode, nil
+}
+
+// GetUserRole возвращает роль пользователя; незарегистрированный пользователь считается клиентом
+func (s *Service) GetUserRole(ctx context.Context, tgID int64) (domain.Role, error) {
+	user, err := s.userRepo.GetByTGID(ctx, tgID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return domain.RoleClient, nil
+		}
+		return "", fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+	return user.Role, nil
+}
+
 // GetSuperUsers возвращает список tg_user_id всех суперпользователей
 func (s *Service) GetSuperUsers(ctx context.Context) ([]int64, error) {
 	userIDs, err := s.userRepo.GetSuperUsers(ctx)
@@ -191,17 +550,219 @@ func (s *Service) GetSuperUsers(ctx context.Context) ([]int64, error) {
 	return userIDs, nil
 }
 
-// roleToID маппит роль в ID для БД
-func roleToID(role domain.Role) int {
-	switch role {
-	case domain.RoleClient:
-		return domain.RoleIDClient
-	case domain.RoleManager:
-		return domain.RoleIDManager
-	case domain.RoleSuperUser:
-		return domain.RoleIDSuperUser
-	default:
-		return domain.RoleIDClient // default client
+// ListUsers возвращает страницу пользователей по фильтру с курсорной пагинацией
+func (s *Service) ListUsers(ctx context.Context, input models.ListUsersInputDTO) (*models.UsersPageDTO, error) {
+	filter := UserListFilter{
+		Role:        input.Role,
+		CreatedFrom: input.CreatedFrom,
+		CreatedTo:   input.CreatedTo,
+		HasCar:      input.HasCar,
+		Search:      strings.TrimSpace(input.Search),
+		SortField:   UserSortCreatedAt,
+		SortDesc:    true,
+		Limit:       defaultUserListLimit,
+	}
+
+	if input.Role != nil && !input.Role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+	if input.CreatedFrom != nil && input.CreatedTo != nil && input.CreatedFrom.After(*input.CreatedTo) {
+		return nil, fmt.Errorf("%w: created_from is after created_to", ErrInvalidFilter)
+	}
+
+	if input.Sort != "" {
+		field := strings.TrimPrefix(input.Sort, "-")
+		switch UserSortField(field) {
+		case UserSortCreatedAt, UserSortName, UserSortTGUserID:
+			filter.SortField = UserSortField(field)
+			filter.SortDesc = strings.HasPrefix(input.Sort, "-")
+		default:
+			return nil, fmt.Errorf("%w: unsupported sort %q", ErrInvalidFilter, input.Sort)
+		}
+	}
+
+	if input.Limit < 0 {
+		return nil, fmt.Errorf("%w: limit must be positive", ErrInvalidFilter)
+	}
+	if input.Limit > 0 {
+		filter.Limit = uint64(min(input.Limit, maxUserListLimit))
+	}
+
+	if input.Cursor != "" {
+		cursor, err := decodeUserCursor(input.Cursor, filter.SortField, filter.SortDesc)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = cursor
+	}
+
+	limit := filter.Limit
+	filter.Limit = limit + 1 // Лишняя запись показывает, что есть следующая страница
+	users, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+
+	page := &models.UsersPageDTO{Users: make([]models.UserDTO, 0, len(users))}
+	if uint64(len(users)) > limit {
+		users = users[:limit]
+		next := encodeUserCursor(users[len(users)-1], filter.SortField, filter.SortDesc)
+		page.NextCursor = &next
+	}
+
+	now := time.Now()
+	for _, user := range users {
+		page.Users = append(page.Users, toUserDTO(user, now))
+	}
+
+	return page, nil
+}
+
+// userCursorPayload содержимое курсора; сортировка сохраняется, чтобы курсор нельзя было применить к другому порядку
+type userCursorPayload struct {
+	Sort      UserSortField `json:"s"`
+	Desc      bool          `json:"d,omitempty"`
+	CreatedAt time.Time     `json:"c"`
+	Name      string        `json:"n,omitempty"`
+	TGUserID  int64         `json:"id"`
+}
+
+func encodeUserCursor(user *domain.User, field UserSortField, desc bool) string {
+	payload, _ := json.Marshal(userCursorPayload{
+		Sort:      field,
+		Desc:      desc,
+		CreatedAt: user.CreatedAt,
+		Name:      user.Name,
+		TGUserID:  user.TGUserID,
+	})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeUserCursor(raw string, field UserSortField, desc bool) (*UserCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var payload userCursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if payload.Sort != field || payload.Desc != desc {
+		return nil, fmt.Errorf("%w: cursor was issued for another sort order", ErrInvalidCursor)
+	}
+
+	return &UserCursor{
+		CreatedAt: payload.CreatedAt,
+		Name:      payload.Name,
+		TGUserID:  payload.TGUserID,
+	}, nil
+}
+
+// toUserDTO собирает профиль пользователя для ответа; статус считается на момент now
+func toUserDTO(user *domain.User, now time.Time) models.UserDTO {
+	return models.UserDTO{
+		TGUserID:        user.TGUserID,
+		Name:            user.Name,
+		PhoneNumber:     user.PhoneNumber,
+		PhoneVerifiedAt: user.PhoneVerifiedAt,
+		PhoneDuplicate:  user.PhoneDuplicate,
+		TGLink:          user.TGLink,
+		LanguageCode:    user.LanguageCode,
+		Role:            user.Role,
+		UserStatusDTO:   toUserStatusDTO(user, now),
+		CreatedAt:       user.CreatedAt,
+	}
+}
+
+// toUserStatusDTO возвращает статус с учетом истечения приостановки; для активного аккаунта причина не показывается
+func toUserStatusDTO(user *domain.User, now time.Time) models.UserStatusDTO {
+	status := user.EffectiveStatus(now)
+	if status == domain.UserStatusActive {
+		return models.UserStatusDTO{Status: status}
+	}
+	return models.UserStatusDTO{
+		Status:         status,
+		SuspendedUntil: user.SuspendedUntil,
+		StatusReason:   user.StatusReason,
+	}
+}
+
+// applyPhoneNumber нормализует номер к E.164 и применяет политику совпадения номеров между пользователями.
+// Пустая строка удаляет номер, прежний номер сохраняется без повторной проверки.
+// Вызывается внутри TxManager.WithTx: номер блокируется до сохранения пользователя, а удаленные аккаунты
+// продолжают занимать номер, чтобы восстановление не создало совпадение.
+func (s *Service) applyPhoneNumber(ctx context.Context, user *domain.User, raw *string) error {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		user.PhoneNumber = nil
+		user.PhoneDuplicate = false
+		return nil
+	}
+
+	normalized, err := phonenumber.Normalize(*raw, s.phones.DefaultRegion)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPhone, err)
+	}
+	if user.PhoneNumber != nil && *user.PhoneNumber == normalized {
+		return nil
+	}
+	user.PhoneNumber = &normalized
+	user.PhoneDuplicate = false
+
+	if s.phones.DuplicatePolicy == DuplicatePhoneAllow {
+		return nil
+	}
+
+	if err = s.userRepo.LockPhoneNumber(ctx, normalized); err != nil {
+		return fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+	owners, err := s.userRepo.GetPhoneOwners(ctx, normalized)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+	for _, ownerID := range owners {
+		if ownerID == user.TGUserID {
+			continue
+		}
+		if s.phones.DuplicatePolicy == DuplicatePhoneReject {
+			return ErrPhoneNumberTaken
+		}
+		user.PhoneDuplicate = true
+		break
+	}
+
+	return nil
+}
+
+// uniqueConsents убирает повторы согласий, сохраняя порядок
+func uniqueConsents(consents []domain.ConsentType) []domain.ConsentType {
+	seen := make(map[domain.ConsentType]struct{}, len(consents))
+	result := make([]domain.ConsentType, 0, len(consents))
+	for _, consent := range consents {
+		if _, ok := seen[consent]; ok {
+			continue
+		}
+		seen[consent] = struct{}{}
+		result = append(result, consent)
+	}
+	return result
+}
+
+// applyTelegramProfile заполняет имя, ссылку и язык из профиля Telegram, если они не переданы
+func applyTelegramProfile(input *models.CreateUserInputDTO, profile models.TelegramProfileDTO) {
+	if input.Name == "" {
+		input.Name = strings.TrimSpace(profile.FirstName + " " + profile.LastName)
+	}
+	if input.Name == "" {
+		input.Name = profile.Username
+	}
+	if input.TGLink == nil && profile.Username != "" {
+		link := "@" + profile.Username
+		input.TGLink = &link
+	}
+	if input.LanguageCode == nil && profile.LanguageCode != "" {
+		lang := profile.LanguageCode
+		input.LanguageCode = &lang
 	}
 }
 
@@ -215,42 +776,60 @@ func (s *Service) CreateCar(ctx context.Context, tgID int64, input models.Create
 		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
 	}
 
-	// Проверяем, есть ли уже автомобили у пользователя
-	existingCars, err := s.carRepo.GetByUserID(ctx, tgID)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+	car := &domain.Car{
+		UserID: tgID,
+		Brand:  input.Brand,
+		Model:  input.Model,
+		Color:  input.Color,
+		Size:   input.Size,
 	}
 
-	// Если это первый автомобиль, он автоматически становится выбранным
-	isSelected := len(existingCars) == 0
-
-	car := &domain.Car{
-		UserID:       tgID,
-		Brand:        input.Brand,
-		Model:        input.Model,
-		LicensePlate: input.LicensePlate,
-		Color:        input.Color,
-		Size:         input.Size,
-		IsSelected:   isSelected,
+	var format string
+	if input.LicensePlateFormat != nil {
+		format = *input.LicensePlateFormat
+	}
+	if err = applyLicensePlate(car, input.LicensePlate, format); err != nil {
+		return nil, err
 	}
 
-	createdCar, err := s.carRepo.Create(ctx, car)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrServiceCreateCar, err)
+	if err = validator.Struct(car); err != nil {
+		return nil, err
 	}
 
-	response := &models.CarDTO{
-		ID:           createdCar.ID,
-		UserID:       createdCar.UserID,
-		Brand:        createdCar.Brand,
-		Model:        createdCar.Model,
-		LicensePlate: createdCar.LicensePlate,
-		Color:        createdCar.Color,
-		Size:         createdCar.Size,
-		IsSelected:   createdCar.IsSelected,
+	var createdCar *domain.Car
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		// Блокировка не дает двум параллельным запросам одновременно добавить "первый" автомобиль
+		existingCars, err := s.lockUserCars(ctx, tgID)
+		if err != nil {
+			return err
+		}
+
+		// Если это первый автомобиль (или остальные ожидают решения спора), он автоматически становится выбранным
+		car.IsSelected = !hasSelectedCar(existingCars)
+
+		holder, err := s.applyPlatePolicy(ctx, car)
+		if err != nil {
+			return err
+		}
+
+		createdCar, err = s.carRepo.Create(ctx, car)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrServiceCreateCar, err)
+		}
+		if holder != nil {
+			return s.fileClaim(ctx, createdCar, holder)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrCarNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
 	}
 
-	return response, nil
+	response := toCarDTO(createdCar)
+	return &response, nil
 }
 
 // UpdateCar обновляет автомобиль (PATCH) с проверкой роли
@@ -263,44 +842,218 @@ func (s *Service) UpdateCar(ctx context.Context, tgID int64, carID int64, input
 		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
 	}
 
-	// Проверка доступа: владелец может изменять свою машину, superuser - любую
-	if !role.CanModifyUser(car.UserID, tgID) {
+	// Проверка доступа: владелец может изменять свою машину, роль с cars:update:any - любую
+	authz, err := s.policy.Authorizer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceUpdateCar, err)
+	}
+	if !authz.CanUpdateCar(role, car.UserID, tgID) {
 		return nil, ErrCarAccessDenied
 	}
 
-	if input.Brand != nil {
-		car.Brand = *input.Brand
+	ownerID := car.UserID
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		// Изменения применяются к заблокированной строке, чтобы не затереть is_selected,
+		// измененный параллельным запросом после чтения выше
+		cars, err := s.lockUserCars(ctx, ownerID)
+		if err != nil {
+			return err
+		}
+		car = findCar(cars, carID)
+		if car == nil {
+			return ErrCarNotFound
+		}
+
+		previousPlate, previousStatus, wasSelected := car.LicensePlateNormalized, car.LicensePlateStatus, car.IsSelected
+		if err = applyCarPatch(car, input); err != nil {
+			return err
+		}
+		if err = validator.Struct(car); err != nil {
+			return err
+		}
+
+		// Новый номер проверяется политикой совпадения номеров, спор о прежнем номере отзывается
+		var holder *domain.Car
+		if car.LicensePlateNormalized != previousPlate {
+			if previousStatus == domain.PlateStatusPendingClaim {
+				if err = s.claimRepo.WithdrawByCarID(ctx, car.ID, time.Now()); err != nil {
+					return fmt.Errorf("%w: %v", ErrServiceUpdateCar, err)
+				}
+				car.IsSelected = !hasSelectedCar(cars)
+			}
+			if holder, err = s.applyPlatePolicy(ctx, car); err != nil {
+				return err
+			}
+		}
+
+		if err = s.carRepo.Update(ctx, car); err != nil {
+			return fmt.Errorf("%w: %v", ErrServiceUpdateCar, err)
+		}
+		if holder != nil {
+			if err = s.fileClaim(ctx, car, holder); err != nil {
+				return err
+			}
+		}
+		// Выбранный автомобиль получил спорный номер: выбирается первый из остальных
+		if wasSelected && !car.IsSelected {
+			return s.selectReplacement(ctx, cars, car.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toCarDTO(car)
+	return &response, nil
+}
+
+// applyPlatePolicy применяет политику совпадения госномеров к автомобилю с новым номером.
+// По политике claim автомобиль с номером, закрепленным за другим пользователем, получает статус pending_claim
+// и снимается с выбора; возвращается автомобиль владельца номера, по которому нужно подать спор (fileClaim).
+// Вызывается внутри TxManager.WithTx: номер блокируется до конца транзакции.
+func (s *Service) applyPlatePolicy(ctx context.Context, car *domain.Car) (*domain.Car, error) {
+	car.LicensePlateStatus = domain.PlateStatusConfirmed
+	if s.plates.DuplicatePolicy == DuplicatePlateAllow {
+		return nil, nil
+	}
+
+	if err := s.carRepo.LockPlate(ctx, car.LicensePlateNormalized); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+	}
+	holders, err := s.carRepo.GetByNormalizedPlate(ctx, car.LicensePlateNormalized)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+	}
+	for _, holder := range holders {
+		if holder.UserID == car.UserID {
+			continue
+		}
+		if s.plates.DuplicatePolicy == DuplicatePlateReject {
+			return nil, ErrPlateTaken
+		}
+		car.LicensePlateStatus = domain.PlateStatusPendingClaim
+		car.IsSelected = false
+		return holder, nil
+	}
+
+	return nil, nil
+}
+
+// fileClaim подает спор автомобиля car о номере, закрепленном за автомобилем holder
+func (s *Service) fileClaim(ctx context.Context, car, holder *domain.Car) error {
+	claim := &domain.PlateClaim{
+		CarID:        &car.ID,
+		ClaimantID:   car.UserID,
+		HolderCarID:  &holder.ID,
+		HolderID:     holder.UserID,
+		LicensePlate: car.LicensePlateNormalized,
+		Status:       domain.PlateClaimPending,
+		CreatedAt:    time.Now(),
 	}
-	if input.Model != nil {
-		car.Model = *input.Model
+	if err := s.claimRepo.Create(ctx, claim); err != nil {
+		return fmt.Errorf("%w: %v", ErrServiceFileClaim, err)
 	}
-	if input.LicensePlate != nil {
-		car.LicensePlate = *input.LicensePlate
+	return nil
+}
+
+// selectReplacement выбирает первый автомобиль с закрепленным номером, кроме excludeID
+func (s *Service) selectReplacement(ctx context.Context, cars []*domain.Car, excludeID int64) error {
+	for _, c := range cars {
+		if c.ID == excludeID || c.LicensePlateStatus != domain.PlateStatusConfirmed {
+			continue
+		}
+		c.IsSelected = true
+		if err := s.carRepo.Update(ctx, c); err != nil {
+			return fmt.Errorf("%w: %v", ErrServiceUpdateCar, err)
+		}
+		return nil
 	}
-	if input.Color != nil {
-		car.Color = input.Color
+	return nil
+}
+
+func hasSelectedCar(cars []*domain.Car) bool {
+	for _, c := range cars {
+		if c.IsSelected {
+			return true
+		}
 	}
-	if input.Size != nil {
-		car.Size = input.Size
+	return false
+}
+
+func findCar(cars []*domain.Car, carID int64) *domain.Car {
+	for _, c := range cars {
+		if c.ID == carID {
+			return c
+		}
 	}
+	return nil
+}
 
-	err = s.carRepo.Update(ctx, car)
+// applyLicensePlate проверяет номер по формату (пустой - автоопределение) и сохраняет
+// отображаемую и нормализованную формы
+func applyLicensePlate(car *domain.Car, raw, format string) error {
+	plate, err := licenseplate.Parse(raw, licenseplate.Format(format))
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrServiceUpdateCar, err)
+		return fmt.Errorf("%w: %v", ErrInvalidPlate, err)
 	}
+	car.LicensePlate = plate.Display
+	car.LicensePlateNormalized = plate.Normalized
+	car.LicensePlateFormat = string(plate.Format)
+	return nil
+}
 
-	response := &models.CarDTO{
-		ID:           car.ID,
-		UserID:       car.UserID,
-		Brand:        car.Brand,
-		Model:        car.Model,
-		LicensePlate: car.LicensePlate,
-		Color:        car.Color,
-		Size:         car.Size,
-		IsSelected:   car.IsSelected,
+// toCarDTO преобразует автомобиль в DTO ответа
+func toCarDTO(car *domain.Car) models.CarDTO {
+	return models.CarDTO{
+		ID:                     car.ID,
+		UserID:                 car.UserID,
+		Brand:                  car.Brand,
+		Model:                  car.Model,
+		LicensePlate:           car.LicensePlate,
+		LicensePlateNormalized: car.LicensePlateNormalized,
+		LicensePlateFormat:     car.LicensePlateFormat,
+		Color:                  car.Color,
+		Size:                   car.Size,
+		IsSelected:             car.IsSelected,
+		LicensePlateStatus:     car.LicensePlateStatus,
 	}
+}
 
-	return response, nil
+// applyCarPatch применяет к автомобилю поля, переданные в PATCH.
+// null для марки, модели и номера очищает поле, и его отклоняет правило required при проверке автомобиля.
+func applyCarPatch(car *domain.Car, input models.UpdateCarInputDTO) error {
+	// Цвет и размер очищаются через null
+	if input.Brand.Present() {
+		car.Brand, _ = input.Brand.Value()
+	}
+	if input.Model.Present() {
+		car.Model, _ = input.Model.Value()
+	}
+	if input.LicensePlate.Present() || input.LicensePlateFormat.Present() {
+		// Новый номер без формата проверяется автоопределением, смена формата - по текущему номеру
+		licensePlate := car.LicensePlate
+		format := car.LicensePlateFormat
+		if input.LicensePlate.Present() {
+			licensePlate, _ = input.LicensePlate.Value()
+			format = ""
+		}
+		if input.LicensePlateFormat.Present() {
+			format, _ = input.LicensePlateFormat.Value()
+		}
+		if licensePlate == "" {
+			car.LicensePlate = ""
+		} else if err := applyLicensePlate(car, licensePlate, format); err != nil {
+			return err
+		}
+	}
+	if input.Color.Present() {
+		car.Color = input.Color.Ptr()
+	}
+	if input.Size.Present() {
+		car.Size = input.Size.Ptr()
+	}
+	return nil
 }
 
 // DeleteCar удаляет автомобиль с проверкой роли
@@ -313,34 +1066,96 @@ func (s *Service) DeleteCar(ctx context.Context, tgID int64, carID int64, role d
 		return fmt.Errorf("%w: %v", ErrServiceGetCar, err)
 	}
 
-	// Проверка доступа: владелец может удалять свою машину, superuser - любую
-	if !role.CanModifyUser(car.UserID, tgID) {
-		return ErrCarAccessDenied
-	}
-
-	wasSelected := car.IsSelected
-
-	err = s.carRepo.Delete(ctx, carID)
+	// Проверка доступа: владелец может удалять свою машину, роль с cars:delete:any - любую
+	authz, err := s.policy.Authorizer(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrServiceDeleteCar, err)
 	}
+	if !authz.CanDeleteCar(role, car.UserID, tgID) {
+		return ErrCarAccessDenied
+	}
 
-	// Если удалили выбранный автомобиль, выбираем первый из оставшихся
-	if wasSelected {
-		remainingCars, err := s.carRepo.GetByUserID(ctx, car.UserID)
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		cars, err := s.lockUserCars(ctx, car.UserID)
 		if err != nil {
-			return fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+			return err
+		}
+
+		deleted := findCar(cars, carID)
+		if deleted == nil {
+			return ErrCarNotFound
 		}
 
-		if len(remainingCars) > 0 {
-			remainingCars[0].IsSelected = true
-			if err := s.carRepo.Update(ctx, remainingCars[0]); err != nil {
-				return fmt.Errorf("%w: %v", ErrServiceUpdateCar, err)
+		if deleted.LicensePlateStatus == domain.PlateStatusPendingClaim {
+			if err := s.claimRepo.WithdrawByCarID(ctx, carID, time.Now()); err != nil {
+				return fmt.Errorf("%w: %v", ErrServiceDeleteCar, err)
 			}
 		}
+
+		if err := s.carRepo.Delete(ctx, carID); err != nil {
+			return fmt.Errorf("%w: %v", ErrServiceDeleteCar, err)
+		}
+
+		// Если удалили выбранный автомобиль, выбираем первый из оставшихся с закрепленным номером
+		if deleted.IsSelected {
+			return s.selectReplacement(ctx, cars, carID)
+		}
+
+		return nil
+	})
+}
+
+// ListCars получает автомобили пользователя.
+// sort: created (по умолчанию), brand, selected; префикс "-" - в обратном порядке.
+func (s *Service) ListCars(ctx context.Context, tgID int64, sort string) (*models.CarsDTO, error) {
+	filter := CarListFilter{UserID: tgID, SortField: CarSortCreated}
+	if sort != "" {
+		field := strings.TrimPrefix(sort, "-")
+		switch CarSortField(field) {
+		case CarSortCreated, CarSortBrand, CarSortSelected:
+			filter.SortField = CarSortField(field)
+			filter.SortDesc = strings.HasPrefix(sort, "-")
+		default:
+			return nil, fmt.Errorf("%w: unsupported sort %q", ErrInvalidFilter, sort)
+		}
 	}
 
-	return nil
+	cars, err := s.carRepo.ListByUserID(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+	}
+
+	response := &models.CarsDTO{
+		Cars: make([]models.CarDTO, 0, len(cars)),
+	}
+	for _, car := range cars {
+		response.Cars = append(response.Cars, toCarDTO(car))
+	}
+
+	return response, nil
+}
+
+// GetCar получает автомобиль с проверкой роли
+func (s *Service) GetCar(ctx context.Context, tgID int64, carID int64, role domain.Role) (*models.CarDTO, error) {
+	car, err := s.carRepo.GetByID(ctx, carID)
+	if err != nil {
+		if errors.Is(err, ErrCarNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+	}
+
+	// Проверка доступа: владелец видит свою машину, роль с cars:read:any - любую
+	authz, err := s.policy.Authorizer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+	}
+	if !authz.CanReadCar(role, car.UserID, tgID) {
+		return nil, ErrCarAccessDenied
+	}
+
+	response := toCarDTO(car)
+	return &response, nil
 }
 
 // GetSelectedCar получает текущий выбранный автомобиль пользователя
@@ -353,20 +1168,54 @@ func (s *Service) GetSelectedCar(ctx context.Context, tgID int64) (*models.CarDT
 		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
 	}
 
-	response := &models.CarDTO{
-		ID:           car.ID,
-		UserID:       car.UserID,
-		Brand:        car.Brand,
-		Model:        car.Model,
-		LicensePlate: car.LicensePlate,
-		Color:        car.Color,
-		Size:         car.Size,
-		IsSelected:   car.IsSelected,
+	response := toCarDTO(car)
+	return &response, nil
+}
+
+// GetSelectedCars получает выбранные автомобили нескольких пользователей одним запросом
+func (s *Service) GetSelectedCars(ctx context.Context, tgIDs []int64) (*models.SelectedCarsBatchDTO, error) {
+	tgIDs = uniqueIDs(tgIDs)
+
+	cars, err := s.carRepo.GetSelectedByUserIDs(ctx, tgIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+	}
+
+	carsByUser := make(map[int64]*domain.Car, len(cars))
+	for _, car := range cars {
+		carsByUser[car.UserID] = car
+	}
+
+	response := &models.SelectedCarsBatchDTO{
+		Cars:       make([]models.CarDTO, 0, len(cars)),
+		MissingIDs: []int64{},
+	}
+	for _, id := range tgIDs {
+		car, ok := carsByUser[id]
+		if !ok {
+			response.MissingIDs = append(response.MissingIDs, id)
+			continue
+		}
+		response.Cars = append(response.Cars, toCarDTO(car))
 	}
 
 	return response, nil
 }
 
+// uniqueIDs убирает повторы, сохраняя порядок
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
 // SetSelectedCar устанавливает автомобиль как выбранный
 func (s *Service) SetSelectedCar(ctx context.Context, tgID int64, carID int64, role domain.Role) (*models.CarDTO, error) {
 	car, err := s.carRepo.GetByID(ctx, carID)
@@ -378,46 +1227,69 @@ func (s *Service) SetSelectedCar(ctx context.Context, tgID int64, carID int64, r
 	}
 
 	// Проверка доступа
-	if !role.CanModifyUser(car.UserID, tgID) {
+	authz, err := s.policy.Authorizer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceUpdateCar, err)
+	}
+	if !authz.CanUpdateCar(role, car.UserID, tgID) {
 		return nil, ErrCarAccessDenied
 	}
 
-	// Если уже выбран, просто возвращаем
-	if car.IsSelected {
-		response := &models.CarDTO{
-			ID:           car.ID,
-			UserID:       car.UserID,
-			Brand:        car.Brand,
-			Model:        car.Model,
-			LicensePlate: car.LicensePlate,
-			Color:        car.Color,
-			Size:         car.Size,
-			IsSelected:   car.IsSelected,
+	ownerID := car.UserID
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		car, err = s.lockUserCar(ctx, ownerID, carID)
+		if err != nil {
+			return err
 		}
-		return response, nil
-	}
 
-	// Сначала снимаем выбор со всех автомобилей пользователя
-	if err := s.carRepo.UnselectAllByUserID(ctx, car.UserID); err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrServiceUpdateCar, err)
-	}
+		// Если уже выбран, ничего не меняем
+		if car.IsSelected {
+			return nil
+		}
+		if car.LicensePlateStatus == domain.PlateStatusPendingClaim {
+			return ErrPlateClaimPending
+		}
 
-	// Устанавливаем текущий автомобиль как выбранный
-	car.IsSelected = true
-	if err := s.carRepo.Update(ctx, car); err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrServiceUpdateCar, err)
+		// Снятие выбора и выбор выполняются в одной транзакции: у пользователя всегда ровно один выбранный автомобиль
+		if err := s.carRepo.UnselectAllByUserID(ctx, car.UserID); err != nil {
+			return fmt.Errorf("%w: %v", ErrServiceUpdateCar, err)
+		}
+
+		car.IsSelected = true
+		if err := s.carRepo.Update(ctx, car); err != nil {
+			return fmt.Errorf("%w: %v", ErrServiceUpdateCar, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	response := &models.CarDTO{
-		ID:           car.ID,
-		UserID:       car.UserID,
-		Brand:        car.Brand,
-		Model:        car.Model,
-		LicensePlate: car.LicensePlate,
-		Color:        car.Color,
-		Size:         car.Size,
-		IsSelected:   car.IsSelected,
+	response := toCarDTO(car)
+	return &response, nil
+}
+
+// lockUserCars блокирует автомобили пользователя в текущей транзакции.
+// Автомобили удаленного пользователя не видны, поэтому его отсутствие означает ErrCarNotFound.
+func (s *Service) lockUserCars(ctx context.Context, userID int64) ([]*domain.Car, error) {
+	cars, err := s.carRepo.LockByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrCarNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
 	}
+	return cars, nil
+}
 
-	return response, nil
+// lockUserCar блокирует автомобили пользователя и возвращает актуальное состояние carID
+func (s *Service) lockUserCar(ctx context.Context, userID, carID int64) (*domain.Car, error) {
+	cars, err := s.lockUserCars(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if car := findCar(cars, carID); car != nil {
+		return car, nil
+	}
+	return nil, ErrCarNotFound
 }
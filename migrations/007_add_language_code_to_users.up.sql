@@ -0,0 +1,2 @@
+-- Язык пользователя из профиля Telegram (language_code, IETF tag)
+ALTER TABLE users ADD COLUMN language_code VARCHAR(10);
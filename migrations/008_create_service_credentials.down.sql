@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_service_credentials_service_name;
+DROP TABLE IF EXISTS service_credentials;
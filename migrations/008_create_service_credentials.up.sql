@@ -0,0 +1,14 @@
+-- Учетные данные внутренних сервисов для подписанных запросов к /internal
+CREATE TABLE service_credentials (
+    id BIGSERIAL PRIMARY KEY,
+    service_name VARCHAR(100) NOT NULL,
+    key_id VARCHAR(64) NOT NULL UNIQUE,
+    secret_hash VARCHAR(64) NOT NULL,
+    created_by BIGINT REFERENCES users(tg_user_id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_service_credentials_service_name ON service_credentials(service_name);
+
+COMMENT ON COLUMN service_credentials.secret_hash IS 'hex(SHA-256(secret)); открытый секрет не хранится';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_role_changes_tg_user_id;
+DROP TABLE IF EXISTS role_changes;
@@ -0,0 +1,12 @@
+-- История изменения ролей пользователей
+CREATE TABLE role_changes (
+    id BIGSERIAL PRIMARY KEY,
+    tg_user_id BIGINT NOT NULL REFERENCES users(tg_user_id) ON DELETE CASCADE,
+    old_role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE RESTRICT,
+    new_role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE RESTRICT,
+    changed_by BIGINT NOT NULL,
+    reason TEXT,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_role_changes_tg_user_id ON role_changes(tg_user_id);
@@ -0,0 +1,31 @@
+-- Справочник прав доступа
+CREATE TABLE permissions (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(64) UNIQUE NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Права ролей
+CREATE TABLE role_permissions (
+    role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_id INTEGER NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+INSERT INTO permissions (code, description) VALUES
+    ('users:read:any', 'Просмотр данных любого пользователя'),
+    ('users:update:any', 'Изменение данных любого пользователя'),
+    ('users:role:assign', 'Назначение ролей пользователям'),
+    ('cars:read:any', 'Просмотр автомобилей любого пользователя'),
+    ('cars:update:any', 'Изменение и выбор автомобилей любого пользователя'),
+    ('cars:delete:any', 'Удаление автомобилей любого пользователя'),
+    ('roles:manage', 'Создание ролей и управление их правами');
+
+-- Собственные данные доступны любому пользователю без отдельных прав.
+-- Суперпользователь получает все права.
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'superuser';
@@ -0,0 +1,22 @@
+-- Статус аккаунта: active, suspended (до suspended_until) или banned
+ALTER TABLE users ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active'
+    CHECK (status IN ('active', 'suspended', 'banned'));
+ALTER TABLE users ADD COLUMN suspended_until TIMESTAMP;
+ALTER TABLE users ADD COLUMN status_reason TEXT;
+ALTER TABLE users ADD COLUMN status_changed_by BIGINT;
+ALTER TABLE users ADD COLUMN status_changed_at TIMESTAMP;
+
+CREATE INDEX idx_users_status ON users(status) WHERE status <> 'active';
+
+COMMENT ON COLUMN users.status IS 'Account status: active, suspended (until suspended_until), banned';
+COMMENT ON COLUMN users.status_changed_by IS 'tg_user_id of the user who changed the status';
+
+-- Право изменять статус аккаунтов
+INSERT INTO permissions (code, description) VALUES
+    ('users:status:update', 'Блокировка и разблокировка пользователей');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'superuser' AND p.code = 'users:status:update';
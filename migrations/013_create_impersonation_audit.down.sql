@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE code IN ('users:impersonate', 'audit:read');
+
+DROP INDEX IF EXISTS idx_impersonation_audit_target_id;
+DROP INDEX IF EXISTS idx_impersonation_audit_actor_id;
+DROP TABLE IF EXISTS impersonation_audit;
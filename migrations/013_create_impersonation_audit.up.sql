@@ -0,0 +1,25 @@
+-- Журнал запросов, выполненных от имени другого пользователя
+CREATE TABLE impersonation_audit (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT NOT NULL,
+    target_id BIGINT NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    status_code INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_impersonation_audit_actor_id ON impersonation_audit(actor_id, created_at DESC);
+CREATE INDEX idx_impersonation_audit_target_id ON impersonation_audit(target_id, created_at DESC);
+
+COMMENT ON COLUMN impersonation_audit.status_code IS 'HTTP status of the response, NULL if the request did not complete';
+
+INSERT INTO permissions (code, description) VALUES
+    ('users:impersonate', 'Выполнение запросов от имени другого пользователя'),
+    ('audit:read', 'Просмотр журнала аудита');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'superuser' AND p.code IN ('users:impersonate', 'audit:read');
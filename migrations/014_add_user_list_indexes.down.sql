@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_tg_link_trgm;
+DROP INDEX IF EXISTS idx_users_phone_number_trgm;
+DROP INDEX IF EXISTS idx_users_name_trgm;
+DROP INDEX IF EXISTS idx_users_name_tg_user_id;
+DROP INDEX IF EXISTS idx_users_created_at_tg_user_id;
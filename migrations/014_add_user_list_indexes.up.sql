@@ -0,0 +1,9 @@
+-- Индексы для списка пользователей: сортировка с курсорной пагинацией и поиск по подстроке
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_users_created_at_tg_user_id ON users(created_at, tg_user_id);
+CREATE INDEX idx_users_name_tg_user_id ON users(name, tg_user_id);
+
+CREATE INDEX idx_users_name_trgm ON users USING GIN (name gin_trgm_ops);
+CREATE INDEX idx_users_phone_number_trgm ON users USING GIN (phone_number gin_trgm_ops);
+CREATE INDEX idx_users_tg_link_trgm ON users USING GIN (tg_link gin_trgm_ops);
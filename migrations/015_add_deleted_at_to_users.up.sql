@@ -0,0 +1,6 @@
+-- Мягкое удаление: аккаунт можно восстановить до окончания срока хранения, затем он удаляется фоновой задачей
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_users_deleted_at ON users(deleted_at) WHERE deleted_at IS NOT NULL;
+
+COMMENT ON COLUMN users.deleted_at IS 'Soft delete time; the row is purged after the retention period';
@@ -0,0 +1,17 @@
+-- Подтверждение номера телефона одноразовым кодом
+ALTER TABLE users ADD COLUMN phone_verified_at TIMESTAMP;
+
+COMMENT ON COLUMN users.phone_verified_at IS 'Time the current phone_number was confirmed with a one-time code, NULL if not confirmed';
+
+-- Активный код подтверждения пользователя; новый запрос кода заменяет предыдущий
+CREATE TABLE phone_verifications (
+    tg_user_id BIGINT PRIMARY KEY REFERENCES users(tg_user_id) ON DELETE CASCADE,
+    phone_number VARCHAR(20) NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON COLUMN phone_verifications.phone_number IS 'Number the code was sent to; the code is rejected if the profile number changed';
+COMMENT ON COLUMN phone_verifications.code_hash IS 'SHA-256 of the code, the code itself is not stored';
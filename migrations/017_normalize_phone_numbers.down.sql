@@ -0,0 +1,2 @@
+-- Исходное написание номеров не сохраняется, откатывается только отметка совпадений
+ALTER TABLE users DROP COLUMN IF EXISTS phone_duplicate;
@@ -0,0 +1,36 @@
+-- Приведение сохраненных номеров к E.164 по правилам региона RU (как [phone_numbers] default_region по умолчанию).
+-- Номера, которые не удалось распознать, остаются как есть: сервис нормализует их при следующем изменении профиля.
+UPDATE users SET phone_number = NULLIF(regexp_replace(phone_number, '[[:space:]().-]', '', 'g'), '')
+WHERE phone_number IS NOT NULL;
+
+-- Международный префикс 00 -> +
+UPDATE users SET phone_number = '+' || substr(phone_number, 3)
+WHERE phone_number ~ '^00[1-9][0-9]{7,14}$';
+
+-- 8XXXXXXXXXX -> +7XXXXXXXXXX
+UPDATE users SET phone_number = '+7' || substr(phone_number, 2)
+WHERE phone_number ~ '^8[0-9]{10}$';
+
+-- 7XXXXXXXXXX -> +7XXXXXXXXXX
+UPDATE users SET phone_number = '+' || phone_number
+WHERE phone_number ~ '^7[0-9]{10}$';
+
+-- XXXXXXXXXX -> +7XXXXXXXXXX
+UPDATE users SET phone_number = '+7' || phone_number
+WHERE phone_number ~ '^[0-9]{10}$';
+
+-- Коды подтверждения привязаны к номеру в прежнем виде
+DELETE FROM phone_verifications;
+
+-- Отметка совпадения номера с номером другого пользователя ([phone_numbers] duplicate_policy = "flag")
+ALTER TABLE users ADD COLUMN phone_duplicate BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN users.phone_duplicate IS 'The phone number was also used by another user when it was saved';
+
+-- Совпадения, которые уже есть в данных, отмечаются у всех владельцев номера
+UPDATE users u SET phone_duplicate = TRUE
+WHERE u.phone_number IS NOT NULL
+  AND EXISTS (
+      SELECT 1 FROM users other
+      WHERE other.phone_number = u.phone_number AND other.tg_user_id <> u.tg_user_id
+  );
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS company_members;
+DROP TABLE IF EXISTS companies;
@@ -0,0 +1,20 @@
+-- Компании (автомойки), которыми управляют менеджеры
+CREATE TABLE companies (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_by BIGINT REFERENCES users(tg_user_id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Менеджеры компаний; пользователь может работать в нескольких компаниях
+CREATE TABLE company_members (
+    company_id BIGINT NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    tg_user_id BIGINT NOT NULL REFERENCES users(tg_user_id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'staff')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (company_id, tg_user_id)
+);
+
+CREATE INDEX idx_company_members_tg_user_id ON company_members(tg_user_id);
+
+COMMENT ON COLUMN company_members.role IS 'Role inside the company: owner or staff';
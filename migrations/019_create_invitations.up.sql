@@ -0,0 +1,19 @@
+-- Одноразовые приглашения, выдающие роль и (опционально) членство в компании
+CREATE TABLE invitations (
+    id BIGSERIAL PRIMARY KEY,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    role VARCHAR(20) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    company_id BIGINT REFERENCES companies(id) ON DELETE CASCADE,
+    company_role VARCHAR(20) CHECK (company_role IN ('owner', 'staff')),
+    created_by BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    accepted_by BIGINT REFERENCES users(tg_user_id) ON DELETE SET NULL,
+    accepted_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    CHECK ((company_id IS NULL) = (company_role IS NULL))
+);
+
+CREATE INDEX idx_invitations_company_id ON invitations(company_id);
+
+COMMENT ON COLUMN invitations.token_hash IS 'SHA-256 of the invitation token, the token itself is not stored';
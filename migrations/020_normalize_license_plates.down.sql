@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_cars_license_plate_normalized;
+ALTER TABLE cars DROP CONSTRAINT IF EXISTS chk_cars_license_plate_format;
+ALTER TABLE cars DROP COLUMN IF EXISTS license_plate_format;
+ALTER TABLE cars DROP COLUMN IF EXISTS license_plate_normalized;
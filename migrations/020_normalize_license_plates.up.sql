@@ -0,0 +1,50 @@
+-- Нормализованный номер для поиска: без пробелов и дефисов, верхний регистр,
+-- кириллические буквы ГОСТ заменены латинскими двойниками (как licenseplate.Normalize)
+ALTER TABLE cars ADD COLUMN license_plate_normalized VARCHAR(20);
+ALTER TABLE cars ADD COLUMN license_plate_format VARCHAR(20);
+
+UPDATE cars SET license_plate_normalized = translate(
+    upper(regexp_replace(license_plate, '[[:space:]-]', '', 'g')),
+    'АВЕКМНОРСТУХавекмнорстух',
+    'ABEKMHOPCTYXABEKMHOPCTYX'
+);
+
+-- Формат определяется в том же порядке, что и при автоопределении в сервисе
+UPDATE cars SET license_plate_format = 'private'
+WHERE license_plate_normalized ~ '^[ABEKMHOPCTYX][0-9]{3}[ABEKMHOPCTYX]{2}[0-9]{2,3}$';
+
+UPDATE cars SET license_plate_format = 'taxi'
+WHERE license_plate_format IS NULL AND license_plate_normalized ~ '^[ABEKMHOPCTYX]{2}[0-9]{3}[0-9]{2,3}$';
+
+UPDATE cars SET license_plate_format = 'trailer'
+WHERE license_plate_format IS NULL AND license_plate_normalized ~ '^[ABEKMHOPCTYX]{2}[0-9]{4}[0-9]{2,3}$';
+
+UPDATE cars SET license_plate_format = 'motorcycle'
+WHERE license_plate_format IS NULL AND license_plate_normalized ~ '^[0-9]{4}[ABEKMHOPCTYX]{2}[0-9]{2,3}$';
+
+UPDATE cars SET license_plate_format = 'diplomatic'
+WHERE license_plate_format IS NULL AND license_plate_normalized ~ '^[0-9]{3}(CD[0-9]|[DT][0-9]{3})[0-9]{2,3}$';
+
+-- Номера, не подходящие под ГОСТ, сохраняются как иностранные без проверки формата
+UPDATE cars SET license_plate_format = 'foreign'
+WHERE license_plate_format IS NULL;
+
+-- Отображаемая форма российских номеров: код региона через пробел, буквы кириллицей (кроме дипломатических)
+UPDATE cars SET license_plate = CASE license_plate_format
+    WHEN 'private' THEN regexp_replace(license_plate_normalized, '^([A-Z][0-9]{3}[A-Z]{2})([0-9]{2,3})$', '\1 \2')
+    WHEN 'taxi' THEN regexp_replace(license_plate_normalized, '^([A-Z]{2}[0-9]{3})([0-9]{2,3})$', '\1 \2')
+    WHEN 'trailer' THEN regexp_replace(license_plate_normalized, '^([A-Z]{2}[0-9]{4})([0-9]{2,3})$', '\1 \2')
+    WHEN 'motorcycle' THEN regexp_replace(license_plate_normalized, '^([0-9]{4}[A-Z]{2})([0-9]{2,3})$', '\1 \2')
+    WHEN 'diplomatic' THEN regexp_replace(license_plate_normalized, '^([0-9]{3}(CD[0-9]|[DT][0-9]{3}))([0-9]{2,3})$', '\1 \3')
+END
+WHERE license_plate_format <> 'foreign';
+
+UPDATE cars SET license_plate = translate(license_plate, 'ABEKMHOPCTYX', 'АВЕКМНОРСТУХ')
+WHERE license_plate_format IN ('private', 'taxi', 'trailer', 'motorcycle');
+
+ALTER TABLE cars ALTER COLUMN license_plate_normalized SET NOT NULL;
+ALTER TABLE cars ALTER COLUMN license_plate_format SET NOT NULL;
+ALTER TABLE cars ADD CONSTRAINT chk_cars_license_plate_format
+    CHECK (license_plate_format IN ('private', 'taxi', 'trailer', 'motorcycle', 'diplomatic', 'foreign'));
+
+CREATE INDEX idx_cars_license_plate_normalized ON cars(license_plate_normalized);
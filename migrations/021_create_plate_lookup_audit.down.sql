@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE code = 'cars:lookup:company';
+
+DROP INDEX IF EXISTS idx_plate_lookup_audit_actor_id;
+DROP INDEX IF EXISTS idx_plate_lookup_audit_company_id;
+DROP TABLE IF EXISTS plate_lookup_audit;
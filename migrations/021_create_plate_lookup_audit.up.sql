@@ -0,0 +1,24 @@
+-- Журнал поиска автомобилей по госномеру менеджерами компаний.
+-- Записи не связаны внешними ключами, чтобы журнал сохранялся после удаления компании или пользователя.
+CREATE TABLE plate_lookup_audit (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT NOT NULL,
+    company_id BIGINT NOT NULL,
+    plate VARCHAR(20) NOT NULL,
+    result_count INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_plate_lookup_audit_company_id ON plate_lookup_audit(company_id, created_at DESC);
+CREATE INDEX idx_plate_lookup_audit_actor_id ON plate_lookup_audit(actor_id, created_at DESC);
+
+COMMENT ON COLUMN plate_lookup_audit.plate IS 'Normalized license plate that was searched';
+
+INSERT INTO permissions (code, description) VALUES
+    ('cars:lookup:company', 'Поиск автомобилей по госномеру от имени своей компании');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name IN ('manager', 'superuser') AND p.code = 'cars:lookup:company';
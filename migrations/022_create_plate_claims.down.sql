@@ -0,0 +1,9 @@
+DELETE FROM permissions WHERE code = 'cars:claims:resolve';
+
+DROP INDEX IF EXISTS idx_plate_claims_status;
+DROP INDEX IF EXISTS idx_plate_claims_pending_car_id;
+DROP TABLE IF EXISTS plate_claims;
+
+ALTER TABLE cars DROP CONSTRAINT IF EXISTS chk_cars_pending_claim_not_selected;
+ALTER TABLE cars DROP CONSTRAINT IF EXISTS chk_cars_license_plate_status;
+ALTER TABLE cars DROP COLUMN IF EXISTS license_plate_status;
@@ -0,0 +1,40 @@
+-- Споры о госномерах: автомобиль с номером, уже закрепленным за другим пользователем,
+-- создается в состоянии pending_claim и не участвует в поиске по номеру до решения спора.
+ALTER TABLE cars ADD COLUMN license_plate_status VARCHAR(20) NOT NULL DEFAULT 'confirmed';
+ALTER TABLE cars ADD CONSTRAINT chk_cars_license_plate_status
+    CHECK (license_plate_status IN ('confirmed', 'pending_claim'));
+
+-- Автомобиль со спорным номером не может быть выбранным
+ALTER TABLE cars ADD CONSTRAINT chk_cars_pending_claim_not_selected
+    CHECK (license_plate_status = 'confirmed' OR NOT is_selected);
+
+CREATE TABLE plate_claims (
+    id BIGSERIAL PRIMARY KEY,
+    car_id BIGINT REFERENCES cars(id) ON DELETE SET NULL,
+    claimant_id BIGINT NOT NULL,
+    holder_car_id BIGINT REFERENCES cars(id) ON DELETE SET NULL,
+    holder_id BIGINT NOT NULL,
+    license_plate_normalized VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    resolved_by BIGINT,
+    comment TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    CONSTRAINT chk_plate_claims_status
+        CHECK (status IN ('pending', 'transferred', 'rejected', 'shared', 'withdrawn'))
+);
+
+-- У автомобиля не больше одного нерешенного спора
+CREATE UNIQUE INDEX idx_plate_claims_pending_car_id ON plate_claims(car_id) WHERE status = 'pending';
+CREATE INDEX idx_plate_claims_status ON plate_claims(status, id);
+
+COMMENT ON COLUMN plate_claims.holder_id IS 'Owner of the confirmed car with the same plate when the claim was filed';
+
+INSERT INTO permissions (code, description) VALUES
+    ('cars:claims:resolve', 'Рассмотрение споров о госномерах');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name IN ('manager', 'superuser') AND p.code = 'cars:claims:resolve';
@@ -0,0 +1,8 @@
+-- Ключи, которые хранятся только в зашифрованном виде, предыдущая версия использовать не может
+DELETE FROM service_credentials WHERE secret_hash IS NULL;
+
+ALTER TABLE service_credentials DROP CONSTRAINT IF EXISTS chk_service_credentials_signing_key;
+ALTER TABLE service_credentials ALTER COLUMN secret_hash SET NOT NULL;
+ALTER TABLE service_credentials DROP COLUMN IF EXISTS signing_key_encrypted;
+
+COMMENT ON COLUMN service_credentials.secret_hash IS 'hex(SHA-256(secret)); открытый секрет не хранится';
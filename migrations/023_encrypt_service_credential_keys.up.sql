@@ -0,0 +1,11 @@
+-- Ключ подписи сервиса хранится зашифрованным ключом шифрования сервера (KEK, [internal_auth] key_encryption_key).
+-- secret_hash (hex(SHA-256(secret))) сам является ключом подписи, поэтому при чтении БД позволял подделывать запросы.
+-- Существующие ключи шифруются при запуске сервиса, после чего secret_hash обнуляется.
+ALTER TABLE service_credentials ADD COLUMN signing_key_encrypted BYTEA;
+ALTER TABLE service_credentials ALTER COLUMN secret_hash DROP NOT NULL;
+
+ALTER TABLE service_credentials ADD CONSTRAINT chk_service_credentials_signing_key
+    CHECK (signing_key_encrypted IS NOT NULL OR secret_hash IS NOT NULL);
+
+COMMENT ON COLUMN service_credentials.secret_hash IS 'Устарело: ключ подписи в открытом виде, обнуляется после шифрования';
+COMMENT ON COLUMN service_credentials.signing_key_encrypted IS 'AES-256-GCM(KEK, SHA-256(secret)), nonce || ciphertext, AAD - key_id';
@@ -0,0 +1,9 @@
+-- Использованные nonce подписанных запросов к /internal (общие для всех инстансов, защита от повтора)
+CREATE TABLE service_request_nonces (
+    key VARCHAR(255) PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_service_request_nonces_expires_at ON service_request_nonces(expires_at);
+
+COMMENT ON COLUMN service_request_nonces.key IS 'key_id:nonce';
@@ -0,0 +1,10 @@
+-- Согласия, принятые пользователем в Mini App (передаются при регистрации в POST /users)
+CREATE TABLE user_consents (
+    id BIGSERIAL PRIMARY KEY,
+    tg_user_id BIGINT NOT NULL REFERENCES users(tg_user_id) ON DELETE CASCADE,
+    consent_type VARCHAR(50) NOT NULL CHECK (consent_type IN ('personal_data', 'marketing')),
+    granted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_user_consents_tg_user_id ON user_consents(tg_user_id);
@@ -0,0 +1 @@
+DELETE FROM permissions WHERE code IN ('companies:manage', 'service_credentials:manage');
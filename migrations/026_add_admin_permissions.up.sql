@@ -0,0 +1,9 @@
+INSERT INTO permissions (code, description) VALUES
+    ('companies:manage', 'Создание компаний и назначение их менеджеров'),
+    ('service_credentials:manage', 'Выпуск и отзыв ключей сервисов для /internal маршрутов');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'superuser' AND p.code IN ('companies:manage', 'service_credentials:manage');
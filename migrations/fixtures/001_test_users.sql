@@ -21,13 +21,15 @@ ON CONFLICT (tg_user_id) DO UPDATE SET
     tg_link = EXCLUDED.tg_link;
 
 -- Автомобиль пользователя 123456789 (выбранный)
-INSERT INTO cars (id, user_id, brand, model, license_plate, color, size, is_selected)
+INSERT INTO cars (id, user_id, brand, model, license_plate, license_plate_normalized, license_plate_format, color, size, is_selected)
 VALUES (
     1001,
     123456789,
     'BMW',
     'X5',
-    'А123БВ799',
+    'А123ВС 799',
+    'A123BC799',
+    'private',
     'Черный',
     'L',
     true
@@ -36,6 +38,8 @@ ON CONFLICT (id) DO UPDATE SET
     brand = EXCLUDED.brand,
     model = EXCLUDED.model,
     license_plate = EXCLUDED.license_plate,
+    license_plate_normalized = EXCLUDED.license_plate_normalized,
+    license_plate_format = EXCLUDED.license_plate_format,
     color = EXCLUDED.color,
     size = EXCLUDED.size,
     is_selected = EXCLUDED.is_selected;
@@ -57,13 +61,15 @@ ON CONFLICT (tg_user_id) DO UPDATE SET
     tg_link = EXCLUDED.tg_link;
 
 -- Автомобиль пользователя 987654321 (выбранный)
-INSERT INTO cars (id, user_id, brand, model, license_plate, color, size, is_selected)
+INSERT INTO cars (id, user_id, brand, model, license_plate, license_plate_normalized, license_plate_format, color, size, is_selected)
 VALUES (
     2001,
     987654321,
     'Mercedes',
     'E-Class',
-    'В999КС777',
+    'В999КС 777',
+    'B999KC777',
+    'private',
     'Серебристый',
     'E',
     true
@@ -72,6 +78,8 @@ ON CONFLICT (id) DO UPDATE SET
     brand = EXCLUDED.brand,
     model = EXCLUDED.model,
     license_plate = EXCLUDED.license_plate,
+    license_plate_normalized = EXCLUDED.license_plate_normalized,
+    license_plate_format = EXCLUDED.license_plate_format,
     color = EXCLUDED.color,
     size = EXCLUDED.size,
     is_selected = EXCLUDED.is_selected;
@@ -93,13 +101,15 @@ ON CONFLICT (tg_user_id) DO UPDATE SET
     tg_link = EXCLUDED.tg_link;
 
 -- Автомобиль пользователя 111222333 (выбранный)
-INSERT INTO cars (id, user_id, brand, model, license_plate, color, size, is_selected)
+INSERT INTO cars (id, user_id, brand, model, license_plate, license_plate_normalized, license_plate_format, color, size, is_selected)
 VALUES (
     3001,
     111222333,
     'Audi',
     'A4',
-    'С555АА199',
+    'С555АА 199',
+    'C555AA199',
+    'private',
     'Белый',
     'D',
     true
@@ -108,6 +118,8 @@ ON CONFLICT (id) DO UPDATE SET
     brand = EXCLUDED.brand,
     model = EXCLUDED.model,
     license_plate = EXCLUDED.license_plate,
+    license_plate_normalized = EXCLUDED.license_plate_normalized,
+    license_plate_format = EXCLUDED.license_plate_format,
     color = EXCLUDED.color,
     size = EXCLUDED.size,
     is_selected = EXCLUDED.is_selected;
@@ -129,13 +141,15 @@ ON CONFLICT (tg_user_id) DO UPDATE SET
     tg_link = EXCLUDED.tg_link;
 
 -- Автомобиль пользователя 444555666 (выбранный)
-INSERT INTO cars (id, user_id, brand, model, license_plate, color, size, is_selected)
+INSERT INTO cars (id, user_id, brand, model, license_plate, license_plate_normalized, license_plate_format, color, size, is_selected)
 VALUES (
     4001,
     444555666,
     'Tesla',
     'Model 3',
-    'Т123КХ777',
+    'Т123КХ 777',
+    'T123KX777',
+    'private',
     'Синий',
     'D',
     true
@@ -144,6 +158,8 @@ ON CONFLICT (id) DO UPDATE SET
     brand = EXCLUDED.brand,
     model = EXCLUDED.model,
     license_plate = EXCLUDED.license_plate,
+    license_plate_normalized = EXCLUDED.license_plate_normalized,
+    license_plate_format = EXCLUDED.license_plate_format,
     color = EXCLUDED.color,
     size = EXCLUDED.size,
     is_selected = EXCLUDED.is_selected;
@@ -165,13 +181,15 @@ ON CONFLICT (tg_user_id) DO UPDATE SET
     tg_link = EXCLUDED.tg_link;
 
 -- Автомобиль пользователя 555666777 (выбранный)
-INSERT INTO cars (id, user_id, brand, model, license_plate, color, size, is_selected)
+INSERT INTO cars (id, user_id, brand, model, license_plate, license_plate_normalized, license_plate_format, color, size, is_selected)
 VALUES (
     5001,
     555666777,
     'Volkswagen',
     'Polo',
-    'О777ОО799',
+    'О777ОО 799',
+    'O777OO799',
+    'private',
     'Красный',
     'B',
     true
@@ -180,6 +198,8 @@ ON CONFLICT (id) DO UPDATE SET
     brand = EXCLUDED.brand,
     model = EXCLUDED.model,
     license_plate = EXCLUDED.license_plate,
+    license_plate_normalized = EXCLUDED.license_plate_normalized,
+    license_plate_format = EXCLUDED.license_plate_format,
     color = EXCLUDED.color,
     size = EXCLUDED.size,
     is_selected = EXCLUDED.is_selected;
@@ -201,13 +221,15 @@ ON CONFLICT (tg_user_id) DO UPDATE SET
     tg_link = EXCLUDED.tg_link;
 
 -- Автомобиль пользователя 666777888 (выбранный)
-INSERT INTO cars (id, user_id, brand, model, license_plate, color, size, is_selected)
+INSERT INTO cars (id, user_id, brand, model, license_plate, license_plate_normalized, license_plate_format, color, size, is_selected)
 VALUES (
     6001,
     666777888,
     'Porsche',
     'Cayenne',
-    'Н123МР777',
+    'Н123МР 777',
+    'H123MP777',
+    'private',
     'Черный',
     'J',
     true
@@ -216,6 +238,8 @@ ON CONFLICT (id) DO UPDATE SET
     brand = EXCLUDED.brand,
     model = EXCLUDED.model,
     license_plate = EXCLUDED.license_plate,
+    license_plate_normalized = EXCLUDED.license_plate_normalized,
+    license_plate_format = EXCLUDED.license_plate_format,
     color = EXCLUDED.color,
     size = EXCLUDED.size,
     is_selected = EXCLUDED.is_selected;
@@ -237,13 +261,15 @@ ON CONFLICT (tg_user_id) DO UPDATE SET
     tg_link = EXCLUDED.tg_link;
 
 -- Автомобиль пользователя 777888999 (выбранный)
-INSERT INTO cars (id, user_id, brand, model, license_plate, color, size, is_selected)
+INSERT INTO cars (id, user_id, brand, model, license_plate, license_plate_normalized, license_plate_format, color, size, is_selected)
 VALUES (
     7001,
     777888999,
     'Lexus',
     'RX350',
-    'К888КК199',
+    'К888КК 199',
+    'K888KK199',
+    'private',
     'Белый',
     'E',
     true
@@ -252,6 +278,8 @@ ON CONFLICT (id) DO UPDATE SET
     brand = EXCLUDED.brand,
     model = EXCLUDED.model,
     license_plate = EXCLUDED.license_plate,
+    license_plate_normalized = EXCLUDED.license_plate_normalized,
+    license_plate_format = EXCLUDED.license_plate_format,
     color = EXCLUDED.color,
     size = EXCLUDED.size,
     is_selected = EXCLUDED.is_selected;
@@ -0,0 +1,146 @@
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrBeginTx  = errors.New("failed to begin transaction")
+	ErrCommitTx = errors.New("failed to commit transaction")
+)
+
+// retryBackoff пауза перед повтором, растет линейно с номером попытки
+const retryBackoff = 10 * time.Millisecond
+
+// Executor выполняет запросы в транзакции из контекста или напрямую в БД
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+}
+
+type txKey struct{}
+
+// txState транзакция и признак конфликта, после которого ее нужно повторить.
+// Репозитории оборачивают ошибки БД через %v, поэтому конфликт фиксируется при выполнении запроса.
+type txState struct {
+	tx        *sqlx.Tx
+	retryable bool
+}
+
+func (s *txState) observe(err error) error {
+	if IsRetryable(err) {
+		s.retryable = true
+	}
+	return err
+}
+
+func (s *txState) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	result, err := s.tx.ExecContext(ctx, query, args...)
+	return result, s.observe(err)
+}
+
+func (s *txState) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	return s.observe(s.tx.GetContext(ctx, dest, query, args...))
+}
+
+func (s *txState) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	return s.observe(s.tx.SelectContext(ctx, dest, query, args...))
+}
+
+// From возвращает транзакцию из ctx, если запрос выполняется внутри Manager.WithTx, иначе db
+func From(ctx context.Context, db *sqlx.DB) Executor {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state
+	}
+	return db
+}
+
+// Manager запускает функции в транзакции с повтором при конфликтах.
+// Транзакция передается через context, поэтому репозитории, получающие соединение через From,
+// работают одинаково внутри и вне транзакции.
+type Manager struct {
+	db          *sqlx.DB
+	maxAttempts int
+}
+
+func NewManager(db *sqlx.DB, maxAttempts int) *Manager {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &Manager{
+		db:          db,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// WithTx выполняет fn в транзакции и фиксирует ее, если fn не вернула ошибку.
+// При ошибке сериализации или взаимной блокировке транзакция откатывается и fn выполняется заново
+// (до maxAttempts раз), поэтому fn не должна иметь побочных эффектов вне БД.
+// Вложенный вызов выполняет fn во внешней транзакции, повторяет ее внешний вызов.
+func (m *Manager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*txState); ok {
+		return fn(ctx)
+	}
+
+	for attempt := 1; ; attempt++ {
+		retry, err := m.run(ctx, fn)
+		if err == nil || !retry || attempt >= m.maxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * retryBackoff):
+		}
+	}
+}
+
+func (m *Manager) run(ctx context.Context, fn func(ctx context.Context) error) (retry bool, err error) {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrBeginTx, err)
+	}
+
+	state := &txState{tx: tx}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		_ = tx.Rollback()
+		return state.retryable || IsRetryable(err), err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return IsRetryable(err), fmt.Errorf("%w: %v", ErrCommitTx, err)
+	}
+
+	return false, nil
+}
+
+// IsRetryable проверяет, что транзакция прервана конфликтом и ее можно повторить:
+// serialization_failure (40001) или deadlock_detected (40P01)
+func IsRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code {
+	case "40001", "40P01":
+		return true
+	default:
+		return false
+	}
+}
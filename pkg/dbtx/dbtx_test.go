@@ -0,0 +1,236 @@
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// fakeDriver драйвер database/sql без сервера БД: считает транзакции и возвращает заданные ошибки запросов
+type fakeDriver struct {
+	mu    sync.Mutex
+	dbs   map[string]*fakeDB
+	count int
+}
+
+// fakeDB состояние одной тестовой БД
+type fakeDB struct {
+	mu        sync.Mutex
+	begins    int
+	commits   int
+	rollbacks int
+	execErrs  []error
+}
+
+var testDriver = &fakeDriver{dbs: make(map[string]*fakeDB)}
+
+func init() {
+	sql.Register("dbtx-fake", testDriver)
+}
+
+// newFakeDB открывает отдельную тестовую БД; ошибки execErrs возвращаются по очереди первыми запросами
+func newFakeDB(t *testing.T, execErrs ...error) (*sqlx.DB, *fakeDB) {
+	t.Helper()
+
+	testDriver.mu.Lock()
+	testDriver.count++
+	name := fmt.Sprintf("db%d", testDriver.count)
+	state := &fakeDB{execErrs: execErrs}
+	testDriver.dbs[name] = state
+	testDriver.mu.Unlock()
+
+	db, err := sqlx.Open("dbtx-fake", name)
+	if err != nil {
+		t.Fatalf("open fake db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, state
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return &fakeConn{db: d.dbs[name]}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return &fakeStmt{db: c.db}, nil }
+func (c *fakeConn) Close() error                        { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.begins++
+	return &fakeTx{db: c.db}, nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.commits++
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.rollbacks++
+	return nil
+}
+
+type fakeStmt struct {
+	db *fakeDB
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if len(s.db.execErrs) > 0 {
+		err := s.db.execErrs[0]
+		s.db.execErrs = s.db.execErrs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("query is not supported by fake driver")
+}
+
+func (d *fakeDB) counts() (begins, commits, rollbacks int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.begins, d.commits, d.rollbacks
+}
+
+func TestWithTxRetriesConflicts(t *testing.T) {
+	for _, code := range []pq.ErrorCode{"40001", "40P01"} {
+		t.Run(string(code), func(t *testing.T) {
+			db, state := newFakeDB(t)
+			m := NewManager(db, 5)
+
+			calls := 0
+			err := m.WithTx(context.Background(), func(ctx context.Context) error {
+				calls++
+				if calls < 3 {
+					return &pq.Error{Code: code}
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("WithTx: %v", err)
+			}
+			if calls != 3 {
+				t.Errorf("fn called %d times, want 3", calls)
+			}
+			if begins, commits, rollbacks := state.counts(); begins != 3 || commits != 1 || rollbacks != 2 {
+				t.Errorf("begins=%d commits=%d rollbacks=%d, want 3, 1, 2", begins, commits, rollbacks)
+			}
+		})
+	}
+}
+
+// TestWithTxRetriesWrappedConflict проверяет повтор, когда репозиторий теряет *pq.Error, оборачивая ошибку через %v
+func TestWithTxRetriesWrappedConflict(t *testing.T) {
+	db, state := newFakeDB(t, &pq.Error{Code: "40001"})
+	m := NewManager(db, 5)
+
+	calls := 0
+	err := m.WithTx(context.Background(), func(ctx context.Context) error {
+		calls++
+		if _, err := From(ctx, db).ExecContext(ctx, "UPDATE cars SET is_selected = false"); err != nil {
+			return fmt.Errorf("update cars: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("fn called %d times, want 2", calls)
+	}
+	if begins, commits, rollbacks := state.counts(); begins != 2 || commits != 1 || rollbacks != 1 {
+		t.Errorf("begins=%d commits=%d rollbacks=%d, want 2, 1, 1", begins, commits, rollbacks)
+	}
+}
+
+func TestWithTxStopsAfterMaxAttempts(t *testing.T) {
+	db, state := newFakeDB(t)
+	m := NewManager(db, 3)
+
+	calls := 0
+	err := m.WithTx(context.Background(), func(ctx context.Context) error {
+		calls++
+		return &pq.Error{Code: "40001"}
+	})
+	if !IsRetryable(err) {
+		t.Fatalf("WithTx returned %v, want serialization failure", err)
+	}
+	if calls != 3 {
+		t.Errorf("fn called %d times, want 3", calls)
+	}
+	if begins, commits, rollbacks := state.counts(); begins != 3 || commits != 0 || rollbacks != 3 {
+		t.Errorf("begins=%d commits=%d rollbacks=%d, want 3, 0, 3", begins, commits, rollbacks)
+	}
+}
+
+func TestWithTxDoesNotRetryOtherErrors(t *testing.T) {
+	db, state := newFakeDB(t)
+	m := NewManager(db, 3)
+
+	errFn := errors.New("car not found")
+	calls := 0
+	err := m.WithTx(context.Background(), func(ctx context.Context) error {
+		calls++
+		return errFn
+	})
+	if !errors.Is(err, errFn) {
+		t.Fatalf("WithTx returned %v, want %v", err, errFn)
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+	if begins, commits, rollbacks := state.counts(); begins != 1 || commits != 0 || rollbacks != 1 {
+		t.Errorf("begins=%d commits=%d rollbacks=%d, want 1, 0, 1", begins, commits, rollbacks)
+	}
+}
+
+func TestWithTxNestedReusesOuterTx(t *testing.T) {
+	db, state := newFakeDB(t)
+	m := NewManager(db, 3)
+
+	err := m.WithTx(context.Background(), func(outer context.Context) error {
+		return m.WithTx(outer, func(inner context.Context) error {
+			if From(inner, db) != From(outer, db) {
+				t.Error("nested WithTx started a new transaction")
+			}
+			_, err := From(inner, db).ExecContext(inner, "DELETE FROM cars")
+			return err
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+	if begins, commits, rollbacks := state.counts(); begins != 1 || commits != 1 || rollbacks != 0 {
+		t.Errorf("begins=%d commits=%d rollbacks=%d, want 1, 1, 0", begins, commits, rollbacks)
+	}
+}
@@ -1,37 +1,94 @@
 package main
 
-// import (
-// 	"fmt"
-// 	"os"
-// 	"strconv"
-// 	"time"
-
-// 	"github.com/golang-jwt/jwt/v5"
-// )
-
-// func main() {
-// 	secret := "your-secret-key-change-in-production"
-
-// 	userID := int64(12345678999)
-// 	if len(os.Args) > 1 {
-// 		id, err := strconv.ParseInt(os.Args[1], 10, 64)
-// 		if err == nil {
-// 			userID = id
-// 		}
-// 	}
-
-// 	claims := jwt.MapClaims{
-// 		"tg_user_id": userID,
-// 		"exp":        time.Now().Add(24 * time.Hour).Unix(),
-// 		"iat":        time.Now().Unix(),
-// 	}
-
-// 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-// 	tokenString, err := token.SignedString([]byte(secret))
-// 	if err != nil {
-// 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-// 		os.Exit(1)
-// 	}
-
-// 	fmt.Println(tokenString)
-// }
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/m04kA/SMC-UserService/pkg/jwtkeys"
+)
+
+// gentoken выпускает JWT токен пользователя для локальной разработки и тестирования.
+//
+// Примеры:
+//
+//	go run ./pkg/gentoken -user 123456789
+//	go run ./pkg/gentoken -user 999999999 -role superuser -kid dev
+//	go run ./pkg/gentoken -user 123456789 -alg RS256 -key ./keys/auth.pem -kid 2025-01
+func main() {
+	userID := flag.Int64("user", 12345678999, "Telegram user ID (claim tg_user_id)")
+	role := flag.String("role", "", "роль пользователя (client, manager, superuser); пусто - claim не выставляется")
+	alg := flag.String("alg", jwtkeys.AlgHS256, "алгоритм подписи: HS256, RS256, EdDSA")
+	kid := flag.String("kid", "dev", "идентификатор ключа (заголовок kid); пусто - заголовок не выставляется")
+	secret := flag.String("secret", "", "секрет для HS256 (можно задать через AUTH_JWT_SECRET)")
+	keyFile := flag.String("key", "", "PEM файл приватного ключа для RS256/EdDSA")
+	issuer := flag.String("iss", "", "claim iss")
+	audience := flag.String("aud", "", "claim aud")
+	ttl := flag.Duration("ttl", 24*time.Hour, "время жизни токена")
+	flag.Parse()
+
+	// Обратная совместимость: gentoken <user_id>
+	if flag.NArg() > 0 {
+		id, err := strconv.ParseInt(flag.Arg(0), 10, 64)
+		if err != nil {
+			fail("invalid user ID %q: %v", flag.Arg(0), err)
+		}
+		*userID = id
+	}
+
+	if v := os.Getenv("AUTH_JWT_SECRET"); v != "" {
+		*secret = v
+	}
+
+	var privatePEM []byte
+	if *keyFile != "" {
+		data, err := os.ReadFile(*keyFile)
+		if err != nil {
+			fail("failed to read key file: %v", err)
+		}
+		privatePEM = data
+	}
+
+	method, signingKey, err := jwtkeys.ParseSigningKey(*alg, []byte(*secret), privatePEM)
+	if err != nil {
+		fail("%v", err)
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"tg_user_id": *userID,
+		"sub":        strconv.FormatInt(*userID, 10),
+		"exp":        now.Add(*ttl).Unix(),
+		"iat":        now.Unix(),
+	}
+	if *role != "" {
+		claims["role"] = *role
+	}
+	if *issuer != "" {
+		claims["iss"] = *issuer
+	}
+	if *audience != "" {
+		claims["aud"] = *audience
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	if *kid != "" {
+		token.Header["kid"] = *kid
+	}
+
+	tokenString, err := token.SignedString(signingKey)
+	if err != nil {
+		fail("%v", err)
+	}
+
+	fmt.Println(tokenString)
+}
+
+func fail(format string, v ...interface{}) {
+	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", v...)
+	os.Exit(1)
+}
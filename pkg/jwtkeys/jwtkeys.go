@@ -0,0 +1,145 @@
+package jwtkeys
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+var (
+	ErrUnsupportedAlg = errors.New("unsupported signing algorithm")
+	ErrUnknownKID     = errors.New("unknown key id")
+	ErrMissingKID     = errors.New("token has no kid header and key set has several keys")
+	ErrAlgMismatch    = errors.New("token algorithm does not match key algorithm")
+	ErrEmptyKeySet    = errors.New("key set is empty")
+	ErrDuplicateKID   = errors.New("duplicate key id")
+)
+
+// Key ключ проверки подписи JWT
+type Key struct {
+	ID     string
+	Method jwt.SigningMethod
+	// Verify ключ проверки: []byte для HS256, *rsa.PublicKey для RS256, ed25519.PublicKey для EdDSA
+	Verify interface{}
+}
+
+// KeySet набор ключей проверки с выбором по заголовку kid (ротация ключей)
+type KeySet struct {
+	keys    map[string]Key
+	methods []string
+}
+
+// NewKeySet создает набор ключей
+func NewKeySet(keys ...Key) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, ErrEmptyKeySet
+	}
+
+	set := &KeySet{keys: make(map[string]Key, len(keys))}
+	seen := make(map[string]bool)
+	for _, k := range keys {
+		if _, ok := set.keys[k.ID]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateKID, k.ID)
+		}
+		set.keys[k.ID] = k
+
+		alg := k.Method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			set.methods = append(set.methods, alg)
+		}
+	}
+
+	return set, nil
+}
+
+// Methods возвращает список алгоритмов, допустимых для набора
+func (s *KeySet) Methods() []string {
+	return s.methods
+}
+
+// Keyfunc выбирает ключ проверки для токена (совместима с jwt.Keyfunc)
+func (s *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	var key Key
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if len(s.keys) != 1 {
+			return nil, ErrMissingKID
+		}
+		for _, k := range s.keys {
+			key = k
+		}
+	} else {
+		k, ok := s.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownKID, kid)
+		}
+		key = k
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, ErrAlgMismatch
+	}
+
+	return key.Verify, nil
+}
+
+// ParseVerificationKey создает ключ проверки по алгоритму.
+// Для HS256 используется secret, для RS256 и EdDSA - публичный ключ в формате PEM.
+func ParseVerificationKey(kid, alg string, secret, publicPEM []byte) (Key, error) {
+	switch alg {
+	case AlgHS256:
+		if len(secret) == 0 {
+			return Key{}, fmt.Errorf("key %q: HS256 requires a secret", kid)
+		}
+		return Key{ID: kid, Method: jwt.SigningMethodHS256, Verify: secret}, nil
+	case AlgRS256:
+		pub, err := jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+		if err != nil {
+			return Key{}, fmt.Errorf("key %q: failed to parse RSA public key: %w", kid, err)
+		}
+		return Key{ID: kid, Method: jwt.SigningMethodRS256, Verify: pub}, nil
+	case AlgEdDSA:
+		pub, err := jwt.ParseEdPublicKeyFromPEM(publicPEM)
+		if err != nil {
+			return Key{}, fmt.Errorf("key %q: failed to parse Ed25519 public key: %w", kid, err)
+		}
+		return Key{ID: kid, Method: jwt.SigningMethodEdDSA, Verify: pub}, nil
+	default:
+		return Key{}, fmt.Errorf("%w: %q", ErrUnsupportedAlg, alg)
+	}
+}
+
+// ParseSigningKey создает ключ подписи по алгоритму (используется для выпуска токенов).
+// Для HS256 используется secret, для RS256 и EdDSA - приватный ключ в формате PEM.
+func ParseSigningKey(alg string, secret, privatePEM []byte) (jwt.SigningMethod, interface{}, error) {
+	switch alg {
+	case AlgHS256:
+		if len(secret) == 0 {
+			return nil, nil, errors.New("HS256 requires a secret")
+		}
+		return jwt.SigningMethodHS256, secret, nil
+	case AlgRS256:
+		priv, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse RSA private key: %w", err)
+		}
+		return jwt.SigningMethodRS256, priv, nil
+	case AlgEdDSA:
+		priv, err := jwt.ParseEdPrivateKeyFromPEM(privatePEM)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse Ed25519 private key: %w", err)
+		}
+		return jwt.SigningMethodEdDSA, priv, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnsupportedAlg, alg)
+	}
+}
@@ -0,0 +1,182 @@
+package jwtkeys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func generateRSA(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate RSA key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal RSA public key: %v", err)
+	}
+	return priv, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, jwt.MapClaims{"sub": "42"})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+func TestKeySetKeyfunc(t *testing.T) {
+	rsaPriv, rsaPEM := generateRSA(t)
+
+	hsOld, err := ParseVerificationKey("hs-old", AlgHS256, []byte("old-secret"), nil)
+	if err != nil {
+		t.Fatalf("parse HS256 key: %v", err)
+	}
+	hsNew, err := ParseVerificationKey("hs-new", AlgHS256, []byte("new-secret"), nil)
+	if err != nil {
+		t.Fatalf("parse HS256 key: %v", err)
+	}
+	rs, err := ParseVerificationKey("rs", AlgRS256, nil, rsaPEM)
+	if err != nil {
+		t.Fatalf("parse RS256 key: %v", err)
+	}
+
+	set, err := NewKeySet(hsOld, hsNew, rs)
+	if err != nil {
+		t.Fatalf("NewKeySet: %v", err)
+	}
+	single, err := NewKeySet(hsNew)
+	if err != nil {
+		t.Fatalf("NewKeySet: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		set     *KeySet
+		token   string
+		wantErr error
+	}{
+		{name: "selects old key by kid", set: set, token: sign(t, jwt.SigningMethodHS256, "hs-old", []byte("old-secret"))},
+		{name: "selects new key by kid", set: set, token: sign(t, jwt.SigningMethodHS256, "hs-new", []byte("new-secret"))},
+		{name: "selects RSA key by kid", set: set, token: sign(t, jwt.SigningMethodRS256, "rs", rsaPriv)},
+		{name: "single key without kid", set: single, token: sign(t, jwt.SigningMethodHS256, "", []byte("new-secret"))},
+		{
+			name:    "signed by another key of the set",
+			set:     set,
+			token:   sign(t, jwt.SigningMethodHS256, "hs-new", []byte("old-secret")),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+		},
+		{
+			// Подмена алгоритма: публичный RSA ключ используется как секрет HMAC
+			name:    "HS256 token against RS256 kid",
+			set:     set,
+			token:   sign(t, jwt.SigningMethodHS256, "rs", rsaPEM),
+			wantErr: ErrAlgMismatch,
+		},
+		{
+			name:    "RS256 token against HS256 kid",
+			set:     set,
+			token:   sign(t, jwt.SigningMethodRS256, "hs-new", rsaPriv),
+			wantErr: ErrAlgMismatch,
+		},
+		{
+			name:    "unknown kid",
+			set:     set,
+			token:   sign(t, jwt.SigningMethodHS256, "hs-retired", []byte("old-secret")),
+			wantErr: ErrUnknownKID,
+		},
+		{
+			name:    "no kid with several keys",
+			set:     set,
+			token:   sign(t, jwt.SigningMethodHS256, "", []byte("new-secret")),
+			wantErr: ErrMissingKID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := jwt.NewParser(jwt.WithValidMethods(tt.set.Methods()))
+			_, err := parser.Parse(tt.token, tt.set.Keyfunc)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestNewKeySet(t *testing.T) {
+	hs, err := ParseVerificationKey("k1", AlgHS256, []byte("secret"), nil)
+	if err != nil {
+		t.Fatalf("parse HS256 key: %v", err)
+	}
+
+	if _, err := NewKeySet(); !errors.Is(err, ErrEmptyKeySet) {
+		t.Errorf("NewKeySet() error = %v, want %v", err, ErrEmptyKeySet)
+	}
+	if _, err := NewKeySet(hs, hs); !errors.Is(err, ErrDuplicateKID) {
+		t.Errorf("NewKeySet(duplicate) error = %v, want %v", err, ErrDuplicateKID)
+	}
+
+	set, err := NewKeySet(hs, Key{ID: "k2", Method: jwt.SigningMethodHS256, Verify: []byte("other")})
+	if err != nil {
+		t.Fatalf("NewKeySet: %v", err)
+	}
+	if methods := set.Methods(); len(methods) != 1 || methods[0] != AlgHS256 {
+		t.Errorf("Methods() = %v, want [%s]", methods, AlgHS256)
+	}
+}
+
+func TestParseVerificationKey(t *testing.T) {
+	_, rsaPEM := generateRSA(t)
+
+	tests := []struct {
+		name      string
+		alg       string
+		secret    []byte
+		publicPEM []byte
+		wantErr   bool
+	}{
+		{name: "HS256", alg: AlgHS256, secret: []byte("secret")},
+		{name: "HS256 without secret", alg: AlgHS256, wantErr: true},
+		{name: "RS256", alg: AlgRS256, publicPEM: rsaPEM},
+		{name: "RS256 with bad PEM", alg: AlgRS256, publicPEM: []byte("not a pem"), wantErr: true},
+		{name: "EdDSA with RSA key", alg: AlgEdDSA, publicPEM: rsaPEM, wantErr: true},
+		{name: "unsupported algorithm", alg: "none", secret: []byte("secret"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseVerificationKey("kid", tt.alg, tt.secret, tt.publicPEM)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ParseVerificationKey() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseVerificationKey() error = %v", err)
+			}
+			if key.ID != "kid" || key.Method.Alg() != tt.alg {
+				t.Errorf("ParseVerificationKey() = %s/%s, want kid/%s", key.ID, key.Method.Alg(), tt.alg)
+			}
+		})
+	}
+}
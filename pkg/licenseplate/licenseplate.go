@@ -0,0 +1,167 @@
+package licenseplate
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidPlate  = errors.New("invalid license plate")
+	ErrUnknownFormat = errors.New("unknown license plate format")
+)
+
+// Format тип регистрационного знака по ГОСТ Р 50577
+type Format string
+
+const (
+	FormatPrivate    Format = "private"    // Тип 1: А123ВС77
+	FormatTaxi       Format = "taxi"       // Тип 1А: АВ12377
+	FormatTrailer    Format = "trailer"    // Тип 2: АВ123477
+	FormatMotorcycle Format = "motorcycle" // Тип 4: 1234АВ77
+	FormatDiplomatic Format = "diplomatic" // Типы 9, 10: 001CD177, 001D12377
+	FormatForeign    Format = "foreign"    // Иностранный знак, формат не проверяется
+)
+
+// Formats форматы, в том числе foreign
+var Formats = []Format{FormatPrivate, FormatTaxi, FormatTrailer, FormatMotorcycle, FormatDiplomatic, FormatForeign}
+
+// russianFormats форматы в порядке автоопределения.
+// АВ123477 подходит и под такси (регион 477), и под прицеп (регион 77): выбирается формат,
+// код региона которого отделен пробелом во вводе ("АВ1234 77"), иначе первый подходящий.
+var russianFormats = []Format{FormatPrivate, FormatTaxi, FormatTrailer, FormatMotorcycle, FormatDiplomatic}
+
+// Шаблоны проверяются по нормализованному номеру: буквы ГОСТ приведены к латинским двойникам
+var patterns = map[Format]*regexp.Regexp{
+	FormatPrivate:    regexp.MustCompile(`^([ABEKMHOPCTYX])([0-9]{3})([ABEKMHOPCTYX]{2})([0-9]{2,3})$`),
+	FormatTaxi:       regexp.MustCompile(`^([ABEKMHOPCTYX]{2})([0-9]{3})([0-9]{2,3})$`),
+	FormatTrailer:    regexp.MustCompile(`^([ABEKMHOPCTYX]{2})([0-9]{4})([0-9]{2,3})$`),
+	FormatMotorcycle: regexp.MustCompile(`^([0-9]{4})([ABEKMHOPCTYX]{2})([0-9]{2,3})$`),
+	FormatDiplomatic: regexp.MustCompile(`^([0-9]{3})(CD[0-9]|[DT][0-9]{3})([0-9]{2,3})$`),
+}
+
+// maxForeignLen ограничение длины иностранного знака (колонка license_plate VARCHAR(20))
+const maxForeignLen = 20
+
+// lookalikes кириллические буквы ГОСТ и их латинские двойники
+var lookalikes = map[rune]rune{
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H',
+	'О': 'O', 'Р': 'P', 'С': 'C', 'Т': 'T', 'У': 'Y', 'Х': 'X',
+}
+
+// cyrillic обратное отображение для отображаемой формы российских знаков
+var cyrillic = func() map[rune]rune {
+	m := make(map[rune]rune, len(lookalikes))
+	for c, l := range lookalikes {
+		m[l] = c
+	}
+	return m
+}()
+
+// Plate регистрационный знак в отображаемой и нормализованной форме
+type Plate struct {
+	Display    string // Кириллица ГОСТ, регион через пробел: "А123ВС 77"
+	Normalized string // Без пробелов и дефисов, буквы-двойники латиницей: "A123BC77"
+	Format     Format
+}
+
+// Normalize приводит номер к виду для поиска: верхний регистр, без пробелов и дефисов,
+// кириллические буквы, совпадающие по начертанию с латинскими, заменены латинскими.
+// "а123вс 77", "A123BC 77" и "А123ВС77" дают "A123BC77".
+func Normalize(raw string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(raw) {
+		if unicode.IsSpace(r) || r == '-' {
+			continue
+		}
+		if l, ok := lookalikes[r]; ok {
+			r = l
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Parse проверяет номер по формату format. Пустой format - автоопределение среди российских форматов;
+// иностранный знак нужно указать явно (FormatForeign), для него проверяется только длина.
+func Parse(raw string, format Format) (*Plate, error) {
+	normalized := Normalize(raw)
+	if normalized == "" {
+		return nil, ErrInvalidPlate
+	}
+
+	switch format {
+	case "":
+		return detect(raw, normalized)
+	case FormatForeign:
+		display := strings.Join(strings.Fields(strings.ToUpper(raw)), " ")
+		if len([]rune(display)) > maxForeignLen {
+			return nil, ErrInvalidPlate
+		}
+		return &Plate{Display: display, Normalized: normalized, Format: FormatForeign}, nil
+	}
+
+	if _, known := patterns[format]; !known {
+		return nil, ErrUnknownFormat
+	}
+	plate, ok := parseRussian(normalized, format)
+	if !ok {
+		return nil, ErrInvalidPlate
+	}
+	return plate, nil
+}
+
+// detect определяет российский формат номера
+func detect(raw, normalized string) (*Plate, error) {
+	fields := strings.Fields(raw)
+	region := fields[len(fields)-1]
+
+	var found *Plate
+	for _, f := range russianFormats {
+		plate, ok := parseRussian(normalized, f)
+		if !ok {
+			continue
+		}
+		if len(fields) > 1 && strings.HasSuffix(plate.Display, " "+region) {
+			return plate, nil
+		}
+		if found == nil {
+			found = plate
+		}
+	}
+	if found == nil {
+		return nil, ErrInvalidPlate
+	}
+	return found, nil
+}
+
+// parseRussian проверяет нормализованный номер по шаблону ГОСТ и строит отображаемую форму
+func parseRussian(normalized string, format Format) (*Plate, bool) {
+	groups := patterns[format].FindStringSubmatch(normalized)
+	if groups == nil {
+		return nil, false
+	}
+
+	// Последняя группа - код региона, отделяется пробелом
+	body := strings.Join(groups[1:len(groups)-1], "")
+	if format != FormatDiplomatic {
+		// На дипломатических знаках буквы CD, D, T латинские
+		body = toCyrillic(body)
+	}
+
+	return &Plate{
+		Display:    body + " " + groups[len(groups)-1],
+		Normalized: normalized,
+		Format:     format,
+	}, true
+}
+
+func toCyrillic(s string) string {
+	return strings.Map(func(r rune) rune {
+		if c, ok := cyrillic[r]; ok {
+			return c
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,114 @@
+package licenseplate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{raw: "А123ВС77", want: "A123BC77"},
+		{raw: "A123BC 77", want: "A123BC77"},
+		{raw: "а123вс 777", want: "A123BC777"},
+		// Латиница и кириллица вперемешку: А и С кириллические, B латинская
+		{raw: "А123BС77", want: "A123BC77"},
+		{raw: " а 123 вс-77 ", want: "A123BC77"},
+		{raw: "1234\tАВ 77", want: "1234AB77"},
+		// Кириллические буквы без латинского двойника не заменяются
+		{raw: "Д123ЖЗ77", want: "Д123ЖЗ77"},
+		{raw: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.raw); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name           string
+		raw            string
+		format         Format
+		wantDisplay    string
+		wantNormalized string
+		wantFormat     Format
+		wantErr        error
+	}{
+		// Автоопределение
+		{name: "private", raw: "А123ВС77", wantDisplay: "А123ВС 77", wantNormalized: "A123BC77", wantFormat: FormatPrivate},
+		{name: "private latin lookalikes", raw: "a123bc 777", wantDisplay: "А123ВС 777", wantNormalized: "A123BC777", wantFormat: FormatPrivate},
+		{name: "taxi", raw: "АВ123 77", wantDisplay: "АВ123 77", wantNormalized: "AB12377", wantFormat: FormatTaxi},
+		{name: "motorcycle", raw: "1234 АВ 77", wantDisplay: "1234АВ 77", wantNormalized: "1234AB77", wantFormat: FormatMotorcycle},
+		{name: "diplomatic CD", raw: "001 CD1 77", wantDisplay: "001CD1 77", wantNormalized: "001CD177", wantFormat: FormatDiplomatic},
+		{name: "diplomatic D", raw: "001d123 77", wantDisplay: "001D123 77", wantNormalized: "001D12377", wantFormat: FormatDiplomatic},
+		// АВ123477: такси с регионом 477 или прицеп с регионом 77
+		{name: "ambiguous without space is taxi", raw: "АВ123477", wantDisplay: "АВ123 477", wantNormalized: "AB123477", wantFormat: FormatTaxi},
+		{name: "ambiguous with trailer region", raw: "АВ1234 77", wantDisplay: "АВ1234 77", wantNormalized: "AB123477", wantFormat: FormatTrailer},
+		{name: "ambiguous with taxi region", raw: "АВ123 477", wantDisplay: "АВ123 477", wantNormalized: "AB123477", wantFormat: FormatTaxi},
+		// Явно заданный формат
+		{name: "explicit trailer", raw: "АВ123477", format: FormatTrailer, wantDisplay: "АВ1234 77", wantNormalized: "AB123477", wantFormat: FormatTrailer},
+		{name: "explicit format mismatch", raw: "А123ВС77", format: FormatTaxi, wantErr: ErrInvalidPlate},
+		{name: "foreign", raw: "  ab  1234 cd ", format: FormatForeign, wantDisplay: "AB 1234 CD", wantNormalized: "AB1234CD", wantFormat: FormatForeign},
+		{name: "foreign too long", raw: "ABCDEFGHIJ KLMNOPQRST", format: FormatForeign, wantErr: ErrInvalidPlate},
+		{name: "unknown format", raw: "А123ВС77", format: "bus", wantErr: ErrUnknownFormat},
+		// Ошибки автоопределения
+		{name: "letter without lookalike", raw: "Д123ВС77", wantErr: ErrInvalidPlate},
+		{name: "one-digit region", raw: "А123ВС7", wantErr: ErrInvalidPlate},
+		{name: "foreign is not detected", raw: "AB 1234 CD", wantErr: ErrInvalidPlate},
+		{name: "empty", raw: "  - ", wantErr: ErrInvalidPlate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plate, err := Parse(tt.raw, tt.format)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Parse(%q, %q) error = %v, want %v", tt.raw, tt.format, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q, %q) error = %v", tt.raw, tt.format, err)
+			}
+			if plate.Display != tt.wantDisplay || plate.Normalized != tt.wantNormalized || plate.Format != tt.wantFormat {
+				t.Errorf("Parse(%q, %q) = %+v, want {%s %s %s}", tt.raw, tt.format, *plate, tt.wantDisplay, tt.wantNormalized, tt.wantFormat)
+			}
+		})
+	}
+}
+
+// TestParseBackfillFormats проверяет номера в виде, в котором они хранились до миграции 020:
+// сервис должен определять для них тот же формат и отображаемую форму, что и миграция
+func TestParseBackfillFormats(t *testing.T) {
+	tests := []struct {
+		stored      string
+		wantDisplay string
+		wantFormat  Format
+	}{
+		{stored: "А123ВС77", wantDisplay: "А123ВС 77", wantFormat: FormatPrivate},
+		{stored: "а123вс777", wantDisplay: "А123ВС 777", wantFormat: FormatPrivate},
+		{stored: "A123BC-77", wantDisplay: "А123ВС 77", wantFormat: FormatPrivate},
+		{stored: "АВ12377", wantDisplay: "АВ123 77", wantFormat: FormatTaxi},
+		{stored: "АВ123477", wantDisplay: "АВ123 477", wantFormat: FormatTaxi},
+		{stored: "АВ1234777", wantDisplay: "АВ1234 777", wantFormat: FormatTrailer},
+		{stored: "1234АВ77", wantDisplay: "1234АВ 77", wantFormat: FormatMotorcycle},
+		{stored: "001CD177", wantDisplay: "001CD1 77", wantFormat: FormatDiplomatic},
+		{stored: "001T12377", wantDisplay: "001T123 77", wantFormat: FormatDiplomatic},
+	}
+
+	for _, tt := range tests {
+		plate, err := Parse(tt.stored, "")
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.stored, err)
+			continue
+		}
+		if plate.Display != tt.wantDisplay || plate.Format != tt.wantFormat {
+			t.Errorf("Parse(%q) = %s/%s, want %s/%s", tt.stored, plate.Display, plate.Format, tt.wantDisplay, tt.wantFormat)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+)
+
+// ContentType тип тела запроса JSON Merge Patch (RFC 7396)
+const ContentType = "application/merge-patch+json"
+
+// IsMergePatch проверяет, передано ли тело как JSON Merge Patch
+func IsMergePatch(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == ContentType
+}
+
+// Field поле документа JSON Merge Patch. Различает три состояния:
+// ключ отсутствует (поле не меняется), явный null (поле очищается) и значение.
+type Field[T any] struct {
+	present bool
+	null    bool
+	value   T
+}
+
+// UnmarshalJSON вызывается только для ключей, присутствующих в документе
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	f.present = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.null = true
+		var zero T
+		f.value = zero
+		return nil
+	}
+	f.null = false
+	return json.Unmarshal(data, &f.value)
+}
+
+// Present сообщает, передан ли ключ
+func (f Field[T]) Present() bool {
+	return f.present
+}
+
+// IsNull сообщает, передан ли явный null
+func (f Field[T]) IsNull() bool {
+	return f.present && f.null
+}
+
+// Value возвращает значение, если ключ передан и не равен null
+func (f Field[T]) Value() (T, bool) {
+	return f.value, f.present && !f.null
+}
+
+// Ptr возвращает указатель на значение или nil для null; вызывать только для переданного ключа
+func (f Field[T]) Ptr() *T {
+	if !f.present || f.null {
+		return nil
+	}
+	v := f.value
+	return &v
+}
+
+// IgnoreNull превращает явный null в отсутствующий ключ.
+// Используется для обычного application/json, где null исторически означает "не менять".
+func (f *Field[T]) IgnoreNull() {
+	if f.null {
+		*f = Field[T]{}
+	}
+}
+
+// OptionalValue реализует validator.Optional: отсутствующий ключ не проверяется, null проверяется как пустое значение
+func (f Field[T]) OptionalValue() (any, bool) {
+	if f.null {
+		return nil, f.present
+	}
+	return f.value, f.present
+}
@@ -0,0 +1,141 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type patch struct {
+	Name  Field[string] `json:"name"`
+	Phone Field[string] `json:"phone"`
+	Year  Field[int]    `json:"year"`
+}
+
+func TestFieldUnmarshal(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantPresent bool
+		wantNull    bool
+		wantValue   string
+		wantOK      bool
+	}{
+		{name: "absent", body: `{}`},
+		{name: "null", body: `{"name": null}`, wantPresent: true, wantNull: true},
+		{name: "null with spaces", body: `{"name":  null  }`, wantPresent: true, wantNull: true},
+		{name: "value", body: `{"name": "Ivan"}`, wantPresent: true, wantValue: "Ivan", wantOK: true},
+		// Пустая строка - значение, а не null
+		{name: "empty string", body: `{"name": ""}`, wantPresent: true, wantValue: "", wantOK: true},
+		{name: "string null", body: `{"name": "null"}`, wantPresent: true, wantValue: "null", wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p patch
+			if err := json.Unmarshal([]byte(tt.body), &p); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+
+			if p.Name.Present() != tt.wantPresent || p.Name.IsNull() != tt.wantNull {
+				t.Errorf("Present() = %v, IsNull() = %v, want %v, %v", p.Name.Present(), p.Name.IsNull(), tt.wantPresent, tt.wantNull)
+			}
+			value, ok := p.Name.Value()
+			if value != tt.wantValue || ok != tt.wantOK {
+				t.Errorf("Value() = %q, %v, want %q, %v", value, ok, tt.wantValue, tt.wantOK)
+			}
+			if ptr := p.Name.Ptr(); (ptr != nil) != tt.wantOK || (ptr != nil && *ptr != tt.wantValue) {
+				t.Errorf("Ptr() = %v, want value %v", ptr, tt.wantOK)
+			}
+
+			// Остальные поля в документе отсутствуют
+			if p.Phone.Present() || p.Year.Present() {
+				t.Error("absent fields are reported as present")
+			}
+		})
+	}
+}
+
+func TestFieldUnmarshalTypeMismatch(t *testing.T) {
+	var p patch
+	if err := json.Unmarshal([]byte(`{"year": "2020"}`), &p); err == nil {
+		t.Fatal("Unmarshal() error = nil, want type error")
+	}
+}
+
+// TestFieldNullAfterValue проверяет, что повторный ключ со значением null сбрасывает предыдущее значение
+func TestFieldNullAfterValue(t *testing.T) {
+	var p patch
+	if err := json.Unmarshal([]byte(`{"year": 2020, "year": null}`), &p); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !p.Year.IsNull() {
+		t.Error("IsNull() = false, want true")
+	}
+	if v, ok := p.Year.Value(); ok || v != 0 {
+		t.Errorf("Value() = %d, %v, want 0, false", v, ok)
+	}
+}
+
+func TestFieldIgnoreNull(t *testing.T) {
+	var p patch
+	if err := json.Unmarshal([]byte(`{"name": null, "phone": "+79991234567"}`), &p); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	p.Name.IgnoreNull()
+	p.Phone.IgnoreNull()
+
+	if p.Name.Present() {
+		t.Error("null field is still present after IgnoreNull")
+	}
+	if v, ok := p.Phone.Value(); !ok || v != "+79991234567" {
+		t.Errorf("IgnoreNull changed value: %q, %v", v, ok)
+	}
+}
+
+func TestFieldOptionalValue(t *testing.T) {
+	var p patch
+	if err := json.Unmarshal([]byte(`{"name": null, "year": 2020}`), &p); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		field       interface{ OptionalValue() (any, bool) }
+		wantValue   any
+		wantPresent bool
+	}{
+		{name: "absent", field: p.Phone, wantValue: "", wantPresent: false},
+		{name: "null", field: p.Name, wantValue: nil, wantPresent: true},
+		{name: "value", field: p.Year, wantValue: 2020, wantPresent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, present := tt.field.OptionalValue()
+			if value != tt.wantValue || present != tt.wantPresent {
+				t.Errorf("OptionalValue() = %v, %v, want %v, %v", value, present, tt.wantValue, tt.wantPresent)
+			}
+		})
+	}
+}
+
+func TestIsMergePatch(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{contentType: "application/merge-patch+json", want: true},
+		{contentType: "application/merge-patch+json; charset=utf-8", want: true},
+		{contentType: "Application/Merge-Patch+JSON", want: true},
+		{contentType: "application/json"},
+		{contentType: "application/json-patch+json"},
+		{contentType: ""},
+		{contentType: ";;"},
+	}
+
+	for _, tt := range tests {
+		if got := IsMergePatch(tt.contentType); got != tt.want {
+			t.Errorf("IsMergePatch(%q) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package phonenumber
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidNumber = errors.New("invalid phone number")
+	ErrUnknownRegion = errors.New("unknown phone region")
+)
+
+// Region правила набора национальных номеров страны
+type Region struct {
+	CallingCode string // Код страны без "+"
+	TrunkPrefix string // Префикс выхода на междугороднюю связь внутри страны ("8" в России)
+	NumberLen   int    // Длина национального номера без префикса
+}
+
+// Regions поддерживаемые регионы по умолчанию (ISO 3166-1 alpha-2)
+var Regions = map[string]Region{
+	"RU": {CallingCode: "7", TrunkPrefix: "8", NumberLen: 10},
+	"KZ": {CallingCode: "7", TrunkPrefix: "8", NumberLen: 10},
+	"BY": {CallingCode: "375", TrunkPrefix: "80", NumberLen: 9},
+	"UA": {CallingCode: "380", TrunkPrefix: "0", NumberLen: 9},
+	"US": {CallingCode: "1", TrunkPrefix: "1", NumberLen: 10},
+}
+
+// Normalize приводит номер к формату E.164 (+<код страны><номер>).
+// Пробелы, скобки, дефисы и точки игнорируются; международный префикс "00" заменяется на "+".
+// Номер без кода страны дополняется кодом региона defaultRegion:
+// для RU "8 999 123-45-67", "9991234567" и "7 999 123 45 67" дают "+79991234567".
+func Normalize(raw, defaultRegion string) (string, error) {
+	digits, international, ok := strip(raw)
+	if !ok || digits == "" {
+		return "", ErrInvalidNumber
+	}
+
+	if !international {
+		region, known := Regions[strings.ToUpper(defaultRegion)]
+		if !known {
+			return "", ErrUnknownRegion
+		}
+		national, ok := nationalNumber(digits, region)
+		if !ok {
+			return "", ErrInvalidNumber
+		}
+		digits = region.CallingCode + national
+	}
+
+	// E.164: до 15 цифр, код страны не начинается с 0
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return "", ErrInvalidNumber
+	}
+
+	return "+" + digits, nil
+}
+
+// strip убирает оформление номера и определяет, указан ли код страны
+func strip(raw string) (digits string, international bool, ok bool) {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "+") {
+		international = true
+		raw = raw[1:]
+	}
+
+	var b strings.Builder
+	for _, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+		default:
+			return "", false, false
+		}
+	}
+
+	digits = b.String()
+	if !international && strings.HasPrefix(digits, "00") {
+		international = true
+		digits = digits[2:]
+	}
+
+	return digits, international, true
+}
+
+// nationalNumber выделяет национальный номер из номера, набранного без "+"
+func nationalNumber(digits string, region Region) (string, bool) {
+	switch {
+	case len(digits) == region.NumberLen:
+		return digits, true
+	case region.TrunkPrefix != "" && len(digits) == len(region.TrunkPrefix)+region.NumberLen &&
+		strings.HasPrefix(digits, region.TrunkPrefix):
+		return digits[len(region.TrunkPrefix):], true
+	case len(digits) == len(region.CallingCode)+region.NumberLen && strings.HasPrefix(digits, region.CallingCode):
+		// Код страны указан без "+"
+		return digits[len(region.CallingCode):], true
+	}
+	return "", false
+}
@@ -0,0 +1,71 @@
+package phonenumber
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		region  string
+		want    string
+		wantErr error
+	}{
+		// RU
+		{name: "RU trunk prefix", raw: "8 999 123-45-67", region: "RU", want: "+79991234567"},
+		{name: "RU national number", raw: "9991234567", region: "RU", want: "+79991234567"},
+		{name: "RU calling code without plus", raw: "7 (999) 123 45 67", region: "RU", want: "+79991234567"},
+		{name: "RU international", raw: "+7 999 123-45-67", region: "RU", want: "+79991234567"},
+		{name: "RU international prefix 00", raw: "00 7 999 123 45 67", region: "RU", want: "+79991234567"},
+		{name: "RU dots and spaces", raw: "  8.999.123.45.67 ", region: "RU", want: "+79991234567"},
+		{name: "RU region is case-insensitive", raw: "89991234567", region: "ru", want: "+79991234567"},
+		{name: "RU too short", raw: "999 123-45", region: "RU", wantErr: ErrInvalidNumber},
+		// 10 цифр - национальный номер, даже если он начинается с 8
+		{name: "RU ten digits starting with 8", raw: "8 999 123-45-6", region: "RU", want: "+78999123456"},
+		{name: "RU too long", raw: "8 999 123-45-678", region: "RU", wantErr: ErrInvalidNumber},
+		// KZ
+		{name: "KZ trunk prefix", raw: "8 (701) 234-56-78", region: "KZ", want: "+77012345678"},
+		{name: "KZ national number", raw: "7012345678", region: "KZ", want: "+77012345678"},
+		// BY
+		{name: "BY trunk prefix", raw: "80 29 123-45-67", region: "BY", want: "+375291234567"},
+		{name: "BY national number", raw: "29 123 45 67", region: "BY", want: "+375291234567"},
+		{name: "BY calling code without plus", raw: "375 29 123 45 67", region: "BY", want: "+375291234567"},
+		// UA
+		{name: "UA trunk prefix", raw: "050 123 45 67", region: "UA", want: "+380501234567"},
+		{name: "UA calling code without plus", raw: "380501234567", region: "UA", want: "+380501234567"},
+		// US
+		{name: "US national number", raw: "(415) 555-2671", region: "US", want: "+14155552671"},
+		{name: "US trunk prefix", raw: "1 415 555 2671", region: "US", want: "+14155552671"},
+		// Код страны в номере важнее региона по умолчанию
+		{name: "international number in other region", raw: "+375 29 123-45-67", region: "RU", want: "+375291234567"},
+		{name: "international number without region", raw: "+44 20 7946 0958", region: "", want: "+442079460958"},
+		// Ошибки
+		{name: "unknown region", raw: "8 999 123-45-67", region: "DE", wantErr: ErrUnknownRegion},
+		{name: "letters", raw: "8 999 CALL-NOW", region: "RU", wantErr: ErrInvalidNumber},
+		{name: "empty", raw: "", region: "RU", wantErr: ErrInvalidNumber},
+		{name: "only plus", raw: "+", region: "RU", wantErr: ErrInvalidNumber},
+		{name: "international too short", raw: "+7 999", region: "RU", wantErr: ErrInvalidNumber},
+		{name: "international too long", raw: "+7 999 123 45 67 89 01 2", region: "RU", wantErr: ErrInvalidNumber},
+		{name: "calling code starts with 0", raw: "+0 999 123 45 67", region: "RU", wantErr: ErrInvalidNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.raw, tt.region)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Normalize(%q, %q) error = %v, want %v", tt.raw, tt.region, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Normalize(%q, %q) error = %v", tt.raw, tt.region, err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize(%q, %q) = %q, want %q", tt.raw, tt.region, got, tt.want)
+			}
+		})
+	}
+}
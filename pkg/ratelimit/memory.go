@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	idleTTL time.Duration
+}
+
+// MemoryStore хранит корзины в памяти процесса (подходит для одного инстанса)
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+
+	tokens, result := Take(limit, b.tokens, now.Sub(b.updated))
+	b.tokens = tokens
+	b.updated = now
+	b.idleTTL = limit.FullAfter()
+	return result, nil
+}
+
+// sweep раз в минуту удаляет корзины, которые успели заполниться полностью
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) > b.idleTTL {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
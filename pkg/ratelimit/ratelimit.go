@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit параметры корзины токенов: Burst - емкость, Rate - пополнение (токенов в секунду)
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Every создает лимит requests запросов за period с емкостью burst
+func Every(requests int, period time.Duration, burst int) Limit {
+	if burst <= 0 {
+		burst = requests
+	}
+	return Limit{
+		Rate:  float64(requests) / period.Seconds(),
+		Burst: burst,
+	}
+}
+
+// Result результат попытки взять токен
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration // Через сколько появится токен (только если запрос отклонен)
+	Reset      time.Duration // Через сколько корзина заполнится полностью
+}
+
+// Store хранилище корзин токенов
+type Store interface {
+	// Take пытается взять один токен из корзины key
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Take пополняет корзину с tokens токенами за elapsed и пытается взять из нее один токен.
+// Возвращает новое число токенов и результат.
+func Take(limit Limit, tokens float64, elapsed time.Duration) (float64, Result) {
+	burst := float64(limit.Burst)
+	if elapsed > 0 {
+		tokens = math.Min(burst, tokens+elapsed.Seconds()*limit.Rate)
+	}
+
+	result := Result{Limit: limit.Burst}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - tokens) / limit.Rate)
+	}
+
+	result.Remaining = int(math.Floor(tokens))
+	result.Reset = secondsToDuration((burst - tokens) / limit.Rate)
+	return tokens, result
+}
+
+// FullAfter возвращает время, за которое пустая корзина заполнится полностью
+func (l Limit) FullAfter() time.Duration {
+	return secondsToDuration(float64(l.Burst) / l.Rate)
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,192 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestEvery(t *testing.T) {
+	tests := []struct {
+		name     string
+		requests int
+		period   time.Duration
+		burst    int
+		want     Limit
+	}{
+		{name: "explicit burst", requests: 60, period: time.Minute, burst: 10, want: Limit{Rate: 1, Burst: 10}},
+		{name: "burst defaults to requests", requests: 5, period: time.Second, want: Limit{Rate: 5, Burst: 5}},
+		{name: "slow rate", requests: 1, period: 10 * time.Second, burst: 1, want: Limit{Rate: 0.1, Burst: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Every(tt.requests, tt.period, tt.burst); got != tt.want {
+				t.Errorf("Every() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTake(t *testing.T) {
+	limit := Limit{Rate: 2, Burst: 4}
+
+	tests := []struct {
+		name       string
+		tokens     float64
+		elapsed    time.Duration
+		wantTokens float64
+		want       Result
+	}{
+		{
+			name:       "full bucket",
+			tokens:     4,
+			wantTokens: 3,
+			want:       Result{Allowed: true, Limit: 4, Remaining: 3, Reset: 500 * time.Millisecond},
+		},
+		{
+			name:       "last token",
+			tokens:     1,
+			wantTokens: 0,
+			want:       Result{Allowed: true, Limit: 4, Remaining: 0, Reset: 2 * time.Second},
+		},
+		{
+			name:       "empty bucket",
+			tokens:     0,
+			wantTokens: 0,
+			want:       Result{Limit: 4, Remaining: 0, RetryAfter: 500 * time.Millisecond, Reset: 2 * time.Second},
+		},
+		{
+			name:       "partial refill is not enough",
+			tokens:     0,
+			elapsed:    250 * time.Millisecond,
+			wantTokens: 0.5,
+			want:       Result{Limit: 4, Remaining: 0, RetryAfter: 250 * time.Millisecond, Reset: 1750 * time.Millisecond},
+		},
+		{
+			name:       "refill gives a token",
+			tokens:     0,
+			elapsed:    500 * time.Millisecond,
+			wantTokens: 0,
+			want:       Result{Allowed: true, Limit: 4, Remaining: 0, Reset: 2 * time.Second},
+		},
+		{
+			name:       "refill is capped by burst",
+			tokens:     1,
+			elapsed:    time.Hour,
+			wantTokens: 3,
+			want:       Result{Allowed: true, Limit: 4, Remaining: 3, Reset: 500 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, got := Take(limit, tt.tokens, tt.elapsed)
+			if tokens != tt.wantTokens {
+				t.Errorf("Take() tokens = %v, want %v", tokens, tt.wantTokens)
+			}
+			if got != tt.want {
+				t.Errorf("Take() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeClock управляемое время для MemoryStore
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestStore() (*MemoryStore, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	store := NewMemoryStore()
+	store.now = clock.Now
+	store.lastSweep = clock.now
+	return store, clock
+}
+
+func TestMemoryStoreBurstAndRefill(t *testing.T) {
+	store, clock := newTestStore()
+	ctx := context.Background()
+	limit := Limit{Rate: 1, Burst: 3}
+
+	take := func() Result {
+		t.Helper()
+		result, err := store.Take(ctx, "user:42", limit)
+		if err != nil {
+			t.Fatalf("Take: %v", err)
+		}
+		return result
+	}
+
+	// Новая корзина полная: burst запросов проходят подряд
+	for i := 2; i >= 0; i-- {
+		if result := take(); !result.Allowed || result.Remaining != i {
+			t.Fatalf("request %d: %+v, want allowed with %d remaining", 3-i, result, i)
+		}
+	}
+
+	result := take()
+	if result.Allowed || result.RetryAfter != time.Second || result.Reset != 3*time.Second {
+		t.Fatalf("over burst: %+v, want denied with retry after 1s and reset 3s", result)
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if result := take(); result.Allowed || result.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("after 500ms: %+v, want denied with retry after 500ms", result)
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if result := take(); !result.Allowed || result.Remaining != 0 {
+		t.Fatalf("after 1s: %+v, want allowed with 0 remaining", result)
+	}
+
+	// За долгий простой корзина заполняется только до burst
+	clock.Advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		if result := take(); !result.Allowed {
+			t.Fatalf("request %d after idle: %+v, want allowed", i+1, result)
+		}
+	}
+	if result := take(); result.Allowed {
+		t.Fatalf("request over burst after idle: %+v, want denied", result)
+	}
+}
+
+func TestMemoryStoreKeysAreIndependent(t *testing.T) {
+	store, _ := newTestStore()
+	ctx := context.Background()
+	limit := Limit{Rate: 1, Burst: 1}
+
+	if result, _ := store.Take(ctx, "user:1", limit); !result.Allowed {
+		t.Fatalf("user:1 first request: %+v, want allowed", result)
+	}
+	if result, _ := store.Take(ctx, "user:1", limit); result.Allowed {
+		t.Fatalf("user:1 second request: %+v, want denied", result)
+	}
+	if result, _ := store.Take(ctx, "user:2", limit); !result.Allowed {
+		t.Fatalf("user:2 first request: %+v, want allowed", result)
+	}
+}
+
+func TestMemoryStoreSweepsFullBuckets(t *testing.T) {
+	store, clock := newTestStore()
+	ctx := context.Background()
+
+	store.Take(ctx, "fast", Limit{Rate: 1, Burst: 2})
+	store.Take(ctx, "slow", Limit{Rate: 0.001, Burst: 2})
+
+	// Через минуту "fast" уже полная и удаляется, "slow" еще пополняется
+	clock.Advance(time.Minute + time.Second)
+	store.Take(ctx, "other", Limit{Rate: 1, Burst: 1})
+
+	if _, ok := store.buckets["fast"]; ok {
+		t.Error("full bucket was not swept")
+	}
+	if _, ok := store.buckets["slow"]; !ok {
+		t.Error("refilling bucket was swept")
+	}
+}
@@ -0,0 +1,77 @@
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeySize размер ключа шифрования (AES-256)
+const KeySize = 32
+
+var (
+	ErrInvalidKey        = errors.New("secretbox: key must be 32 bytes encoded in base64")
+	ErrMalformedSealed   = errors.New("secretbox: sealed value is malformed")
+	ErrDecryptionFailure = errors.New("secretbox: failed to decrypt sealed value")
+)
+
+// Box шифрует секреты, которые сервис должен хранить в БД и использовать в открытом виде
+// (например, ключи HMAC подписи), ключом шифрования сервера (KEK) по AES-256-GCM.
+// Утечка БД без KEK не раскрывает секреты.
+type Box struct {
+	aead cipher.AEAD
+}
+
+// New создает Box из ключа длиной KeySize байт
+func New(key []byte) (*Box, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	return &Box{aead: aead}, nil
+}
+
+// ParseKey декодирует ключ из base64 (стандартного или URL-safe, с паддингом или без)
+func ParseKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if key, err := enc.DecodeString(encoded); err == nil && len(key) == KeySize {
+			return key, nil
+		}
+	}
+	return nil, ErrInvalidKey
+}
+
+// Seal шифрует plaintext: nonce || ciphertext.
+// associatedData привязывает шифротекст к записи (например, к key_id): его нельзя перенести в другую строку.
+func (b *Box) Seal(plaintext, associatedData []byte) ([]byte, error) {
+	nonce := make([]byte, b.aead.NonceSize(), b.aead.NonceSize()+len(plaintext)+b.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("secretbox: failed to generate nonce: %w", err)
+	}
+	return b.aead.Seal(nonce, nonce, plaintext, associatedData), nil
+}
+
+// Open расшифровывает значение, полученное из Seal с теми же associatedData
+func (b *Box) Open(sealed, associatedData []byte) ([]byte, error) {
+	if len(sealed) < b.aead.NonceSize()+b.aead.Overhead() {
+		return nil, ErrMalformedSealed
+	}
+	nonce, ciphertext := sealed[:b.aead.NonceSize()], sealed[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, ciphertext, associatedData)
+	if err != nil {
+		return nil, ErrDecryptionFailure
+	}
+	return plaintext, nil
+}
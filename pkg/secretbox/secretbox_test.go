@@ -0,0 +1,123 @@
+package secretbox
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func testKey(fill byte) []byte {
+	return bytes.Repeat([]byte{fill}, KeySize)
+}
+
+func TestParseKey(t *testing.T) {
+	// 0xfb кодируется символами "+" и "/", которые отличают стандартный base64 от URL-safe
+	key := testKey(0xfb)
+
+	tests := []struct {
+		name    string
+		encoded string
+		wantErr bool
+	}{
+		{name: "standard", encoded: base64.StdEncoding.EncodeToString(key)},
+		{name: "standard without padding", encoded: base64.RawStdEncoding.EncodeToString(key)},
+		{name: "url-safe", encoded: base64.URLEncoding.EncodeToString(key)},
+		{name: "url-safe without padding", encoded: base64.RawURLEncoding.EncodeToString(key)},
+		{name: "surrounding spaces", encoded: " " + base64.StdEncoding.EncodeToString(key) + "\n"},
+		{name: "short key", encoded: base64.StdEncoding.EncodeToString(key[:16]), wantErr: true},
+		{name: "long key", encoded: base64.StdEncoding.EncodeToString(append(key, 0)), wantErr: true},
+		{name: "not base64", encoded: "not a base64 key!", wantErr: true},
+		{name: "empty", encoded: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseKey(tt.encoded)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidKey) {
+					t.Fatalf("ParseKey() error = %v, want %v", err, ErrInvalidKey)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseKey() error = %v", err)
+			}
+			if !bytes.Equal(got, key) {
+				t.Errorf("ParseKey() = %x, want %x", got, key)
+			}
+		})
+	}
+}
+
+func TestNewRejectsBadKeyLength(t *testing.T) {
+	for _, size := range []int{0, 16, 24, 31, 33} {
+		if _, err := New(make([]byte, size)); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("New(%d bytes) error = %v, want %v", size, err, ErrInvalidKey)
+		}
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	box, err := New(testKey(1))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	otherBox, err := New(testKey(2))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	plaintext := []byte("hmac signing key")
+	ad := []byte("key_id=svc_1")
+	sealed, err := box.Seal(plaintext, ad)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	// Случайный nonce: повторное шифрование дает другой шифротекст
+	again, err := box.Seal(plaintext, ad)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if bytes.Equal(sealed, again) {
+		t.Error("Seal() returned the same value twice")
+	}
+
+	tampered := bytes.Clone(sealed)
+	tampered[len(tampered)-1] ^= 0x01
+
+	tests := []struct {
+		name    string
+		box     *Box
+		sealed  []byte
+		ad      []byte
+		wantErr error
+	}{
+		{name: "round trip", box: box, sealed: sealed, ad: ad},
+		{name: "wrong KEK", box: otherBox, sealed: sealed, ad: ad, wantErr: ErrDecryptionFailure},
+		{name: "other associated data", box: box, sealed: sealed, ad: []byte("key_id=svc_2"), wantErr: ErrDecryptionFailure},
+		{name: "tampered ciphertext", box: box, sealed: tampered, ad: ad, wantErr: ErrDecryptionFailure},
+		{name: "truncated ciphertext", box: box, sealed: sealed[:len(sealed)-1], ad: ad, wantErr: ErrDecryptionFailure},
+		{name: "shorter than nonce and tag", box: box, sealed: sealed[:20], ad: ad, wantErr: ErrMalformedSealed},
+		{name: "empty", box: box, sealed: nil, ad: ad, wantErr: ErrMalformedSealed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.box.Open(tt.sealed, tt.ad)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Open() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Errorf("Open() = %q, want %q", got, plaintext)
+			}
+		})
+	}
+}
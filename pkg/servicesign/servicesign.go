@@ -0,0 +1,65 @@
+package servicesign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Заголовки подписанного межсервисного запроса
+const (
+	HeaderKeyID     = "X-Service-Key-ID"
+	HeaderTimestamp = "X-Service-Timestamp"
+	HeaderNonce     = "X-Service-Nonce"
+	HeaderSignature = "X-Service-Signature"
+)
+
+// SigningKey выводит ключ подписи из секрета сервиса: SHA-256(secret).
+// Открытый секрет не сохраняется и не передается в запросах, но результат - сам ключ HMAC,
+// поэтому хранить его можно только зашифрованным (см. pkg/secretbox), а не как хеш для сравнения.
+func SigningKey(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
+
+// CanonicalString формирует строку для подписи:
+// METHOD\nPATH?QUERY\nTIMESTAMP\nNONCE\nhex(SHA-256(body))
+func CanonicalString(method, pathWithQuery string, timestamp int64, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		pathWithQuery,
+		strconv.FormatInt(timestamp, 10),
+		nonce,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+}
+
+// Sign вычисляет подпись запроса (hex HMAC-SHA256)
+func Sign(signingKey []byte, method, pathWithQuery string, timestamp int64, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write([]byte(CanonicalString(method, pathWithQuery, timestamp, nonce, body)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify проверяет подпись запроса за постоянное время
+func Verify(signingKey []byte, signature, method, pathWithQuery string, timestamp int64, nonce string, body []byte) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	expected, _ := hex.DecodeString(Sign(signingKey, method, pathWithQuery, timestamp, nonce, body))
+	return hmac.Equal(got, expected)
+}
+
+// WithinSkew проверяет, что метка времени запроса отличается от now не более чем на skew
+func WithinSkew(timestamp int64, now time.Time, skew time.Duration) bool {
+	diff := now.Sub(time.Unix(timestamp, 0))
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= skew
+}
@@ -0,0 +1,98 @@
+package servicesign
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	key := SigningKey("service-secret")
+	const (
+		method    = "POST"
+		path      = "/internal/users:batchGet?include=cars"
+		timestamp = int64(1_700_000_000)
+		nonce     = "6f1c2a9e"
+	)
+	body := []byte(`{"tg_user_ids":[1,2,3]}`)
+	signature := Sign(key, method, path, timestamp, nonce, body)
+
+	tests := []struct {
+		name      string
+		key       []byte
+		signature string
+		method    string
+		path      string
+		timestamp int64
+		nonce     string
+		body      []byte
+		want      bool
+	}{
+		{name: "round trip", want: true},
+		{name: "method case is ignored", method: "post", want: true},
+		{name: "changed body", body: []byte(`{"tg_user_ids":[1,2,4]}`)},
+		{name: "empty body", body: []byte{}},
+		{name: "changed path", path: "/internal/users:batchDelete?include=cars"},
+		{name: "changed query", path: "/internal/users:batchGet?include=phones"},
+		{name: "changed method", method: "PUT"},
+		{name: "changed timestamp", timestamp: timestamp + 1},
+		{name: "changed nonce", nonce: "6f1c2a9f"},
+		{name: "other signing key", key: SigningKey("other-secret")},
+		{name: "signature is not hex", signature: "zz" + signature[2:]},
+		{name: "truncated signature", signature: signature[:len(signature)-2]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Незаданные поля берутся из подписанного запроса
+			k, sig, m, p, ts, n, b := key, signature, method, path, timestamp, nonce, body
+			if tt.key != nil {
+				k = tt.key
+			}
+			if tt.signature != "" {
+				sig = tt.signature
+			}
+			if tt.method != "" {
+				m = tt.method
+			}
+			if tt.path != "" {
+				p = tt.path
+			}
+			if tt.timestamp != 0 {
+				ts = tt.timestamp
+			}
+			if tt.nonce != "" {
+				n = tt.nonce
+			}
+			if tt.body != nil {
+				b = tt.body
+			}
+
+			if got := Verify(k, sig, m, p, ts, n, b); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithinSkew(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name      string
+		timestamp int64
+		want      bool
+	}{
+		{name: "same time", timestamp: now.Unix(), want: true},
+		{name: "past within skew", timestamp: now.Unix() - 300, want: true},
+		{name: "future within skew", timestamp: now.Unix() + 300, want: true},
+		{name: "past beyond skew", timestamp: now.Unix() - 301},
+		{name: "future beyond skew", timestamp: now.Unix() + 301},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WithinSkew(tt.timestamp, now, 5*time.Minute); got != tt.want {
+				t.Errorf("WithinSkew() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
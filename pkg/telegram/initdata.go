@@ -0,0 +1,125 @@
+package telegram
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInitDataMalformed = errors.New("init data is malformed")
+	ErrInitDataSignature = errors.New("init data signature is invalid")
+	ErrInitDataExpired   = errors.New("init data is expired")
+	ErrInitDataNoUser    = errors.New("init data has no user")
+)
+
+// User профиль пользователя Telegram из initData Mini App
+type User struct {
+	ID           int64  `json:"id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name,omitempty"`
+	Username     string `json:"username,omitempty"`
+	LanguageCode string `json:"language_code,omitempty"`
+	IsPremium    bool   `json:"is_premium,omitempty"`
+}
+
+// FullName возвращает имя и фамилию через пробел
+func (u User) FullName() string {
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
+// InitData проверенные данные запуска Telegram Mini App
+type InitData struct {
+	QueryID  string
+	User     User
+	AuthDate time.Time
+}
+
+// Validator проверяет подпись initData ключом бота
+type Validator struct {
+	secretKey []byte
+	maxAge    time.Duration
+	now       func() time.Time
+}
+
+// NewValidator создает валидатор initData.
+// maxAge - окно свежести auth_date, 0 - не проверять.
+func NewValidator(botToken string, maxAge time.Duration) *Validator {
+	// secret_key = HMAC_SHA256(key="WebAppData", msg=bot_token)
+	mac := hmac.New(sha256.New, []byte("WebAppData"))
+	mac.Write([]byte(botToken))
+
+	return &Validator{
+		secretKey: mac.Sum(nil),
+		maxAge:    maxAge,
+		now:       time.Now,
+	}
+}
+
+// Validate проверяет подпись и свежесть initData и возвращает профиль пользователя.
+// См. https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app
+func (v *Validator) Validate(raw string) (*InitData, error) {
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInitDataMalformed, err)
+	}
+
+	receivedHash := values.Get("hash")
+	if receivedHash == "" {
+		return nil, fmt.Errorf("%w: missing hash", ErrInitDataMalformed)
+	}
+
+	// data_check_string: отсортированные пары key=value кроме hash, разделенные \n
+	pairs := make([]string, 0, len(values))
+	for key := range values {
+		if key == "hash" {
+			continue
+		}
+		pairs = append(pairs, key+"="+values.Get(key))
+	}
+	sort.Strings(pairs)
+
+	mac := hmac.New(sha256.New, v.secretKey)
+	mac.Write([]byte(strings.Join(pairs, "\n")))
+	expected := mac.Sum(nil)
+
+	got, err := hex.DecodeString(receivedHash)
+	if err != nil || !hmac.Equal(got, expected) {
+		return nil, ErrInitDataSignature
+	}
+
+	authDateUnix, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid auth_date", ErrInitDataMalformed)
+	}
+	authDate := time.Unix(authDateUnix, 0)
+	if v.maxAge > 0 && v.now().Sub(authDate) > v.maxAge {
+		return nil, ErrInitDataExpired
+	}
+
+	rawUser := values.Get("user")
+	if rawUser == "" {
+		return nil, ErrInitDataNoUser
+	}
+	var user User
+	if err := json.Unmarshal([]byte(rawUser), &user); err != nil {
+		return nil, fmt.Errorf("%w: invalid user: %v", ErrInitDataMalformed, err)
+	}
+	if user.ID == 0 {
+		return nil, ErrInitDataNoUser
+	}
+
+	return &InitData{
+		QueryID:  values.Get("query_id"),
+		User:     user,
+		AuthDate: authDate,
+	}, nil
+}
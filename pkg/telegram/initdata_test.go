@@ -0,0 +1,171 @@
+package telegram
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testBotToken = "123456:TEST-bot-token"
+
+// signInitData подписывает поля так же, как Telegram: HMAC data_check_string ключом из токена бота
+func signInitData(botToken string, fields map[string]string) url.Values {
+	secret := hmac.New(sha256.New, []byte("WebAppData"))
+	secret.Write([]byte(botToken))
+
+	pairs := make([]string, 0, len(fields))
+	values := url.Values{}
+	for key, value := range fields {
+		pairs = append(pairs, key+"="+value)
+		values.Set(key, value)
+	}
+	sort.Strings(pairs)
+
+	mac := hmac.New(sha256.New, secret.Sum(nil))
+	mac.Write([]byte(strings.Join(pairs, "\n")))
+	values.Set("hash", hex.EncodeToString(mac.Sum(nil)))
+	return values
+}
+
+func TestValidatorValidate(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	fields := func() map[string]string {
+		return map[string]string{
+			"query_id":  "AAH-query",
+			"auth_date": "1699999900",
+			"user":      `{"id":42,"first_name":"Ivan","last_name":"Petrov","username":"ivan"}`,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		botToken string
+		raw      func() string
+		wantErr  error
+	}{
+		{
+			name: "valid hash",
+			raw:  func() string { return signInitData(testBotToken, fields()).Encode() },
+		},
+		{
+			name: "tampered field",
+			raw: func() string {
+				values := signInitData(testBotToken, fields())
+				values.Set("user", `{"id":43,"first_name":"Ivan"}`)
+				return values.Encode()
+			},
+			wantErr: ErrInitDataSignature,
+		},
+		{
+			name:     "wrong bot token",
+			botToken: "654321:OTHER-bot-token",
+			raw:      func() string { return signInitData(testBotToken, fields()).Encode() },
+			wantErr:  ErrInitDataSignature,
+		},
+		{
+			name: "expired auth_date",
+			raw: func() string {
+				f := fields()
+				f["auth_date"] = "1699990000"
+				return signInitData(testBotToken, f).Encode()
+			},
+			wantErr: ErrInitDataExpired,
+		},
+		{
+			name: "missing hash",
+			raw: func() string {
+				values := signInitData(testBotToken, fields())
+				values.Del("hash")
+				return values.Encode()
+			},
+			wantErr: ErrInitDataMalformed,
+		},
+		{
+			name: "hash is not hex",
+			raw: func() string {
+				values := signInitData(testBotToken, fields())
+				values.Set("hash", "not-a-hex-hash")
+				return values.Encode()
+			},
+			wantErr: ErrInitDataSignature,
+		},
+		{
+			name: "malformed user JSON",
+			raw: func() string {
+				f := fields()
+				f["user"] = `{"id":42,"first_name":`
+				return signInitData(testBotToken, f).Encode()
+			},
+			wantErr: ErrInitDataMalformed,
+		},
+		{
+			name: "missing user",
+			raw: func() string {
+				f := fields()
+				delete(f, "user")
+				return signInitData(testBotToken, f).Encode()
+			},
+			wantErr: ErrInitDataNoUser,
+		},
+		{
+			name: "user without id",
+			raw: func() string {
+				f := fields()
+				f["user"] = `{"first_name":"Ivan"}`
+				return signInitData(testBotToken, f).Encode()
+			},
+			wantErr: ErrInitDataNoUser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			botToken := tt.botToken
+			if botToken == "" {
+				botToken = testBotToken
+			}
+			v := NewValidator(botToken, time.Hour)
+			v.now = func() time.Time { return now }
+
+			data, err := v.Validate(tt.raw())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			if data.User.ID != 42 || data.User.FullName() != "Ivan Petrov" || data.User.Username != "ivan" {
+				t.Errorf("Validate() user = %+v", data.User)
+			}
+			if data.QueryID != "AAH-query" {
+				t.Errorf("Validate() query_id = %q, want %q", data.QueryID, "AAH-query")
+			}
+			if !data.AuthDate.Equal(time.Unix(1_699_999_900, 0)) {
+				t.Errorf("Validate() auth_date = %v", data.AuthDate)
+			}
+		})
+	}
+}
+
+// TestValidatorValidateNoMaxAge проверяет, что при maxAge = 0 свежесть auth_date не проверяется
+func TestValidatorValidateNoMaxAge(t *testing.T) {
+	v := NewValidator(testBotToken, 0)
+	raw := signInitData(testBotToken, map[string]string{
+		"auth_date": "1",
+		"user":      `{"id":42,"first_name":"Ivan"}`,
+	}).Encode()
+
+	if _, err := v.Validate(raw); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+}
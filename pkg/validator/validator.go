@@ -0,0 +1,274 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Коды ошибок полей
+const (
+	CodeRequired = "required"
+	CodeTooShort = "too_short"
+	CodeTooLong  = "too_long"
+	CodeFormat   = "invalid_format"
+	CodeOneOf    = "not_allowed"
+)
+
+// FieldError ошибка проверки одного поля; Field - имя поля из json тега.
+// Rule и Param нужны для перевода Message: Rule - правило с уточнением типа значения
+// (required, max.string, min.items, e164, oneof, ...), Param - параметр правила.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Rule    string `json:"-"`
+	Param   string `json:"-"`
+}
+
+// Errors ошибки проверки всех полей структуры
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	parts := make([]string, 0, len(e))
+	for _, fe := range e {
+		parts = append(parts, fe.Field+": "+fe.Message)
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+// IsValidationError сообщает, содержит ли цепочка ошибок Errors
+func IsValidationError(err error) bool {
+	var errs Errors
+	return errors.As(err, &errs)
+}
+
+// Optional поле, которое может отсутствовать в запросе (например, mergepatch.Field).
+// Отсутствующее поле не проверяется, явный null проверяется как пустое значение.
+type Optional interface {
+	OptionalValue() (value any, present bool)
+}
+
+var (
+	optionalType = reflect.TypeOf((*Optional)(nil)).Elem()
+	e164Pattern  = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+)
+
+// Struct проверяет поля структуры по тегу validate и возвращает Errors или nil.
+// Поддерживаемые правила (через запятую):
+//   - required - значение не пустое (строка из пробелов считается пустой)
+//   - omitempty - пустое значение не проверяется остальными правилами
+//   - min=N, max=N - длина строки в символах, длина среза или значение числа
+//   - e164 - номер телефона в формате E.164
+//   - oneof=a b c - одно из перечисленных значений
+//   - dive - следующие правила применяются к каждому элементу среза
+//
+// Вложенные структуры проверяются рекурсивно, имя поля получает префикс родителя.
+// Неизвестное правило - ошибка программиста, вызывает панику.
+func Struct(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validator: expected struct, got %s", rv.Kind()))
+	}
+
+	var errs Errors
+	validateStruct(rv, "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func validateStruct(rv reflect.Value, prefix string, errs *Errors) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, skip := fieldName(sf)
+		if skip {
+			continue
+		}
+		fv := rv.Field(i)
+
+		// Встроенные структуры проверяются без префикса
+		if sf.Anonymous && fv.Kind() == reflect.Struct {
+			validateStruct(fv, prefix, errs)
+			continue
+		}
+
+		tag := sf.Tag.Get("validate")
+		if tag == "-" {
+			continue
+		}
+		field := prefix + name
+		if tag != "" {
+			validateValue(fv, field, strings.Split(tag, ","), errs)
+			continue
+		}
+		if nested, ok := nestedStruct(fv); ok {
+			validateStruct(nested, field+".", errs)
+		}
+	}
+}
+
+// fieldName возвращает имя поля из json тега
+func fieldName(sf reflect.StructField) (string, bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, false
+	}
+	return sf.Name, false
+}
+
+func nestedStruct(fv reflect.Value) (reflect.Value, bool) {
+	for fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return reflect.Value{}, false
+		}
+		fv = fv.Elem()
+	}
+	if fv.Kind() != reflect.Struct || fv.Type().Implements(optionalType) {
+		return reflect.Value{}, false
+	}
+	return fv, true
+}
+
+func validateValue(fv reflect.Value, field string, rules []string, errs *Errors) {
+	// Optional и указатели раскрываем до значения; nil означает пустое значение
+	if fv.CanInterface() {
+		if opt, ok := fv.Interface().(Optional); ok {
+			value, present := opt.OptionalValue()
+			if !present {
+				return
+			}
+			fv = reflect.ValueOf(value)
+		}
+	}
+	for fv.IsValid() && fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			fv = reflect.Value{}
+			break
+		}
+		fv = fv.Elem()
+	}
+
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "":
+		case "omitempty":
+			if isEmpty(fv) {
+				return
+			}
+		case "required":
+			if isEmpty(fv) {
+				*errs = append(*errs, FieldError{Field: field, Code: CodeRequired, Message: "is required", Rule: "required"})
+				return
+			}
+		case "dive":
+			if !fv.IsValid() || (fv.Kind() != reflect.Slice && fv.Kind() != reflect.Array) {
+				panic(fmt.Sprintf("validator: dive on non-slice field %s", field))
+			}
+			for j := 0; j < fv.Len(); j++ {
+				validateValue(fv.Index(j), fmt.Sprintf("%s[%d]", field, j), rules[i+1:], errs)
+			}
+			return
+		default:
+			if !fv.IsValid() {
+				continue
+			}
+			if fe, ok := checkRule(fv, field, name, param); !ok {
+				*errs = append(*errs, fe)
+				return
+			}
+		}
+	}
+}
+
+func checkRule(fv reflect.Value, field, name, param string) (FieldError, bool) {
+	switch name {
+	case "min", "max":
+		limit, err := strconv.Atoi(param)
+		if err != nil {
+			panic(fmt.Sprintf("validator: invalid %s parameter %q on field %s", name, param, field))
+		}
+		size, kind := measure(fv, field)
+		if name == "min" && size < int64(limit) {
+			return FieldError{Field: field, Code: CodeTooShort, Message: fmt.Sprintf("must be at least %d%s", limit, units[kind]),
+				Rule: "min." + kind, Param: param}, false
+		}
+		if name == "max" && size > int64(limit) {
+			return FieldError{Field: field, Code: CodeTooLong, Message: fmt.Sprintf("must be at most %d%s", limit, units[kind]),
+				Rule: "max." + kind, Param: param}, false
+		}
+	case "e164":
+		if fv.Kind() != reflect.String || !e164Pattern.MatchString(fv.String()) {
+			return FieldError{Field: field, Code: CodeFormat, Message: "must be a phone number in E.164 format", Rule: "e164"}, false
+		}
+	case "oneof":
+		allowed := strings.Fields(param)
+		value := fmt.Sprint(fv.Interface())
+		for _, a := range allowed {
+			if value == a {
+				return FieldError{}, true
+			}
+		}
+		return FieldError{Field: field, Code: CodeOneOf, Message: "must be one of: " + strings.Join(allowed, ", "),
+			Rule: "oneof", Param: strings.Join(allowed, ", ")}, false
+	default:
+		panic(fmt.Sprintf("validator: unknown rule %q on field %s", name, field))
+	}
+	return FieldError{}, true
+}
+
+// Rules ключи FieldError.Rule, для которых нужен перевод сообщения
+var Rules = []string{
+	"required", "e164", "oneof",
+	"min.string", "min.items", "min.number",
+	"max.string", "max.items", "max.number",
+}
+
+var units = map[string]string{"string": " characters", "items": " items", "number": ""}
+
+// measure возвращает длину строки в символах, длину среза или значение числа и вид значения
+func measure(fv reflect.Value, field string) (int64, string) {
+	switch fv.Kind() {
+	case reflect.String:
+		return int64(utf8.RuneCountInString(fv.String())), "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return int64(fv.Len()), "items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fv.Int(), "number"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(fv.Uint()), "number"
+	}
+	panic(fmt.Sprintf("validator: min/max on unsupported field %s of kind %s", field, fv.Kind()))
+}
+
+func isEmpty(fv reflect.Value) bool {
+	if !fv.IsValid() {
+		return true
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(fv.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return fv.Len() == 0
+	}
+	return fv.IsZero()
+}